@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types specific to Cassandra managed resources.
+const (
+	// TypeQuota indicates whether a resource fits within the quota of its
+	// ProviderConfig.
+	TypeQuota xpv1.ConditionType = "Quota"
+
+	// TypeUpToDate indicates whether the observed state of a resource matches
+	// its desired state.
+	TypeUpToDate xpv1.ConditionType = "UpToDate"
+
+	// TypeAuthorized indicates whether the ProviderConfig credentials are
+	// allowed to manage a resource as specified.
+	TypeAuthorized xpv1.ConditionType = "Authorized"
+
+	// TypeOwnership indicates whether a resource is the only one managing
+	// its external resource.
+	TypeOwnership xpv1.ConditionType = "Ownership"
+
+	// TypeDependencies indicates whether the keyspaces and roles a resource
+	// refers to exist.
+	TypeDependencies xpv1.ConditionType = "Dependencies"
+
+	// TypePolicy indicates whether a resource complies with the policies of
+	// its ProviderConfig.
+	TypePolicy xpv1.ConditionType = "Policy"
+
+	// TypePassword indicates whether the password of a role is the one the
+	// provider set.
+	TypePassword xpv1.ConditionType = "Password"
+)
+
+// Reasons for Cassandra specific conditions.
+const (
+	ReasonWithinQuota   xpv1.ConditionReason = "WithinQuota"
+	ReasonQuotaExceeded xpv1.ConditionReason = "QuotaExceeded"
+	ReasonInSync        xpv1.ConditionReason = "InSync"
+	ReasonOutOfSync     xpv1.ConditionReason = "OutOfSync"
+	ReasonAuthorized    xpv1.ConditionReason = "Authorized"
+
+	ReasonSuperUserRequired      xpv1.ConditionReason = "SuperUserRequired"
+	ReasonRolesUnreadable        xpv1.ConditionReason = "RolesUnreadable"
+	ReasonInsufficientPrivileges xpv1.ConditionReason = "InsufficientPrivileges"
+	ReasonExclusiveOwnership     xpv1.ConditionReason = "ExclusiveOwnership"
+	ReasonConflictingOwnership   xpv1.ConditionReason = "ConflictingOwnership"
+	ReasonDependenciesFound      xpv1.ConditionReason = "DependenciesFound"
+	ReasonKeyspaceNotFound       xpv1.ConditionReason = "KeyspaceNotFound"
+	ReasonRoleNotFound           xpv1.ConditionReason = "RoleNotFound"
+	ReasonWithinPolicy           xpv1.ConditionReason = "WithinPolicy"
+	ReasonSuperUserForbidden     xpv1.ConditionReason = "SuperUserForbidden"
+	ReasonSchemaPropagating      xpv1.ConditionReason = "SchemaPropagating"
+	ReasonReferencesUnresolved   xpv1.ConditionReason = "ReferencesUnresolved"
+	ReasonPasswordManaged        xpv1.ConditionReason = "PasswordManaged"
+	ReasonPasswordChanged        xpv1.ConditionReason = "PasswordChangedExternally"
+	ReasonPasswordUnobservable   xpv1.ConditionReason = "PasswordUnobservable"
+)
+
+// WithinQuota returns a condition that indicates the resource fits within
+// the quota of its ProviderConfig.
+func WithinQuota() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeQuota,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinQuota,
+	}
+}
+
+// QuotaExceeded returns a condition that indicates the resource was not
+// created because doing so would exceed the quota of its ProviderConfig.
+func QuotaExceeded(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeQuota,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonQuotaExceeded,
+		Message:            msg,
+	}
+}
+
+// InSync returns a condition that indicates the observed state of the
+// resource matches its desired state.
+func InSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpToDate,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInSync,
+	}
+}
+
+// OutOfSync returns a condition that indicates the observed state of the
+// resource has drifted from its desired state.
+func OutOfSync(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpToDate,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOutOfSync,
+		Message:            msg,
+	}
+}
+
+// Authorized returns a condition that indicates the ProviderConfig credentials
+// are allowed to manage the resource as specified.
+func Authorized() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAuthorized,
+	}
+}
+
+// SuperUserRequired returns a condition that indicates the resource could not
+// be managed because doing so requires superuser credentials.
+func SuperUserRequired(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSuperUserRequired,
+		Message:            msg,
+	}
+}
+
+// RolesUnreadable returns a condition that indicates the resource could not be
+// observed because the ProviderConfig credentials may not read roles.
+func RolesUnreadable(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRolesUnreadable,
+		Message:            msg,
+	}
+}
+
+// InsufficientPrivileges returns a condition that indicates the resource could
+// not be observed because the ProviderConfig credentials may not read the
+// schema of its keyspace.
+func InsufficientPrivileges(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthorized,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInsufficientPrivileges,
+		Message:            msg,
+	}
+}
+
+// ExclusiveOwnership returns a condition that indicates the resource is the
+// only one managing its external resource.
+func ExclusiveOwnership() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOwnership,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExclusiveOwnership,
+	}
+}
+
+// ConflictingOwnership returns a condition that indicates the resource is
+// not reconciled because other resources manage the same external resource.
+func ConflictingOwnership(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOwnership,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConflictingOwnership,
+		Message:            msg,
+	}
+}
+
+// DependenciesFound returns a condition that indicates the keyspaces and roles
+// the resource refers to exist.
+func DependenciesFound() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencies,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesFound,
+	}
+}
+
+// KeyspaceNotFound returns a condition that indicates a keyspace the resource
+// refers to does not exist.
+func KeyspaceNotFound(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencies,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonKeyspaceNotFound,
+		Message:            msg,
+	}
+}
+
+// RoleNotFound returns a condition that indicates a role the resource refers
+// to does not exist.
+func RoleNotFound(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencies,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRoleNotFound,
+		Message:            msg,
+	}
+}
+
+// ReferencesUnresolved returns a condition that indicates a role or keyspace
+// the resource references has not been resolved yet.
+func ReferencesUnresolved(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDependencies,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferencesUnresolved,
+		Message:            msg,
+	}
+}
+
+// WithinPolicy returns a condition that indicates the resource complies with
+// the policies of its ProviderConfig.
+func WithinPolicy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePolicy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWithinPolicy,
+	}
+}
+
+// SuperUserForbidden returns a condition that indicates the role is not
+// managed because its ProviderConfig does not allow superuser roles.
+func SuperUserForbidden(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePolicy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSuperUserForbidden,
+		Message:            msg,
+	}
+}
+
+// SchemaPropagating returns a condition that indicates a resource exists but
+// is not ready because its schema is not yet visible in every datacenter.
+func SchemaPropagating(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSchemaPropagating,
+		Message:            msg,
+	}
+}
+
+// PasswordManaged returns a condition that indicates the password of the role
+// is the one the provider set.
+func PasswordManaged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePassword,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordManaged,
+	}
+}
+
+// PasswordChanged returns a condition that indicates the password of the role
+// was changed by others than the provider.
+func PasswordChanged(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePassword,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordChanged,
+		Message:            msg,
+	}
+}
+
+// PasswordUnobservable returns a condition that indicates changes of the
+// password of the role cannot be detected, since its salted hash may not be
+// selected.
+func PasswordUnobservable(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePassword,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordUnobservable,
+		Message:            msg,
+	}
+}
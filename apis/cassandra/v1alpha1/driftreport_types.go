@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A DriftEntry is a field of a managed resource whose observed value differs
+// from its desired value.
+type DriftEntry struct {
+	// Kind of the managed resource, e.g. Keyspace.
+	Kind string `json:"kind"`
+
+	// Name of the managed resource.
+	Name string `json:"name"`
+
+	// Field that drifted, e.g. replicationFactor.
+	Field string `json:"field"`
+
+	// Desired value of the field, if it has one.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Observed value of the field, if it has one.
+	// +optional
+	Observed string `json:"observed,omitempty"`
+
+	// FirstObservedTime is when the drift was first observed.
+	FirstObservedTime metav1.Time `json:"firstObservedTime"`
+
+	// LastObservedTime is when the drift was last observed. It is refreshed
+	// every few minutes while the drift persists.
+	LastObservedTime metav1.Time `json:"lastObservedTime"`
+}
+
+// A DriftReportStatus lists the drift of the managed resources using a
+// ProviderConfig.
+type DriftReportStatus struct {
+	// Drift lists the drifted fields, sorted by kind, name and field.
+	// +optional
+	Drift []DriftEntry `json:"drift,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DriftReport lists the Cassandra managed resources using the
+// ProviderConfig of the same name whose observed state differs from their
+// desired state. The controllers update it whenever they observe drift, and
+// remove resources once they are in sync again.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,sql}
+type DriftReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status DriftReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DriftReportList contains a list of DriftReport
+type DriftReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriftReport `json:"items"`
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// EffectiveAccessParameters are the configurable fields of an
+// EffectiveAccess.
+type EffectiveAccessParameters struct {
+	// Role whose access is reported.
+	// +optional
+	// +crossplane:generate:reference:type=Role
+	Role *string `json:"role,omitempty"`
+
+	// RoleRef references the role object whose access is reported.
+	// +immutable
+	// +optional
+	RoleRef *xpv1.Reference `json:"roleRef,omitempty"`
+
+	// RoleSelector selects a reference to a Role whose access is reported.
+	// +immutable
+	// +optional
+	RoleSelector *xpv1.Selector `json:"roleSelector,omitempty"`
+}
+
+// An EffectiveAccessSpec defines the desired state of an EffectiveAccess.
+type EffectiveAccessSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EffectiveAccessParameters `json:"forProvider"`
+}
+
+// An EffectivePermission lists the permissions held on a resource.
+type EffectivePermission struct {
+	// Resource the permissions are held on, e.g. data/shop for the keyspace
+	// shop.
+	Resource string `json:"resource"`
+
+	// Permissions held on the resource.
+	Permissions []string `json:"permissions,omitempty"`
+
+	// GrantedTo lists the roles the permissions are granted to: the role or
+	// the roles granted to it.
+	GrantedTo []string `json:"grantedTo,omitempty"`
+}
+
+// An EffectiveAccessObservation represents the observed access of a
+// Cassandra role.
+type EffectiveAccessObservation struct {
+	// Roles are the role and the roles granted to it, directly or through
+	// other roles.
+	Roles []string `json:"roles,omitempty"`
+
+	// SuperUser is true if the role or any of the roles granted to it has the
+	// SUPERUSER privilege, in which case it holds every permission.
+	SuperUser *bool `json:"superUser,omitempty"`
+
+	// Permissions held by the role and the roles granted to it, by resource.
+	Permissions []EffectivePermission `json:"permissions,omitempty"`
+
+	ClusterIdentity `json:",inline"`
+}
+
+// An EffectiveAccessStatus represents the observed state of an
+// EffectiveAccess.
+type EffectiveAccessStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EffectiveAccessObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EffectiveAccess reports all permissions a Cassandra role holds, including
+// those of the roles granted to it. It is read-only: it never changes the
+// cluster, and deleting it leaves the role untouched.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
+// +kubebuilder:printcolumn:name="SUPERUSER",type="boolean",JSONPath=".status.atProvider.superUser"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type EffectiveAccess struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EffectiveAccessSpec   `json:"spec"`
+	Status EffectiveAccessStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EffectiveAccessList contains a list of EffectiveAccess
+type EffectiveAccessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EffectiveAccess `json:"items"`
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -32,13 +34,42 @@ type GrantSpec struct {
 // +kubebuilder:validation:Enum=ALL_PERMISSIONS;ALTER;AUTHORIZE;CREATE;DESCRIBE;DROP;EXECUTE;MODIFY;SELECT
 type GrantPrivilege string
 
+// Privileges that can be granted.
+const (
+	GrantPrivilegeAllPermissions GrantPrivilege = "ALL_PERMISSIONS"
+	GrantPrivilegeAlter          GrantPrivilege = "ALTER"
+	GrantPrivilegeAuthorize      GrantPrivilege = "AUTHORIZE"
+	GrantPrivilegeCreate         GrantPrivilege = "CREATE"
+	GrantPrivilegeDescribe       GrantPrivilege = "DESCRIBE"
+	GrantPrivilegeDrop           GrantPrivilege = "DROP"
+	GrantPrivilegeExecute        GrantPrivilege = "EXECUTE"
+	GrantPrivilegeModify         GrantPrivilege = "MODIFY"
+	GrantPrivilegeSelect         GrantPrivilege = "SELECT"
+)
+
+// CQL returns the privilege as written in GRANT statements, e.g. ALL
+// PERMISSIONS.
+func (p GrantPrivilege) CQL() string {
+	return strings.ReplaceAll(string(p), "_", " ")
+}
+
 // If Privileges are specified, we should have at least one
 
 // GrantPrivileges is a list of the privileges to be granted
 // +kubebuilder:validation:MinItems:=1
 type GrantPrivileges []GrantPrivilege
 
+// ToCQL returns the privileges as written in GRANT statements.
+func (gp GrantPrivileges) ToCQL() []string {
+	out := make([]string, len(gp))
+	for i, p := range gp {
+		out[i] = p.CQL()
+	}
+	return out
+}
+
 // GrantParameters define the desired state of a PostgreSQL grant instance.
+// +kubebuilder:validation:XValidation:rule="has(self.role) || has(self.roleRef) || has(self.roleSelector)",message="one of role, roleRef and roleSelector must be set"
 type GrantParameters struct {
 	// Privileges to be granted.
 	Privileges GrantPrivileges `json:"privileges"`
@@ -72,6 +103,36 @@ type GrantParameters struct {
 	// +immutable
 	// +optional
 	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// ApplyToExistingTablesIndividually grants the privileges on each table
+	// that exists in the keyspace instead of on the keyspace itself, for
+	// clusters with table-level auditing requirements. Tables created later
+	// are granted on the next reconcile.
+	// +optional
+	ApplyToExistingTablesIndividually *bool `json:"applyToExistingTablesIndividually,omitempty"`
+
+	// OnRole grants the privileges on the named role instead of a keyspace,
+	// e.g. AUTHORIZE to allow granting the role to others, or ALTER to allow
+	// changing its password. Mutually exclusive with keyspace and onAllRoles.
+	// +optional
+	OnRole *string `json:"onRole,omitempty"`
+
+	// OnAllRoles grants the privileges on all roles instead of a keyspace.
+	// Mutually exclusive with keyspace and onRole.
+	// +optional
+	OnAllRoles *bool `json:"onAllRoles,omitempty"`
+
+	// RevokePublic revokes all permissions the role named by publicRole
+	// holds on the resources of this grant whenever it is reconciled, e.g.
+	// to strip the default permissions of a role shared by all users and
+	// keep a least-privilege baseline.
+	// +optional
+	RevokePublic *bool `json:"revokePublic,omitempty"`
+
+	// PublicRole is the role whose permissions are revoked if revokePublic
+	// is true. It must differ from role.
+	// +optional
+	PublicRole *string `json:"publicRole,omitempty"`
 }
 
 // A GrantStatus represents the observed state of a Grant.
@@ -84,6 +145,8 @@ type GrantStatus struct {
 type GrantObservation struct {
 	// Privileges represents the applied privileges
 	Privileges []string `json:"privileges,omitempty"`
+
+	ClusterIdentity `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
@@ -92,9 +155,10 @@ type GrantObservation struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
-// +kubebuilder:printcolumn:name="KEYSAPCE",type="string",JSONPath=".spec.forProvider.keyspace"
+// +kubebuilder:printcolumn:name="KEYSPACE",type="string",JSONPath=".spec.forProvider.keyspace"
 // +kubebuilder:printcolumn:name="PRIVILEGES",type="string",JSONPath=".spec.forProvider.privileges"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
 type Grant struct {
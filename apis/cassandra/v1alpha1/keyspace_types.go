@@ -22,7 +22,50 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Policies for datacenters that are added to or removed from the cluster
+// after a keyspace was created with automatic datacenter replication.
+const (
+	// TopologyIgnore keeps replicating to the datacenters the keyspace
+	// was created with.
+	TopologyIgnore = "Ignore"
+	// TopologyReport reports a keyspace whose datacenters differ from
+	// those of the cluster as out of sync, without altering it.
+	TopologyReport = "Report"
+	// TopologyFollow alters the keyspace to replicate to exactly the
+	// datacenters of the cluster.
+	TopologyFollow = "Follow"
+)
+
+// AutoDatacenterReplication replicates a keyspace to every datacenter of its
+// cluster with NetworkTopologyStrategy.
+type AutoDatacenterReplication struct {
+	// Factor is the replication factor of each datacenter.
+	// +kubebuilder:validation:Minimum=1
+	Factor int `json:"factor"`
+
+	// OnTopologyChange controls what happens when datacenters are added to
+	// or removed from the cluster after the keyspace was created: Ignore
+	// keeps the datacenters the keyspace replicates to, Report reports the
+	// keyspace as out of sync and Follow alters it to replicate to the
+	// current datacenters.
+	// +kubebuilder:validation:Enum=Ignore;Report;Follow
+	// +kubebuilder:default=Report
+	// +optional
+	OnTopologyChange *string `json:"onTopologyChange,omitempty"`
+}
+
+// Policy returns the OnTopologyChange policy, which defaults to Report.
+func (a *AutoDatacenterReplication) Policy() string {
+	if a.OnTopologyChange == nil {
+		return TopologyReport
+	}
+	return *a.OnTopologyChange
+}
+
 // KeyspaceParameters are the configurable fields of a Keyspace.
+// +kubebuilder:validation:XValidation:rule="!has(self.autoDatacenterReplication) || !has(self.replicationFactor)",message="replicationFactor and autoDatacenterReplication are mutually exclusive: the factor of each datacenter is autoDatacenterReplication.factor"
+// +kubebuilder:validation:XValidation:rule="!has(self.autoDatacenterReplication) || !has(self.replicationClass) || self.replicationClass == 'NetworkTopologyStrategy'",message="autoDatacenterReplication requires replicationClass NetworkTopologyStrategy, or no replicationClass"
+// +kubebuilder:validation:XValidation:rule="!has(self.transientReplicas) || ((!has(self.replicationFactor) || self.transientReplicas < self.replicationFactor) && (!has(self.autoDatacenterReplication) || self.transientReplicas < self.autoDatacenterReplication.factor))",message="transientReplicas must be less than the replication factor: at least one replica must be full"
 type KeyspaceParameters struct {
 	// ReplicationClass used for keyspace
 	// +kubebuilder:validation:Enum=SimpleStrategy;NetworkTopologyStrategy
@@ -33,9 +76,65 @@ type KeyspaceParameters struct {
 	// +optional
 	ReplicationFactor *int `json:"replicationFactor,omitempty"`
 
+	// TransientReplicas is how many of the ReplicationFactor replicas are
+	// transient. Transient replication is experimental and must be enabled
+	// on the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TransientReplicas *int `json:"transientReplicas,omitempty"`
+
+	// AutoDatacenterReplication replicates the keyspace to every datacenter
+	// of the cluster, as discovered from system.local and system.peers when
+	// it is created, with NetworkTopologyStrategy and the same factor. It
+	// is mutually exclusive with replicationFactor, and replicationClass
+	// must be NetworkTopologyStrategy if it is set. Transient replicas apply
+	// to each datacenter.
+	// +optional
+	AutoDatacenterReplication *AutoDatacenterReplication `json:"autoDatacenterReplication,omitempty"`
+
 	// Decided if turn on durable writes
 	// +optional
 	DurableWrites *bool `json:"durableWrites,omitempty"`
+
+	// AutoCorrectDrift controls whether the keyspace is altered when its
+	// observed settings drift from the desired ones. When false, drift is
+	// only reported through the UpToDate condition.
+	// +kubebuilder:default=true
+	// +optional
+	AutoCorrectDrift *bool `json:"autoCorrectDrift,omitempty"`
+
+	// IfNotExists controls whether an existing keyspace of the same name is
+	// adopted. When false, the keyspace is created without IF NOT EXISTS and the
+	// resource fails to reconcile if a keyspace it did not create already exists,
+	// so that naming collisions are caught.
+	// +kubebuilder:default=true
+	// +optional
+	IfNotExists *bool `json:"ifNotExists,omitempty"`
+
+	// BootstrapFrom is the name of a template keyspace whose user defined
+	// types, tables and secondary indexes, but not data, are created in this
+	// keyspace. Objects that are missing from this keyspace are created
+	// again, but objects that differ from their template are not altered.
+	// Table options are not copied.
+	// +optional
+	BootstrapFrom *string `json:"bootstrapFrom,omitempty"`
+
+	// Description of the keyspace. It is kept in the descriptions table of
+	// the ProviderConfig, and ignored if the ProviderConfig has none.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// LateInitializePolicy controls which unset parameters are filled in
+	// with the values observed on the server. By default all of them are.
+	// +optional
+	LateInitializePolicy *LateInitializePolicy `json:"lateInitializePolicy,omitempty"`
+
+	// AwaitSchemaInAllDatacenters keeps the keyspace from becoming ready
+	// until a node of every datacenter of the cluster reports the schema
+	// version of the node the provider is connected to, so that applications
+	// in other datacenters do not race ahead of the schema propagating.
+	// +optional
+	AwaitSchemaInAllDatacenters *bool `json:"awaitSchemaInAllDatacenters,omitempty"`
 }
 
 // A KeyspaceSpec defines the desired state of a Keyspace.
@@ -44,9 +143,49 @@ type KeyspaceSpec struct {
 	ForProvider       KeyspaceParameters `json:"forProvider"`
 }
 
+// A KeyspaceObservation represents the observed state of a Cassandra keyspace.
+type KeyspaceObservation struct {
+	// ReplicationClass observed on the keyspace.
+	ReplicationClass string `json:"replicationClass,omitempty"`
+
+	// ReplicationFactor observed on the keyspace.
+	ReplicationFactor int `json:"replicationFactor,omitempty"`
+
+	// TransientReplicas observed on the keyspace.
+	TransientReplicas int `json:"transientReplicas,omitempty"`
+
+	// Replication options observed on the keyspace other than its class,
+	// e.g. replication_factor or the replication factor of each datacenter
+	// of a NetworkTopologyStrategy keyspace.
+	Replication map[string]string `json:"replication,omitempty"`
+
+	// DurableWrites observed on the keyspace.
+	DurableWrites *bool `json:"durableWrites,omitempty"`
+
+	// Description of the keyspace observed in the descriptions table.
+	Description string `json:"description,omitempty"`
+
+	// LastOperation is the last ALTER KEYSPACE statement the provider
+	// applied to the keyspace, so that changes to the cluster can be
+	// reviewed from the history of the Keyspace alone.
+	LastOperation *KeyspaceOperation `json:"lastOperation,omitempty"`
+
+	ClusterIdentity `json:",inline"`
+}
+
+// A KeyspaceOperation is a statement applied to a keyspace.
+type KeyspaceOperation struct {
+	// Statement that was applied, as executed.
+	Statement string `json:"statement"`
+
+	// Time the statement was applied at.
+	Time metav1.Time `json:"time"`
+}
+
 // A KeyspaceStatus represents the observed state of a Keyspace.
 type KeyspaceStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KeyspaceObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -55,6 +194,9 @@ type KeyspaceStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".status.atProvider.replicationClass"
+// +kubebuilder:printcolumn:name="RF",type="integer",JSONPath=".status.atProvider.replicationFactor"
+// +kubebuilder:printcolumn:name="DURABLE",type="boolean",JSONPath=".status.atProvider.durableWrites"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
 type Keyspace struct {
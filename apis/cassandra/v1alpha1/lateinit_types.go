@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Late initialization modes.
+const (
+	LateInitializeAll    = "All"
+	LateInitializeNone   = "None"
+	LateInitializeFields = "Fields"
+)
+
+// A LateInitializePolicy controls which unset parameters of a resource are
+// filled in with the values observed on the server. Parameters that are not
+// late initialized are left unset, are not compared with the server and are
+// never altered, so that changes made outside of Crossplane are kept.
+type LateInitializePolicy struct {
+	// Mode is All to late initialize every unset parameter, None to late
+	// initialize none and Fields to late initialize only the listed fields.
+	// +kubebuilder:validation:Enum=All;None;Fields
+	// +kubebuilder:default=All
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+
+	// Fields lists the JSON names of the parameters to late initialize, e.g.
+	// login, if the mode is Fields.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Allows returns whether the named parameter may be late initialized under
+// this policy. Every parameter may be late initialized if the policy is nil.
+func (p *LateInitializePolicy) Allows(field string) bool {
+	if p == nil || p.Mode == nil {
+		return true
+	}
+	switch *p.Mode {
+	case LateInitializeNone:
+		return false
+	case LateInitializeFields:
+		for _, f := range p.Fields {
+			if f == field {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
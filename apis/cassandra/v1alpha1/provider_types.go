@@ -26,6 +26,421 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// FailoverIdentities lists further credentials and endpoints, e.g. those
+	// of the datacenters of an active/passive topology, in priority order.
+	// They are tried in order when connecting with the credentials and
+	// serviceRef fails, and the identity that connected is reported in
+	// status.activeIdentity. Identities are only failed over if resources
+	// connect before they are observed, i.e. lazyConnect is not true.
+	// +optional
+	FailoverIdentities []FailoverIdentity `json:"failoverIdentities,omitempty"`
+
+	// ExternalName configures how the external names of Keyspaces and Roles
+	// using this ProviderConfig are derived from their object names. It only
+	// applies to resources that do not already have an external name.
+	// +optional
+	ExternalName *ExternalNameFormat `json:"externalName,omitempty"`
+
+	// Quota caps the number of resources that may be created through this
+	// ProviderConfig. Resources that would exceed it are not created.
+	// +optional
+	Quota *ProviderQuota `json:"quota,omitempty"`
+
+	// DefaultConsistencySerial is the serial consistency level used by the
+	// lightweight transactions the provider issues, which record the owners
+	// of roles in the owners table. Other statements are not conditional, so
+	// it does not apply to them. Multi-datacenter clusters should use
+	// LOCAL_SERIAL to avoid cross-datacenter Paxos rounds.
+	// +kubebuilder:validation:Enum=SERIAL;LOCAL_SERIAL
+	// +optional
+	DefaultConsistencySerial *string `json:"defaultConsistencySerial,omitempty"`
+
+	// ObserveWith selects how Keyspaces are observed. CQL reads
+	// system_schema. ManagementAPI reads the replication of keyspaces from
+	// the management API sidecar of the nodes, e.g. the one k8ssandra
+	// deploys, which keeps answering while nodes restart. Whether writes to
+	// a keyspace are durable is not reported by the management API, so it is
+	// neither compared nor late initialized.
+	// +kubebuilder:validation:Enum=CQL;ManagementAPI
+	// +kubebuilder:default=CQL
+	// +optional
+	ObserveWith *string `json:"observeWith,omitempty"`
+
+	// ManagementAPI configures the management API of the nodes. It is
+	// required if Keyspaces are observed with it.
+	// +optional
+	ManagementAPI *ManagementAPIConfig `json:"managementAPI,omitempty"`
+
+	// ExecutionProfiles configure the consistency, timeout and retries of
+	// the statements the provider reads and writes with.
+	// +optional
+	ExecutionProfiles *ExecutionProfiles `json:"executionProfiles,omitempty"`
+
+	// MaxPreparedStatements is how many prepared statements each session of
+	// the provider caches. Larger caches trade memory for fewer round trips
+	// to prepare statements again. Sessions last a single reconcile, so
+	// their caches only span its statements. The driver default is 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPreparedStatements *int `json:"maxPreparedStatements,omitempty"`
+
+	// TLS enables encrypted connections to the cluster.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// ServiceRef references a Kubernetes Service the cluster is reached
+	// through. When set, it takes precedence over the endpoint and port of
+	// the credentials Secret, which then only needs to contain the username
+	// and password.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+
+	// AuthMechanism selects how the provider authenticates to the cluster.
+	// Password authenticates with the username and password of the
+	// credentials Secret. Other mechanisms, such as GSSAPI, are available
+	// only if they were registered in the provider build.
+	// +kubebuilder:default=Password
+	// +optional
+	AuthMechanism *string `json:"authMechanism,omitempty"`
+
+	// ConnectionSecretMetadata is applied to every connection Secret
+	// published for resources using this ProviderConfig, e.g. so that
+	// secret management tooling can select them. Labels and annotations
+	// already present on the Secrets are kept.
+	// +optional
+	ConnectionSecretMetadata *SecretMetadata `json:"connectionSecretMetadata,omitempty"`
+
+	// Descriptions configures a table in which the descriptions of the
+	// keyspaces and roles using this ProviderConfig are kept, so that they
+	// can be read with cqlsh. Descriptions are not stored if it is not set.
+	// +optional
+	Descriptions *DescriptionsConfig `json:"descriptions,omitempty"`
+
+	// Owners configures a table in which the UIDs of the Roles managing the
+	// roles of the cluster are recorded. Roles refuse to manage a role that is
+	// recorded as managed by another Role, e.g. one with the same external
+	// name in another team's namespace, and do not drop it when deleted.
+	// Owners are not recorded if it is not set.
+	// +optional
+	Owners *OwnersConfig `json:"owners,omitempty"`
+
+	// Notifications configures a webhook that is notified whenever the
+	// provider creates, updates or deletes a keyspace, role, grant or
+	// trigger using this ProviderConfig, e.g. to record the change in a
+	// change management system.
+	// +optional
+	Notifications *NotificationConfig `json:"notifications,omitempty"`
+
+	// Passwords configures how the passwords of Roles using this
+	// ProviderConfig are generated. Passwords of 27 letters and digits are
+	// generated if it is not set.
+	// +optional
+	Passwords *PasswordConfig `json:"passwords,omitempty"`
+
+	// AllowSuperuserRoles allows Roles using this ProviderConfig to be
+	// superusers. Set it to false to offer self-service roles to tenants
+	// that must not provision superusers: Roles that specify superUser are
+	// then neither created nor updated.
+	// +kubebuilder:default=true
+	// +optional
+	AllowSuperuserRoles *bool `json:"allowSuperuserRoles,omitempty"`
+
+	// CircuitBreaker configures when resources using this ProviderConfig
+	// stop connecting to its cluster after repeated connection failures,
+	// rather than each retrying against a cluster that is down. Connecting
+	// fails 5 times before they back off for 30 seconds if it is not set.
+	// +optional
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+
+	// LazyConnect defers connecting to the cluster until the first statement
+	// a resource executes, rather than dialing its nodes before the resource
+	// is observed. Connection failures are then returned by that statement.
+	// Resources connect right away while the circuit breaker probes a
+	// cluster that was unavailable.
+	// +optional
+	LazyConnect *bool `json:"lazyConnect,omitempty"`
+
+	// Warmup executes a lightweight query right after connecting to the
+	// cluster, so that nodes that accept connections but cannot serve
+	// statements fail the connection and count towards the circuit breaker,
+	// rather than failing the first statement of every resource. It is
+	// ignored if lazyConnect is true.
+	// +optional
+	Warmup *bool `json:"warmup,omitempty"`
+
+	// EventAnnotations are added to every event recorded for resources using
+	// this ProviderConfig, e.g. the team that owns them, so that events of
+	// multi-tenant clusters can be routed to the alerting pipeline of that
+	// team.
+	// +optional
+	EventAnnotations map[string]string `json:"eventAnnotations,omitempty"`
+
+	// Pacing defers the schema changes of keyspaces and triggers while the
+	// cluster is under stress, e.g. a shared production cluster that is
+	// catching up with compactions, and resumes them once it recovers.
+	// Schema changes are never deferred if it is not set.
+	// +optional
+	Pacing *PacingConfig `json:"pacing,omitempty"`
+}
+
+// PacingConfig configures when the schema changes of the resources of a
+// ProviderConfig are deferred. The load of the cluster is read from the
+// system_views virtual tables of the node the provider is connected to, at
+// most once per checkInterval. Clusters that do not have them, before
+// Cassandra 4.0, are never considered under stress.
+type PacingConfig struct {
+	// MaxPendingCompactions is how many compactions may be pending before
+	// schema changes are deferred.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPendingCompactions *int `json:"maxPendingCompactions,omitempty"`
+
+	// MaxPendingMutations is how many mutations may be queued before schema
+	// changes are deferred. Nodes that queue mutations start dropping them
+	// once they time out.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPendingMutations *int `json:"maxPendingMutations,omitempty"`
+
+	// CheckInterval is how long the load of the cluster is reused before it
+	// is read again, e.g. "30s", and so how long schema changes are deferred
+	// at least.
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+}
+
+// CircuitBreakerConfig configures the circuit breaker of a ProviderConfig.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many consecutive connections must fail before
+	// the breaker opens.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	FailureThreshold *int `json:"failureThreshold,omitempty"`
+
+	// Cooldown is how long the breaker stays open, e.g. "30s". A single
+	// resource then probes the cluster, and the breaker closes if it
+	// connects.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// PasswordConfig configures how passwords are generated.
+type PasswordConfig struct {
+	// Length of generated passwords.
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=256
+	// +kubebuilder:default=27
+	// +optional
+	Length *int `json:"length,omitempty"`
+
+	// CharacterSet generated passwords consist of. It defaults to lowercase
+	// and uppercase letters and digits. Characters that must be escaped in
+	// connection strings are best avoided.
+	// +kubebuilder:validation:MinLength=10
+	// +optional
+	CharacterSet *string `json:"characterSet,omitempty"`
+}
+
+// OwnersConfig configures the table owners are recorded in. The table is
+// keyed by the kind of the managed object and its name.
+type OwnersConfig struct {
+	// Keyspace the table is in. It must exist and is not managed by the
+	// provider.
+	Keyspace string `json:"keyspace"`
+
+	// Table owners are recorded in. It is created if it does not exist.
+	// +kubebuilder:default=crossplane_owners
+	// +optional
+	Table *string `json:"table,omitempty"`
+}
+
+// NotificationConfig configures the webhook changes are notified to. A JSON
+// object describing the change is POSTed to it for every change. Changes are
+// not notified again if the webhook fails.
+type NotificationConfig struct {
+	// URL the changes are POSTed to.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// TokenSecretRef references a token that is sent as bearer token in the
+	// Authorization header of every notification.
+	// +optional
+	TokenSecretRef *xpv1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// DescriptionsConfig configures the table descriptions are kept in. The table
+// is keyed by the kind of the described object, keyspace or role, and its
+// name.
+type DescriptionsConfig struct {
+	// Keyspace the table is in. It must exist and is not managed by the
+	// provider.
+	Keyspace string `json:"keyspace"`
+
+	// Table descriptions are kept in. It is created if it does not exist.
+	// +kubebuilder:default=crossplane_descriptions
+	// +optional
+	Table *string `json:"table,omitempty"`
+}
+
+// How Keyspaces are observed.
+const (
+	ObserveWithCQL           = "CQL"
+	ObserveWithManagementAPI = "ManagementAPI"
+)
+
+// ManagementAPIConfig configures the management API of the nodes.
+type ManagementAPIConfig struct {
+	// URL the management API is served at, e.g.
+	// http://cluster-dc1-service.cassandra.svc:8080.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+// ExecutionProfiles configure how the provider executes statements.
+type ExecutionProfiles struct {
+	// Read is used by the statements that observe resources, such as
+	// SELECT and LIST statements.
+	// +optional
+	Read *ExecutionProfile `json:"read,omitempty"`
+
+	// Write is used by the statements that create, update and delete
+	// resources.
+	// +optional
+	Write *ExecutionProfile `json:"write,omitempty"`
+}
+
+// An ExecutionProfile configures how statements are executed.
+type ExecutionProfile struct {
+	// Consistency level of the statements. It defaults to ALL.
+	// +kubebuilder:validation:Enum=ANY;ONE;TWO;THREE;QUORUM;ALL;LOCAL_QUORUM;EACH_QUORUM;LOCAL_ONE
+	// +optional
+	Consistency *string `json:"consistency,omitempty"`
+
+	// Timeout of each statement, e.g. "10s". Statements are otherwise only
+	// bounded by the timeout of the reconciliation.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries is how many times a failed statement is retried. The driver
+	// default is used if it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int `json:"retries,omitempty"`
+}
+
+// ClusterIdentity identifies the cluster and datacenter a managed resource was
+// observed on, as reported by the node the provider was connected to.
+type ClusterIdentity struct {
+	// ClusterName is the name of the cluster.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Datacenter is the datacenter of the node.
+	Datacenter string `json:"datacenter,omitempty"`
+}
+
+// SecretMetadata is metadata applied to Secrets.
+type SecretMetadata struct {
+	// Labels applied to the Secrets.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations applied to the Secrets.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// How a ServiceReference is resolved.
+const (
+	ServiceResolveDNS    = "DNS"
+	ServiceResolvePodIPs = "PodIPs"
+)
+
+// A ServiceReference resolves the endpoint of a cluster from a Kubernetes
+// Service at connect time.
+type ServiceReference struct {
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// PortName is the name of the Service port to connect to. The first port
+	// is used if it is not set.
+	// +optional
+	PortName *string `json:"portName,omitempty"`
+
+	// Resolve selects whether to connect to the DNS name of the Service or
+	// directly to the ready pod IPs backing it, which lets the driver
+	// balance requests across the nodes itself.
+	// +kubebuilder:validation:Enum=DNS;PodIPs
+	// +kubebuilder:default=DNS
+	// +optional
+	Resolve *string `json:"resolve,omitempty"`
+}
+
+// TLSConfig configures encrypted connections to the cluster.
+type TLSConfig struct {
+	// CACert references a PEM encoded CA bundle used to verify the node
+	// certificates. The system roots are used if it is not set.
+	// +optional
+	CACert *xpv1.SecretKeySelector `json:"caCert,omitempty"`
+
+	// ClientCertSecretRef references a kubernetes.io/tls Secret holding the
+	// tls.crt and tls.key the provider authenticates to the nodes with, e.g.
+	// the Secret cert-manager issues a Certificate into. The Secret is read
+	// whenever the provider connects, so renewed certificates are used
+	// without restarting the provider. Its ca.crt, if any, is used to verify
+	// the node certificates unless CACert is set.
+	// +optional
+	ClientCertSecretRef *xpv1.SecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// ServerName node certificates are verified against. It defaults to the
+	// address of each node, which must be overridden for clusters behind load
+	// balancers whose certificates don't match the node addresses.
+	// +optional
+	ServerName *string `json:"serverName,omitempty"`
+
+	// EnableHostVerification verifies the node certificates. Disabling it
+	// accepts any certificate and should only be used for testing.
+	// +kubebuilder:default=true
+	// +optional
+	EnableHostVerification *bool `json:"enableHostVerification,omitempty"`
+}
+
+// ExternalNameFormat is applied to the name of a managed resource to produce
+// its external name, allowing the same manifests to be instantiated per
+// environment without name clashes.
+type ExternalNameFormat struct {
+	// Prefix prepended to the managed resource name, e.g. "prod_".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix appended to the managed resource name.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// ProviderQuota limits what a shared cluster may host via the provider.
+// Resources are admitted in the order they started using the ProviderConfig.
+type ProviderQuota struct {
+	// MaxKeyspaces is the maximum number of Keyspaces.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxKeyspaces *int `json:"maxKeyspaces,omitempty"`
+
+	// MaxTotalReplicationFactor is the maximum sum of the replication
+	// factors of all Keyspaces, which bounds the replicated data they may
+	// create on the cluster.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxTotalReplicationFactor *int `json:"maxTotalReplicationFactor,omitempty"`
+
+	// MaxRoles is the maximum number of Roles.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRoles *int `json:"maxRoles,omitempty"`
 }
 
 const (
@@ -33,23 +448,131 @@ const (
 	// should acquire credentials from a connection secret written by a managed
 	// resource that represents a Cassandra server.
 	CredentialsSourceCassandraConnectionSecret xpv1.CredentialsSource = "CassandraConnectionSecret"
+
+	// CredentialsSourceCassandraDatacenter indicates that a provider should
+	// acquire credentials from the superuser secret of a k8ssandra
+	// CassandraDatacenter, and connect to its service.
+	CredentialsSourceCassandraDatacenter xpv1.CredentialsSource = "CassandraDatacenter"
 )
 
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=CassandraConnectionSecret
+	// +kubebuilder:validation:Enum=CassandraConnectionSecret;CassandraDatacenter
 	Source xpv1.CredentialsSource `json:"source"`
 
 	// A CredentialsSecretRef is a reference to a Cassandra connection secret
 	// that contains the credentials that must be used to connect to the
 	// provider. +optional
 	ConnectionSecretRef *xpv1.SecretReference `json:"connectionSecretRef,omitempty"`
+
+	// DatacenterRef references the k8ssandra CassandraDatacenter whose
+	// superuser secret and service are connected with if the source is
+	// CassandraDatacenter. A ServiceRef of the ProviderConfig takes
+	// precedence over the service of the datacenter.
+	// +optional
+	DatacenterRef *DatacenterReference `json:"datacenterRef,omitempty"`
+}
+
+// PrimaryIdentity is the name the credentials and serviceRef of a
+// ProviderConfig are reported under in its status.activeIdentity.
+const PrimaryIdentity = "primary"
+
+// A FailoverIdentity is a credentials Secret and endpoint the provider
+// connects with when connecting with those preceding it fails.
+type FailoverIdentity struct {
+	// Name of the identity, reported in status.activeIdentity while it is
+	// connected with.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ConnectionSecretRef references a Cassandra connection secret that
+	// contains the credentials, and the endpoint and port unless ServiceRef
+	// is set.
+	ConnectionSecretRef xpv1.SecretReference `json:"connectionSecretRef"`
+
+	// ServiceRef references a Kubernetes Service the cluster is reached
+	// through with this identity. It takes precedence over the endpoint and
+	// port of its credentials Secret.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+}
+
+// A DatacenterReference references a k8ssandra CassandraDatacenter.
+type DatacenterReference struct {
+	// Name of the CassandraDatacenter.
+	Name string `json:"name"`
+
+	// Namespace of the CassandraDatacenter.
+	Namespace string `json:"namespace"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// DriftReport summarizes the last audit of the managed resources using
+	// this ProviderConfig against the cluster. It is only reported when the
+	// provider runs with --audit-interval.
+	// +optional
+	DriftReport *DriftAudit `json:"driftReport,omitempty"`
+
+	// ActiveIdentity is the identity the provider last connected to the
+	// cluster with. It is only reported for ProviderConfigs with
+	// failoverIdentities.
+	// +optional
+	ActiveIdentity *ActiveIdentity `json:"activeIdentity,omitempty"`
+}
+
+// An ActiveIdentity is the identity a ProviderConfig connects with.
+type ActiveIdentity struct {
+	// Name of the identity, or primary for the credentials and serviceRef of
+	// the ProviderConfig.
+	Name string `json:"name"`
+
+	// Endpoint connected to.
+	Endpoint string `json:"endpoint"`
+
+	// LastTransitionTime is when the provider started connecting with this
+	// identity.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// A DriftAudit summarizes how the managed resources using a ProviderConfig
+// compare to the actual state of the cluster.
+type DriftAudit struct {
+	// LastAuditTime is when the cluster was last audited.
+	LastAuditTime metav1.Time `json:"lastAuditTime"`
+
+	// Resources summarizes the audit per managed resource kind.
+	// +optional
+	Resources []DriftSummary `json:"resources,omitempty"`
+
+	// Error that prevented the last audit from completing.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// A DriftSummary counts the managed resources of a kind that are missing from
+// or differ from the cluster.
+type DriftSummary struct {
+	// Kind of the managed resources.
+	Kind string `json:"kind"`
+
+	// Total number of managed resources of this kind.
+	Total int `json:"total"`
+
+	// Missing is the number of managed resources that do not exist in the
+	// cluster.
+	Missing int `json:"missing"`
+
+	// Drifted is the number of managed resources whose settings differ from
+	// the cluster.
+	Drifted int `json:"drifted"`
+
+	// OutOfSync lists the names of up to ten missing or drifted resources.
+	// +optional
+	OutOfSync []string `json:"outOfSync,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -82,10 +82,37 @@ var (
 	GrantGroupVersionKind = SchemeGroupVersion.WithKind(GrantKind)
 )
 
+// Trigger type metadata.
+var (
+	TriggerKind             = reflect.TypeOf(Trigger{}).Name()
+	TriggerGroupKind        = schema.GroupKind{Group: Group, Kind: TriggerKind}.String()
+	TriggerKindAPIVersion   = TriggerKind + "." + SchemeGroupVersion.String()
+	TriggerGroupVersionKind = SchemeGroupVersion.WithKind(TriggerKind)
+)
+
+// EffectiveAccess type metadata.
+var (
+	EffectiveAccessKind             = reflect.TypeOf(EffectiveAccess{}).Name()
+	EffectiveAccessGroupKind        = schema.GroupKind{Group: Group, Kind: EffectiveAccessKind}.String()
+	EffectiveAccessKindAPIVersion   = EffectiveAccessKind + "." + SchemeGroupVersion.String()
+	EffectiveAccessGroupVersionKind = SchemeGroupVersion.WithKind(EffectiveAccessKind)
+)
+
+// DriftReport type metadata.
+var (
+	DriftReportKind             = reflect.TypeOf(DriftReport{}).Name()
+	DriftReportGroupKind        = schema.GroupKind{Group: Group, Kind: DriftReportKind}.String()
+	DriftReportKindAPIVersion   = DriftReportKind + "." + SchemeGroupVersion.String()
+	DriftReportGroupVersionKind = SchemeGroupVersion.WithKind(DriftReportKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
 	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
 	SchemeBuilder.Register(&Keyspace{}, &KeyspaceList{})
 	SchemeBuilder.Register(&Role{}, &RoleList{})
 	SchemeBuilder.Register(&Grant{}, &GrantList{})
+	SchemeBuilder.Register(&Trigger{}, &TriggerList{})
+	SchemeBuilder.Register(&EffectiveAccess{}, &EffectiveAccessList{})
+	SchemeBuilder.Register(&DriftReport{}, &DriftReportList{})
 }
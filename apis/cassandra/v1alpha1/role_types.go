@@ -28,9 +28,79 @@ type RoleSpec struct {
 	ForProvider       RoleParameters `json:"forProvider"`
 }
 
+// A RoleObservation represents the observed state of a Cassandra role.
+type RoleObservation struct {
+	// SuperUser is true if the role has the SUPERUSER privilege.
+	SuperUser *bool `json:"superUser,omitempty"`
+
+	// Login is true if the role is allowed to login.
+	Login *bool `json:"login,omitempty"`
+
+	// Description of the role observed in the descriptions table.
+	Description string `json:"description,omitempty"`
+
+	// PasswordRotatedAt is when the password of the role was last rotated.
+	PasswordRotatedAt *metav1.Time `json:"passwordRotatedAt,omitempty"`
+
+	// Name of the role in the cluster, as last observed. A Role whose
+	// external name no longer matches it is being renamed.
+	Name string `json:"name,omitempty"`
+
+	// Rename reports the progress of the last rename of the role.
+	Rename *RoleRename `json:"rename,omitempty"`
+
+	// PasswordHashDigest is the SHA-256 digest of the salted hash of the
+	// password of the role, as observed after the provider last set it.
+	// It is only observed if externalPasswordChangePolicy is set.
+	PasswordHashDigest string `json:"passwordHashDigest,omitempty"`
+
+	ClusterIdentity `json:",inline"`
+}
+
+// A RenamePhase is a step of renaming a role.
+type RenamePhase string
+
+// Phases of renaming a role. Every phase is retried until it succeeds.
+const (
+	// RenamePending roles will be renamed by the next update.
+	RenamePending RenamePhase = "Pending"
+	// RenameCreating roles are created under their new name.
+	RenameCreating RenamePhase = "Creating"
+	// RenameMigrating roles are granted what the old role was granted, and
+	// to the roles it was granted to.
+	RenameMigrating RenamePhase = "Migrating"
+	// RenameDropping roles have their old role dropped.
+	RenameDropping RenamePhase = "Dropping"
+	// RenameComplete roles were renamed.
+	RenameComplete RenamePhase = "Complete"
+)
+
+// AnnotationAllowRename allows changing the external name of a Role when set
+// to "true", which renames its role. The role is created under its new name
+// with a new password, granted what the old role was granted, and the old
+// role is dropped. Without it, changes of the external name are rejected.
+const AnnotationAllowRename = "cassandra.cql.crossplane.io/allow-rename"
+
+// A RoleRename reports the progress of renaming a role.
+type RoleRename struct {
+	// From is the old name of the role.
+	From string `json:"from"`
+
+	// To is the new name of the role.
+	To string `json:"to"`
+
+	// Phase of the rename.
+	Phase RenamePhase `json:"phase"`
+
+	// LastTransitionTime is when the rename last entered a phase.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // A RoleStatus represents the observed state of a Role.
 type RoleStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RoleObservation `json:"atProvider,omitempty"`
 }
 
 // RolePrivilege is the Cassandra identifier to add or remove a permission
@@ -46,11 +116,119 @@ type RolePrivilege struct {
 	Login *bool `json:"login,omitempty"`
 }
 
+// A ConnectionDetailsFormat is a driver specific format of the connection
+// details of a role.
+// +kubebuilder:validation:Enum=ContactPoints;CQLSHRC;JavaDriver
+type ConnectionDetailsFormat string
+
+// ConnectionSecretKeys are additional key names the standard connection
+// details of a role are published under.
+type ConnectionSecretKeys struct {
+	// UsernameKeyName the username is published under in addition to
+	// username.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	UsernameKeyName *string `json:"usernameKeyName,omitempty"`
+
+	// PasswordKeyName the password is published under in addition to
+	// password, e.g. CASSANDRA_PASSWORD.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	PasswordKeyName *string `json:"passwordKeyName,omitempty"`
+
+	// EndpointKeyName the endpoint is published under in addition to
+	// endpoint.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	EndpointKeyName *string `json:"endpointKeyName,omitempty"`
+
+	// PortKeyName the port is published under in addition to port.
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	PortKeyName *string `json:"portKeyName,omitempty"`
+}
+
+// Policies for the password of an adopted role.
+const (
+	PasswordRotate = "Rotate"
+	PasswordKeep   = "Keep"
+)
+
+// Policies for passwords of a role that were changed by others than the
+// provider.
+const (
+	ExternalPasswordChangeAlert    = "Alert"
+	ExternalPasswordChangeReassert = "Reassert"
+)
+
 // RoleParameters define the desired state of a Cassandra role instance.
 type RoleParameters struct {
 	// Privileges to be granted.
 	// +optional
 	Privileges RolePrivilege `json:"privileges,omitempty"`
+
+	// IfNotExists controls whether an existing role of the same name is
+	// adopted. When false, the role is created without IF NOT EXISTS and the
+	// resource fails to reconcile if a role it did not create already exists,
+	// so that naming collisions are caught.
+	// +kubebuilder:default=true
+	// +optional
+	IfNotExists *bool `json:"ifNotExists,omitempty"`
+
+	// PasswordOnAdoption controls the password of an existing role that is
+	// adopted. Rotate replaces it with a generated password that is
+	// published in the connection secret. Keep leaves it as is, so that the
+	// current users of the role are not locked out, and publishes the
+	// connection details without a password. A password is generated again
+	// if the connection secret is deleted or the password is rotated.
+	// +kubebuilder:validation:Enum=Rotate;Keep
+	// +kubebuilder:default=Rotate
+	// +optional
+	PasswordOnAdoption *string `json:"passwordOnAdoption,omitempty"`
+
+	// ConnectionDetailsFormat lists driver specific formats that are
+	// published in addition to the standard connection details, so that
+	// applications can mount the connection secret as is. ContactPoints
+	// publishes the contact-points and local-datacenter keys, CQLSHRC a
+	// cqlshrc key and JavaDriver an application.conf key. The formats are
+	// published together with the password when the role is created.
+	// +optional
+	ConnectionDetailsFormat []ConnectionDetailsFormat `json:"connectionDetailsFormat,omitempty"`
+
+	// ConnectionSecretKeys publishes the standard connection details under
+	// additional key names too, so that applications expecting fixed
+	// environment variables can consume the connection secret as is.
+	// +optional
+	ConnectionSecretKeys *ConnectionSecretKeys `json:"connectionSecretKeys,omitempty"`
+
+	// ExternalPasswordChangePolicy controls how changes of the password of
+	// the role by others than the provider are handled. They are detected by
+	// observing the salted hash of the password in system_auth.roles, which
+	// the credentials of the ProviderConfig must be allowed to select. Alert
+	// sets the Password condition to False until the provider sets the
+	// password again, e.g. when it is rotated. Reassert sets the password
+	// published in the connection secret again, or a new generated one if
+	// none is published. Changes are not detected if it is not set.
+	// +kubebuilder:validation:Enum=Alert;Reassert
+	// +optional
+	ExternalPasswordChangePolicy *string `json:"externalPasswordChangePolicy,omitempty"`
+
+	// RotatePasswordEvery is how often the password of the role is replaced
+	// with a new generated one, e.g. "720h". The connection secret is
+	// updated with every new password. The password is not rotated if it is
+	// not set.
+	// +optional
+	RotatePasswordEvery *metav1.Duration `json:"rotatePasswordEvery,omitempty"`
+
+	// Description of the role. It is kept in the descriptions table of the
+	// ProviderConfig, and ignored if the ProviderConfig has none.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// LateInitializePolicy controls which unset parameters are filled in
+	// with the values observed on the server. By default all of them are.
+	// +optional
+	LateInitializePolicy *LateInitializePolicy `json:"lateInitializePolicy,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -59,6 +237,8 @@ type RoleParameters struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SUPERUSER",type="boolean",JSONPath=".status.atProvider.superUser"
+// +kubebuilder:printcolumn:name="LOGIN",type="boolean",JSONPath=".status.atProvider.login"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
 type Role struct {
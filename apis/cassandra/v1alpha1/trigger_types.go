@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TriggerParameters are the configurable fields of a Trigger.
+type TriggerParameters struct {
+	// Keyspace of the table the trigger is created on.
+	// +optional
+	// +crossplane:generate:reference:type=Keyspace
+	Keyspace *string `json:"keyspace,omitempty"`
+
+	// KeyspaceRef references the keyspace object of the table.
+	// +immutable
+	// +optional
+	KeyspaceRef *xpv1.Reference `json:"keyspaceRef,omitempty"`
+
+	// KeyspaceSelector selects a reference to a Keyspace of the table.
+	// +immutable
+	// +optional
+	KeyspaceSelector *xpv1.Selector `json:"keyspaceSelector,omitempty"`
+
+	// Table the trigger is created on.
+	// +immutable
+	Table string `json:"table"`
+
+	// Class is the fully qualified name of the Java class implementing the
+	// trigger. It must be installed on every node of the cluster.
+	Class string `json:"class"`
+}
+
+// A TriggerSpec defines the desired state of a Trigger.
+type TriggerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TriggerParameters `json:"forProvider"`
+}
+
+// A TriggerObservation represents the observed state of a Cassandra trigger.
+type TriggerObservation struct {
+	// Class observed on the trigger.
+	Class string `json:"class,omitempty"`
+
+	ClusterIdentity `json:",inline"`
+}
+
+// A TriggerStatus represents the observed state of a Trigger.
+type TriggerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TriggerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Trigger represents the declarative state of a Cassandra trigger.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="KEYSPACE",type="string",JSONPath=".spec.forProvider.keyspace"
+// +kubebuilder:printcolumn:name="TABLE",type="string",JSONPath=".spec.forProvider.table"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+type Trigger struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TriggerSpec   `json:"spec"`
+	Status TriggerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TriggerList contains a list of Trigger
+type TriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Trigger `json:"items"`
+}
@@ -22,212 +22,216 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Grant) DeepCopyInto(out *Grant) {
+func (in *ActiveIdentity) DeepCopyInto(out *ActiveIdentity) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Grant.
-func (in *Grant) DeepCopy() *Grant {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveIdentity.
+func (in *ActiveIdentity) DeepCopy() *ActiveIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(Grant)
+	out := new(ActiveIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Grant) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrantList) DeepCopyInto(out *GrantList) {
+func (in *AutoDatacenterReplication) DeepCopyInto(out *AutoDatacenterReplication) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Grant, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.OnTopologyChange != nil {
+		in, out := &in.OnTopologyChange, &out.OnTopologyChange
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantList.
-func (in *GrantList) DeepCopy() *GrantList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoDatacenterReplication.
+func (in *AutoDatacenterReplication) DeepCopy() *AutoDatacenterReplication {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantList)
+	out := new(AutoDatacenterReplication)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrantList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerConfig) DeepCopyInto(out *CircuitBreakerConfig) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int)
+		**out = **in
 	}
-	return nil
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerConfig.
+func (in *CircuitBreakerConfig) DeepCopy() *CircuitBreakerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrantObservation) DeepCopyInto(out *GrantObservation) {
+func (in *ClusterIdentity) DeepCopyInto(out *ClusterIdentity) {
 	*out = *in
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantObservation.
-func (in *GrantObservation) DeepCopy() *GrantObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIdentity.
+func (in *ClusterIdentity) DeepCopy() *ClusterIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantObservation)
+	out := new(ClusterIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrantParameters) DeepCopyInto(out *GrantParameters) {
+func (in *ConnectionSecretKeys) DeepCopyInto(out *ConnectionSecretKeys) {
 	*out = *in
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
-		*out = make(GrantPrivileges, len(*in))
-		copy(*out, *in)
-	}
-	if in.Role != nil {
-		in, out := &in.Role, &out.Role
+	if in.UsernameKeyName != nil {
+		in, out := &in.UsernameKeyName, &out.UsernameKeyName
 		*out = new(string)
 		**out = **in
 	}
-	if in.RoleRef != nil {
-		in, out := &in.RoleRef, &out.RoleRef
-		*out = new(v1.Reference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RoleSelector != nil {
-		in, out := &in.RoleSelector, &out.RoleSelector
-		*out = new(v1.Selector)
-		(*in).DeepCopyInto(*out)
+	if in.PasswordKeyName != nil {
+		in, out := &in.PasswordKeyName, &out.PasswordKeyName
+		*out = new(string)
+		**out = **in
 	}
-	if in.Keyspace != nil {
-		in, out := &in.Keyspace, &out.Keyspace
+	if in.EndpointKeyName != nil {
+		in, out := &in.EndpointKeyName, &out.EndpointKeyName
 		*out = new(string)
 		**out = **in
 	}
-	if in.KeyspaceRef != nil {
-		in, out := &in.KeyspaceRef, &out.KeyspaceRef
-		*out = new(v1.Reference)
-		(*in).DeepCopyInto(*out)
+	if in.PortKeyName != nil {
+		in, out := &in.PortKeyName, &out.PortKeyName
+		*out = new(string)
+		**out = **in
 	}
-	if in.KeyspaceSelector != nil {
-		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
-		*out = new(v1.Selector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSecretKeys.
+func (in *ConnectionSecretKeys) DeepCopy() *ConnectionSecretKeys {
+	if in == nil {
+		return nil
 	}
+	out := new(ConnectionSecretKeys)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantParameters.
-func (in *GrantParameters) DeepCopy() *GrantParameters {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatacenterReference) DeepCopyInto(out *DatacenterReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatacenterReference.
+func (in *DatacenterReference) DeepCopy() *DatacenterReference {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantParameters)
+	out := new(DatacenterReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in GrantPrivileges) DeepCopyInto(out *GrantPrivileges) {
-	{
-		in := &in
-		*out = make(GrantPrivileges, len(*in))
-		copy(*out, *in)
+func (in *DescriptionsConfig) DeepCopyInto(out *DescriptionsConfig) {
+	*out = *in
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantPrivileges.
-func (in GrantPrivileges) DeepCopy() GrantPrivileges {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DescriptionsConfig.
+func (in *DescriptionsConfig) DeepCopy() *DescriptionsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantPrivileges)
+	out := new(DescriptionsConfig)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrantSpec) DeepCopyInto(out *GrantSpec) {
+func (in *DriftAudit) DeepCopyInto(out *DriftAudit) {
 	*out = *in
-	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.LastAuditTime.DeepCopyInto(&out.LastAuditTime)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]DriftSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantSpec.
-func (in *GrantSpec) DeepCopy() *GrantSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftAudit.
+func (in *DriftAudit) DeepCopy() *DriftAudit {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantSpec)
+	out := new(DriftAudit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrantStatus) DeepCopyInto(out *GrantStatus) {
+func (in *DriftEntry) DeepCopyInto(out *DriftEntry) {
 	*out = *in
-	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	in.FirstObservedTime.DeepCopyInto(&out.FirstObservedTime)
+	in.LastObservedTime.DeepCopyInto(&out.LastObservedTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantStatus.
-func (in *GrantStatus) DeepCopy() *GrantStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftEntry.
+func (in *DriftEntry) DeepCopy() *DriftEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(GrantStatus)
+	out := new(DriftEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Keyspace) DeepCopyInto(out *Keyspace) {
+func (in *DriftReport) DeepCopyInto(out *DriftReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyspace.
-func (in *Keyspace) DeepCopy() *Keyspace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReport.
+func (in *DriftReport) DeepCopy() *DriftReport {
 	if in == nil {
 		return nil
 	}
-	out := new(Keyspace)
+	out := new(DriftReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Keyspace) DeepCopyObject() runtime.Object {
+func (in *DriftReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -235,31 +239,31 @@ func (in *Keyspace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceList) DeepCopyInto(out *KeyspaceList) {
+func (in *DriftReportList) DeepCopyInto(out *DriftReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Keyspace, len(*in))
+		*out = make([]DriftReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceList.
-func (in *KeyspaceList) DeepCopy() *KeyspaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportList.
+func (in *DriftReportList) DeepCopy() *DriftReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceList)
+	out := new(DriftReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeyspaceList) DeepCopyObject() runtime.Object {
+func (in *DriftReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -267,70 +271,49 @@ func (in *KeyspaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceParameters) DeepCopyInto(out *KeyspaceParameters) {
+func (in *DriftReportStatus) DeepCopyInto(out *DriftReportStatus) {
 	*out = *in
-	if in.ReplicationClass != nil {
-		in, out := &in.ReplicationClass, &out.ReplicationClass
-		*out = new(string)
-		**out = **in
-	}
-	if in.ReplicationFactor != nil {
-		in, out := &in.ReplicationFactor, &out.ReplicationFactor
-		*out = new(int)
-		**out = **in
-	}
-	if in.DurableWrites != nil {
-		in, out := &in.DurableWrites, &out.DurableWrites
-		*out = new(bool)
-		**out = **in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceParameters.
-func (in *KeyspaceParameters) DeepCopy() *KeyspaceParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportStatus.
+func (in *DriftReportStatus) DeepCopy() *DriftReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceParameters)
+	out := new(DriftReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceSpec) DeepCopyInto(out *KeyspaceSpec) {
+func (in *DriftSummary) DeepCopyInto(out *DriftSummary) {
 	*out = *in
-	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	in.ForProvider.DeepCopyInto(&out.ForProvider)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSpec.
-func (in *KeyspaceSpec) DeepCopy() *KeyspaceSpec {
-	if in == nil {
-		return nil
+	if in.OutOfSync != nil {
+		in, out := &in.OutOfSync, &out.OutOfSync
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(KeyspaceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyspaceStatus) DeepCopyInto(out *KeyspaceStatus) {
-	*out = *in
-	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceStatus.
-func (in *KeyspaceStatus) DeepCopy() *KeyspaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftSummary.
+func (in *DriftSummary) DeepCopy() *DriftSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyspaceStatus)
+	out := new(DriftSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+func (in *EffectiveAccess) DeepCopyInto(out *EffectiveAccess) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -338,18 +321,18 @@ func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
-func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccess.
+func (in *EffectiveAccess) DeepCopy() *EffectiveAccess {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfig)
+	out := new(EffectiveAccess)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderConfig) DeepCopyObject() runtime.Object {
+func (in *EffectiveAccess) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -357,31 +340,31 @@ func (in *ProviderConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
+func (in *EffectiveAccessList) DeepCopyInto(out *EffectiveAccessList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProviderConfig, len(*in))
+		*out = make([]EffectiveAccess, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigList.
-func (in *ProviderConfigList) DeepCopy() *ProviderConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccessList.
+func (in *EffectiveAccessList) DeepCopy() *EffectiveAccessList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigList)
+	out := new(EffectiveAccessList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
+func (in *EffectiveAccessList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -389,70 +372,1021 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+func (in *EffectiveAccessObservation) DeepCopyInto(out *EffectiveAccessObservation) {
 	*out = *in
-	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SuperUser != nil {
+		in, out := &in.SuperUser, &out.SuperUser
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]EffectivePermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.ClusterIdentity = in.ClusterIdentity
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
-func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccessObservation.
+func (in *EffectiveAccessObservation) DeepCopy() *EffectiveAccessObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigSpec)
+	out := new(EffectiveAccessObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigStatus) DeepCopyInto(out *ProviderConfigStatus) {
+func (in *EffectiveAccessParameters) DeepCopyInto(out *EffectiveAccessParameters) {
 	*out = *in
-	in.ProviderConfigStatus.DeepCopyInto(&out.ProviderConfigStatus)
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleSelector != nil {
+		in, out := &in.RoleSelector, &out.RoleSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigStatus.
-func (in *ProviderConfigStatus) DeepCopy() *ProviderConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccessParameters.
+func (in *EffectiveAccessParameters) DeepCopy() *EffectiveAccessParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigStatus)
+	out := new(EffectiveAccessParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigUsage) DeepCopyInto(out *ProviderConfigUsage) {
+func (in *EffectiveAccessSpec) DeepCopyInto(out *EffectiveAccessSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.ProviderConfigUsage.DeepCopyInto(&out.ProviderConfigUsage)
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigUsage.
-func (in *ProviderConfigUsage) DeepCopy() *ProviderConfigUsage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccessSpec.
+func (in *EffectiveAccessSpec) DeepCopy() *EffectiveAccessSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigUsage)
+	out := new(EffectiveAccessSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderConfigUsage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigUsageList) DeepCopyInto(out *ProviderConfigUsageList) {
+func (in *EffectiveAccessStatus) DeepCopyInto(out *EffectiveAccessStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveAccessStatus.
+func (in *EffectiveAccessStatus) DeepCopy() *EffectiveAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectivePermission) DeepCopyInto(out *EffectivePermission) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GrantedTo != nil {
+		in, out := &in.GrantedTo, &out.GrantedTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectivePermission.
+func (in *EffectivePermission) DeepCopy() *EffectivePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectivePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionProfile) DeepCopyInto(out *ExecutionProfile) {
+	*out = *in
+	if in.Consistency != nil {
+		in, out := &in.Consistency, &out.Consistency
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionProfile.
+func (in *ExecutionProfile) DeepCopy() *ExecutionProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionProfiles) DeepCopyInto(out *ExecutionProfiles) {
+	*out = *in
+	if in.Read != nil {
+		in, out := &in.Read, &out.Read
+		*out = new(ExecutionProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Write != nil {
+		in, out := &in.Write, &out.Write
+		*out = new(ExecutionProfile)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionProfiles.
+func (in *ExecutionProfiles) DeepCopy() *ExecutionProfiles {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionProfiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalNameFormat) DeepCopyInto(out *ExternalNameFormat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalNameFormat.
+func (in *ExternalNameFormat) DeepCopy() *ExternalNameFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalNameFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverIdentity) DeepCopyInto(out *FailoverIdentity) {
+	*out = *in
+	out.ConnectionSecretRef = in.ConnectionSecretRef
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverIdentity.
+func (in *FailoverIdentity) DeepCopy() *FailoverIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Grant) DeepCopyInto(out *Grant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Grant.
+func (in *Grant) DeepCopy() *Grant {
+	if in == nil {
+		return nil
+	}
+	out := new(Grant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Grant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantList) DeepCopyInto(out *GrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Grant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantList.
+func (in *GrantList) DeepCopy() *GrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantObservation) DeepCopyInto(out *GrantObservation) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.ClusterIdentity = in.ClusterIdentity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantObservation.
+func (in *GrantObservation) DeepCopy() *GrantObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantParameters) DeepCopyInto(out *GrantParameters) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make(GrantPrivileges, len(*in))
+		copy(*out, *in)
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleSelector != nil {
+		in, out := &in.RoleSelector, &out.RoleSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyToExistingTablesIndividually != nil {
+		in, out := &in.ApplyToExistingTablesIndividually, &out.ApplyToExistingTablesIndividually
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnRole != nil {
+		in, out := &in.OnRole, &out.OnRole
+		*out = new(string)
+		**out = **in
+	}
+	if in.OnAllRoles != nil {
+		in, out := &in.OnAllRoles, &out.OnAllRoles
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RevokePublic != nil {
+		in, out := &in.RevokePublic, &out.RevokePublic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PublicRole != nil {
+		in, out := &in.PublicRole, &out.PublicRole
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantParameters.
+func (in *GrantParameters) DeepCopy() *GrantParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in GrantPrivileges) DeepCopyInto(out *GrantPrivileges) {
+	{
+		in := &in
+		*out = make(GrantPrivileges, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantPrivileges.
+func (in GrantPrivileges) DeepCopy() GrantPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantPrivileges)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantSpec) DeepCopyInto(out *GrantSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantSpec.
+func (in *GrantSpec) DeepCopy() *GrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantStatus) DeepCopyInto(out *GrantStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantStatus.
+func (in *GrantStatus) DeepCopy() *GrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Keyspace) DeepCopyInto(out *Keyspace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyspace.
+func (in *Keyspace) DeepCopy() *Keyspace {
+	if in == nil {
+		return nil
+	}
+	out := new(Keyspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Keyspace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceList) DeepCopyInto(out *KeyspaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Keyspace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceList.
+func (in *KeyspaceList) DeepCopy() *KeyspaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyspaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceObservation) DeepCopyInto(out *KeyspaceObservation) {
+	*out = *in
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DurableWrites != nil {
+		in, out := &in.DurableWrites, &out.DurableWrites
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastOperation != nil {
+		in, out := &in.LastOperation, &out.LastOperation
+		*out = new(KeyspaceOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ClusterIdentity = in.ClusterIdentity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceObservation.
+func (in *KeyspaceObservation) DeepCopy() *KeyspaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceOperation) DeepCopyInto(out *KeyspaceOperation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceOperation.
+func (in *KeyspaceOperation) DeepCopy() *KeyspaceOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceParameters) DeepCopyInto(out *KeyspaceParameters) {
+	*out = *in
+	if in.ReplicationClass != nil {
+		in, out := &in.ReplicationClass, &out.ReplicationClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplicationFactor != nil {
+		in, out := &in.ReplicationFactor, &out.ReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.TransientReplicas != nil {
+		in, out := &in.TransientReplicas, &out.TransientReplicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutoDatacenterReplication != nil {
+		in, out := &in.AutoDatacenterReplication, &out.AutoDatacenterReplication
+		*out = new(AutoDatacenterReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DurableWrites != nil {
+		in, out := &in.DurableWrites, &out.DurableWrites
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutoCorrectDrift != nil {
+		in, out := &in.AutoCorrectDrift, &out.AutoCorrectDrift
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IfNotExists != nil {
+		in, out := &in.IfNotExists, &out.IfNotExists
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BootstrapFrom != nil {
+		in, out := &in.BootstrapFrom, &out.BootstrapFrom
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.LateInitializePolicy != nil {
+		in, out := &in.LateInitializePolicy, &out.LateInitializePolicy
+		*out = new(LateInitializePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AwaitSchemaInAllDatacenters != nil {
+		in, out := &in.AwaitSchemaInAllDatacenters, &out.AwaitSchemaInAllDatacenters
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceParameters.
+func (in *KeyspaceParameters) DeepCopy() *KeyspaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceSpec) DeepCopyInto(out *KeyspaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceSpec.
+func (in *KeyspaceSpec) DeepCopy() *KeyspaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceStatus) DeepCopyInto(out *KeyspaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceStatus.
+func (in *KeyspaceStatus) DeepCopy() *KeyspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LateInitializePolicy) DeepCopyInto(out *LateInitializePolicy) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LateInitializePolicy.
+func (in *LateInitializePolicy) DeepCopy() *LateInitializePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LateInitializePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementAPIConfig) DeepCopyInto(out *ManagementAPIConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementAPIConfig.
+func (in *ManagementAPIConfig) DeepCopy() *ManagementAPIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementAPIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationConfig) DeepCopyInto(out *NotificationConfig) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationConfig.
+func (in *NotificationConfig) DeepCopy() *NotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnersConfig) DeepCopyInto(out *OwnersConfig) {
+	*out = *in
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnersConfig.
+func (in *OwnersConfig) DeepCopy() *OwnersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacingConfig) DeepCopyInto(out *PacingConfig) {
+	*out = *in
+	if in.MaxPendingCompactions != nil {
+		in, out := &in.MaxPendingCompactions, &out.MaxPendingCompactions
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPendingMutations != nil {
+		in, out := &in.MaxPendingMutations, &out.MaxPendingMutations
+		*out = new(int)
+		**out = **in
+	}
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacingConfig.
+func (in *PacingConfig) DeepCopy() *PacingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PacingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordConfig) DeepCopyInto(out *PasswordConfig) {
+	*out = *in
+	if in.Length != nil {
+		in, out := &in.Length, &out.Length
+		*out = new(int)
+		**out = **in
+	}
+	if in.CharacterSet != nil {
+		in, out := &in.CharacterSet, &out.CharacterSet
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordConfig.
+func (in *PasswordConfig) DeepCopy() *PasswordConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
+func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigList.
+func (in *ProviderConfigList) DeepCopy() *ProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+	*out = *in
+	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.FailoverIdentities != nil {
+		in, out := &in.FailoverIdentities, &out.FailoverIdentities
+		*out = make([]FailoverIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalName != nil {
+		in, out := &in.ExternalName, &out.ExternalName
+		*out = new(ExternalNameFormat)
+		**out = **in
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(ProviderQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultConsistencySerial != nil {
+		in, out := &in.DefaultConsistencySerial, &out.DefaultConsistencySerial
+		*out = new(string)
+		**out = **in
+	}
+	if in.ObserveWith != nil {
+		in, out := &in.ObserveWith, &out.ObserveWith
+		*out = new(string)
+		**out = **in
+	}
+	if in.ManagementAPI != nil {
+		in, out := &in.ManagementAPI, &out.ManagementAPI
+		*out = new(ManagementAPIConfig)
+		**out = **in
+	}
+	if in.ExecutionProfiles != nil {
+		in, out := &in.ExecutionProfiles, &out.ExecutionProfiles
+		*out = new(ExecutionProfiles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPreparedStatements != nil {
+		in, out := &in.MaxPreparedStatements, &out.MaxPreparedStatements
+		*out = new(int)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthMechanism != nil {
+		in, out := &in.AuthMechanism, &out.AuthMechanism
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConnectionSecretMetadata != nil {
+		in, out := &in.ConnectionSecretMetadata, &out.ConnectionSecretMetadata
+		*out = new(SecretMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Descriptions != nil {
+		in, out := &in.Descriptions, &out.Descriptions
+		*out = new(DescriptionsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = new(OwnersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Passwords != nil {
+		in, out := &in.Passwords, &out.Passwords
+		*out = new(PasswordConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowSuperuserRoles != nil {
+		in, out := &in.AllowSuperuserRoles, &out.AllowSuperuserRoles
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LazyConnect != nil {
+		in, out := &in.LazyConnect, &out.LazyConnect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EventAnnotations != nil {
+		in, out := &in.EventAnnotations, &out.EventAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Pacing != nil {
+		in, out := &in.Pacing, &out.Pacing
+		*out = new(PacingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
+func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigStatus) DeepCopyInto(out *ProviderConfigStatus) {
+	*out = *in
+	in.ProviderConfigStatus.DeepCopyInto(&out.ProviderConfigStatus)
+	if in.DriftReport != nil {
+		in, out := &in.DriftReport, &out.DriftReport
+		*out = new(DriftAudit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveIdentity != nil {
+		in, out := &in.ActiveIdentity, &out.ActiveIdentity
+		*out = new(ActiveIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigStatus.
+func (in *ProviderConfigStatus) DeepCopy() *ProviderConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigUsage) DeepCopyInto(out *ProviderConfigUsage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.ProviderConfigUsage.DeepCopyInto(&out.ProviderConfigUsage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigUsage.
+func (in *ProviderConfigUsage) DeepCopy() *ProviderConfigUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfigUsage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigUsageList) DeepCopyInto(out *ProviderConfigUsageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
 		*out = make([]ProviderConfigUsage, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
@@ -486,6 +1420,11 @@ func (in *ProviderCredentials) DeepCopyInto(out *ProviderCredentials) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.DatacenterRef != nil {
+		in, out := &in.DatacenterRef, &out.DatacenterRef
+		*out = new(DatacenterReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentials.
@@ -498,6 +1437,36 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderQuota) DeepCopyInto(out *ProviderQuota) {
+	*out = *in
+	if in.MaxKeyspaces != nil {
+		in, out := &in.MaxKeyspaces, &out.MaxKeyspaces
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTotalReplicationFactor != nil {
+		in, out := &in.MaxTotalReplicationFactor, &out.MaxTotalReplicationFactor
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxRoles != nil {
+		in, out := &in.MaxRoles, &out.MaxRoles
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderQuota.
+func (in *ProviderQuota) DeepCopy() *ProviderQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Role) DeepCopyInto(out *Role) {
 	*out = *in
@@ -557,10 +1526,85 @@ func (in *RoleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+	*out = *in
+	if in.SuperUser != nil {
+		in, out := &in.SuperUser, &out.SuperUser
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PasswordRotatedAt != nil {
+		in, out := &in.PasswordRotatedAt, &out.PasswordRotatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = new(RoleRename)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ClusterIdentity = in.ClusterIdentity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
+func (in *RoleObservation) DeepCopy() *RoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
 	*out = *in
 	in.Privileges.DeepCopyInto(&out.Privileges)
+	if in.IfNotExists != nil {
+		in, out := &in.IfNotExists, &out.IfNotExists
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PasswordOnAdoption != nil {
+		in, out := &in.PasswordOnAdoption, &out.PasswordOnAdoption
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConnectionDetailsFormat != nil {
+		in, out := &in.ConnectionDetailsFormat, &out.ConnectionDetailsFormat
+		*out = make([]ConnectionDetailsFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionSecretKeys != nil {
+		in, out := &in.ConnectionSecretKeys, &out.ConnectionSecretKeys
+		*out = new(ConnectionSecretKeys)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalPasswordChangePolicy != nil {
+		in, out := &in.ExternalPasswordChangePolicy, &out.ExternalPasswordChangePolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.RotatePasswordEvery != nil {
+		in, out := &in.RotatePasswordEvery, &out.RotatePasswordEvery
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.LateInitializePolicy != nil {
+		in, out := &in.LateInitializePolicy, &out.LateInitializePolicy
+		*out = new(LateInitializePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
@@ -598,6 +1642,25 @@ func (in *RolePrivilege) DeepCopy() *RolePrivilege {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleRename) DeepCopyInto(out *RoleRename) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleRename.
+func (in *RoleRename) DeepCopy() *RoleRename {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
 	*out = *in
@@ -619,6 +1682,7 @@ func (in *RoleSpec) DeepCopy() *RoleSpec {
 func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
@@ -630,3 +1694,231 @@ func (in *RoleStatus) DeepCopy() *RoleStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretMetadata) DeepCopyInto(out *SecretMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretMetadata.
+func (in *SecretMetadata) DeepCopy() *SecretMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	if in.PortName != nil {
+		in, out := &in.PortName, &out.PortName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resolve != nil {
+		in, out := &in.Resolve, &out.Resolve
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.ServerName != nil {
+		in, out := &in.ServerName, &out.ServerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableHostVerification != nil {
+		in, out := &in.EnableHostVerification, &out.EnableHostVerification
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trigger) DeepCopyInto(out *Trigger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
+func (in *Trigger) DeepCopy() *Trigger {
+	if in == nil {
+		return nil
+	}
+	out := new(Trigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Trigger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerList) DeepCopyInto(out *TriggerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Trigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerList.
+func (in *TriggerList) DeepCopy() *TriggerList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerObservation) DeepCopyInto(out *TriggerObservation) {
+	*out = *in
+	out.ClusterIdentity = in.ClusterIdentity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerObservation.
+func (in *TriggerObservation) DeepCopy() *TriggerObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerParameters) DeepCopyInto(out *TriggerParameters) {
+	*out = *in
+	if in.Keyspace != nil {
+		in, out := &in.Keyspace, &out.Keyspace
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyspaceRef != nil {
+		in, out := &in.KeyspaceRef, &out.KeyspaceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyspaceSelector != nil {
+		in, out := &in.KeyspaceSelector, &out.KeyspaceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerParameters.
+func (in *TriggerParameters) DeepCopy() *TriggerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSpec.
+func (in *TriggerSpec) DeepCopy() *TriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
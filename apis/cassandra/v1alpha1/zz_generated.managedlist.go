@@ -19,6 +19,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
+// GetItems of this EffectiveAccessList.
+func (l *EffectiveAccessList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this GrantList.
 func (l *GrantList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -45,3 +54,12 @@ func (l *RoleList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this TriggerList.
+func (l *TriggerList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// crd is the part of a CustomResourceDefinition the test reads.
+type crd struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+	} `json:"spec"`
+}
+
+// TestCRDs verifies that a CRD was generated for every kind of the scheme, so
+// that kinds are not added without running go generate.
+func TestCRDs(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join("..", "package", "crds", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := make(map[string]bool, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Clean(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &crd{}
+		if err := yaml.Unmarshal(b, c); err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		generated[c.Spec.Group+"/"+c.Spec.Names.Kind] = true
+	}
+
+	for gvk := range s.AllKnownTypes() {
+		o, err := s.New(gvk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Lists and the options every group version registers are not
+		// custom resources.
+		if _, ok := o.(resource.Object); !ok || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		if !generated[gvk.Group+"/"+gvk.Kind] {
+			t.Errorf("%s: no CRD was generated, run go generate ./...", gvk)
+		}
+	}
+}
@@ -441,6 +441,172 @@ cleanup_test_resources() {
   "${KUBECTL}" delete secret example-pw
 }
 
+# Cassandra versions the reconcilers are tested against in parallel. The
+# schema tables the provider observes differ between them.
+CASSANDRA_VERSIONS="${CASSANDRA_VERSIONS:-3.11 4.1 5.0}"
+
+# cassandra_name returns the name of the Deployment, Service, Secret and
+# ProviderConfig of the supplied Cassandra version, e.g. cassandra-4-1.
+cassandra_name() {
+  echo "cassandra-${1//./-}"
+}
+
+setup_cassandra() {
+  local version="$1"
+  local name="$(cassandra_name "${version}")"
+  echo_step "installing Cassandra ${version}"
+  "${KUBECTL}" create secret generic "${name}-creds" \
+      --from-literal endpoint="${name}.default.svc.cluster.local" \
+      --from-literal port="9042"
+
+  local yaml="$( cat <<EOF
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ${name}
+spec:
+  selector:
+    matchLabels:
+      app: ${name}
+  template:
+    metadata:
+      labels:
+        app: ${name}
+    spec:
+      containers:
+        - name: cassandra
+          image: cassandra:${version}
+          env:
+            - name: MAX_HEAP_SIZE
+              value: 512M
+            - name: HEAP_NEWSIZE
+              value: 128M
+          ports:
+            - containerPort: 9042
+          readinessProbe:
+            exec:
+              command: ["cqlsh", "-e", "SELECT release_version FROM system.local"]
+            initialDelaySeconds: 20
+            periodSeconds: 10
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: ${name}
+spec:
+  selector:
+    app: ${name}
+  ports:
+    - port: 9042
+---
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: ProviderConfig
+metadata:
+  name: ${name}
+spec:
+  credentials:
+    source: CassandraConnectionSecret
+    connectionSecretRef:
+      namespace: default
+      name: ${name}-creds
+EOF
+  )"
+
+  echo "${yaml}" | "${KUBECTL}" apply -f -
+}
+
+wait_cassandra() {
+  local name="$(cassandra_name "$1")"
+  echo_step "waiting for Cassandra $1"
+  "${KUBECTL}" rollout status "deployment/${name}" --timeout 10m
+}
+
+cleanup_cassandra() {
+  local name="$(cassandra_name "$1")"
+  echo_step "uninstalling Cassandra $1"
+  "${KUBECTL}" delete providerconfig.cassandra.cql.crossplane.io "${name}"
+  "${KUBECTL}" delete deployment "${name}"
+  "${KUBECTL}" delete service "${name}"
+  "${KUBECTL}" delete secret "${name}-creds"
+}
+
+# await_jsonpath waits until the supplied JSONPath of the supplied resource
+# has the supplied value.
+await_jsonpath() {
+  local resource="$1" path="$2" want="$3"
+  for _ in $(seq 60); do
+    [ "$("${KUBECTL}" get "${resource}" -o jsonpath="${path}")" == "${want}" ] && return 0
+    sleep 2
+  done
+  echo_error "${resource}: ${path} is not ${want}"
+}
+
+# test_cassandra_keyspace creates, updates and deletes a Keyspace in the
+# cluster of the supplied Cassandra version, and checks that its replication
+# is observed. Roles and grants are not tested: the Cassandra images do not
+# enable authentication.
+test_cassandra_keyspace() {
+  local version="$1"
+  local name="$(cassandra_name "${version}")"
+  local ks="keyspace.cassandra.cql.crossplane.io/inttest-${name}"
+  echo_step "test Cassandra ${version} Keyspace resource"
+
+  local yaml="$( cat <<EOF
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: Keyspace
+metadata:
+  name: inttest-${name}
+  annotations:
+    crossplane.io/external-name: inttest
+spec:
+  providerConfigRef:
+    name: ${name}
+  forProvider:
+    replicationClass: SimpleStrategy
+    replicationFactor: 1
+    durableWrites: true
+EOF
+  )"
+  echo "${yaml}" | "${KUBECTL}" apply -f -
+
+  echo_info "check if is ready"
+  "${KUBECTL}" wait --timeout 3m --for condition=Ready "${ks}"
+  await_jsonpath "${ks}" '{.status.atProvider.replicationClass}' SimpleStrategy
+  await_jsonpath "${ks}" '{.status.atProvider.replicationFactor}' 1
+  echo_step_completed
+
+  echo_info "check if updates are observed"
+  "${KUBECTL}" patch "${ks}" --type merge -p '{"spec":{"forProvider":{"durableWrites":false}}}'
+  await_jsonpath "${ks}" '{.status.atProvider.durableWrites}' false
+  "${KUBECTL}" wait --timeout 2m --for condition=Synced "${ks}"
+  echo_step_completed
+
+  echo_info "check if is deleted"
+  "${KUBECTL}" delete "${ks}"
+  "${KUBECTL}" wait --timeout 2m --for delete "${ks}"
+  echo_step_completed
+}
+
+# test_cassandra runs the Cassandra tests against every version in parallel,
+# and prints the output of each version once all of them finished.
+test_cassandra() {
+  local logs="$(mktemp -d)"
+  local pids=() version
+  for version in ${CASSANDRA_VERSIONS}; do
+    ( set -e; test_cassandra_keyspace "${version}" ) >"${logs}/${version}.log" 2>&1 &
+    pids+=("$!")
+  done
+
+  local failed=() i=0
+  for version in ${CASSANDRA_VERSIONS}; do
+    wait "${pids[$i]}" || failed+=("${version}")
+    cat "${logs}/${version}.log"
+    i=$((i + 1))
+  done
+  rm -rf "${logs}"
+  [ ${#failed[@]} -eq 0 ] || echo_error "Cassandra tests failed for ${failed[*]}"
+}
+
 setup_cluster
 setup_crossplane
 setup_provider
@@ -469,6 +635,21 @@ cleanup_provider_config
 cleanup_mariadb
 cleanup_tls_certs
 
+echo_step "--- INTEGRATION TESTS - CASSANDRA ${CASSANDRA_VERSIONS} ---"
+
+for version in ${CASSANDRA_VERSIONS}; do
+  setup_cassandra "${version}"
+done
+for version in ${CASSANDRA_VERSIONS}; do
+  wait_cassandra "${version}"
+done
+
+test_cassandra
+
+for version in ${CASSANDRA_VERSIONS}; do
+  cleanup_cassandra "${version}"
+done
+
 echo_step "--- CLEAN-UP ---"
 cleanup_provider
 
@@ -17,23 +17,58 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "github.com/denisenkom/go-mssqldb"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/yaml"
 
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-sql/apis"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/config"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/driftreport"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/export"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/preflight"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/role"
+	"github.com/crossplane-contrib/provider-sql/pkg/examples"
+	"github.com/crossplane-contrib/provider-sql/pkg/features"
+)
+
+const (
+	// validateTimeout bounds the validation of ProviderConfigs on startup.
+	validateTimeout = 2 * time.Minute
+
+	// shutdownMargin is how much longer than the termination grace period
+	// the controller manager waits for its controllers to stop, so that
+	// reconciles whose statements were cancelled can return.
+	shutdownMargin = 5 * time.Second
 )
 
 func main() {
@@ -43,8 +78,52 @@ func main() {
 		pollInterval   = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("10m").Duration()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").Envar("LEADER_ELECTION").Bool()
+		leaseDuration  = app.Flag("leader-election-lease-duration", "Duration non-leader replicas wait before attempting to acquire leadership.").Default("15s").Duration()
+		renewDeadline  = app.Flag("leader-election-renew-deadline", "Duration the leader retries refreshing leadership before giving it up.").Default("10s").Duration()
+		retryPeriod    = app.Flag("leader-election-retry-period", "Duration replicas wait between attempts to acquire or renew leadership.").Default("2s").Duration()
+		healthAddr     = app.Flag("health-probe-bind-address", "Address the health and readiness probes are served on by every replica.").Default(":8081").String()
+		noLateInit     = app.Flag("disable-late-init", "Kind of Cassandra managed resource whose spec is never late initialized, e.g. to avoid spec changes that GitOps tools report as drift. Observed values are still reported in its status. May be repeated.").Enums("All", "Keyspace", "Role")
+		validatePCs    = app.Flag("validate-provider-configs", "Validate every Cassandra ProviderConfig on startup and log a summary.").Default("false").Bool()
+		validateProbe  = app.Flag("validate-provider-configs-connect", "Also connect to the cluster of every Cassandra ProviderConfig when validating them.").Default("false").Bool()
+		terminateOnErr = app.Flag("terminate-on-config-error", "Validate every Cassandra ProviderConfig on startup and exit if any is invalid.").Default("false").Bool()
+		auditInterval  = app.Flag("audit-interval", "Interval at which all Cassandra managed resources are audited against their clusters in bulk. Disabled if zero.").Default("0").Duration()
+		enableWebhooks = app.Flag("enable-webhooks", "Serve the admission webhooks of Cassandra managed resources, e.g. to reject renaming the role of a Role or to warn about incomplete ProviderConfig credentials.").Default("false").Envar("ENABLE_WEBHOOKS").Bool()
+		certsDir       = app.Flag("webhook-certs-dir", "Directory containing the tls.crt and tls.key the admission webhooks are served with.").Default("/tls/server").Envar("TLS_SERVER_CERTS_DIR").String()
+		metricsAllow   = app.Flag("metrics-resource-allow", "Regular expression of the names of Cassandra managed resources whose statement durations are labelled with their name. May be repeated.").RegexpList()
+		metricsBuckets = app.Flag("metrics-resource-hash-buckets", "Number of buckets the names of other Cassandra managed resources are hashed into to label their statement durations. Not labelled if zero.").Default("0").Int()
+		gracePeriod    = app.Flag("termination-grace-period", "How long Cassandra statements that are in flight when the provider shuts down are waited for before they are cancelled. Keep it shorter than the terminationGracePeriodSeconds of the provider pod.").Default(drain.DefaultGracePeriod.String()).Duration()
+		exemplars      = app.Flag("metrics-exemplars", "Attach the kind and name of Cassandra managed resources to their statement durations as exemplars, served in the OpenMetrics format at "+latency.Path+".").Default("false").Bool()
+
+		_            = app.Command("start", "Start the provider controllers.").Default()
+		examplesCmd  = app.Command("generate-examples", "Generate example manifests for every managed resource kind.")
+		examplesDir  = examplesCmd.Flag("output-dir", "Directory to write the example manifests to.").Default("examples/generated").String()
+		cassandraCmd = app.Command("cassandra", "Work with Cassandra managed resources.")
+		renderCmd    = cassandraCmd.Command("render", "Print the CQL statements that Cassandra manifests execute when they are created. Passwords are redacted.")
+		renderFile   = renderCmd.Flag("file", "Manifest to render, or - to read it from stdin.").Short('f').Required().String()
+		exportCmd    = cassandraCmd.Command("export", "Export the schema of the keyspaces of the cluster of a ProviderConfig, described by the server on Cassandra 4.0 and later.")
+		exportPC     = exportCmd.Flag("provider-config", "ProviderConfig whose cluster is exported.").Required().String()
+		exportKS     = exportCmd.Flag("keyspace", "Keyspace to export. All keyspaces other than the system keyspaces are exported if none is set. May be repeated.").Strings()
+		exportFormat = exportCmd.Flag("format", "Export CQL scripts or ConfigMaps keeping them.").Default("CQL").Enum("CQL", "ConfigMap")
+		exportNS     = exportCmd.Flag("namespace", "Namespace of the exported ConfigMaps.").Default("crossplane-system").String()
+		exportDir    = exportCmd.Flag("output-dir", "Directory to write a file per keyspace to. The schema is printed if it is not set.").String()
 	)
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	cmd := kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	if cmd == examplesCmd.FullCommand() {
+		s := runtime.NewScheme()
+		kingpin.FatalIfError(apis.AddToScheme(s), "Cannot add SQL APIs to scheme")
+		paths, err := examples.Generate(s, *examplesDir)
+		kingpin.FatalIfError(err, "Cannot generate examples")
+		for _, p := range paths {
+			fmt.Println(p)
+		}
+		return
+	}
+
+	if cmd == renderCmd.FullCommand() {
+		kingpin.FatalIfError(renderManifests(*renderFile), "Cannot render Cassandra manifests")
+		return
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-sql"))
@@ -55,26 +134,190 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
-
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
+	if cmd == exportCmd.FullCommand() {
+		kingpin.FatalIfError(exportSchemas(cfg, log, *exportPC, *exportKS, *exportFormat, *exportNS, *exportDir), "Cannot export Cassandra schema")
+		return
+	}
+
+	log.Debug("Starting", "sync-period", syncPeriod.String())
+
+	if !*leaderElection {
+		log.Info("Leader election is disabled: run a single replica, as every replica reconciles and executes statements against the managed servers")
+	}
+
+	// Exemplars are only exposed in the OpenMetrics format, which the
+	// default metrics endpoint does not negotiate.
+	mo := metricsserver.Options{BindAddress: ":8080"}
+	if *exemplars {
+		mo.ExtraHandlers = map[string]http.Handler{
+			latency.Path: promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+		}
+	}
+
+	shutdownTimeout := *gracePeriod + shutdownMargin
+
+	// Controllers and the drift reporter only run on the leader, so
+	// followers serve health probes and metrics but never execute
+	// statements against the managed servers.
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		LeaderElection:   *leaderElection,
-		LeaderElectionID: "crossplane-leader-election-provider-sql",
+		LeaderElection:                *leaderElection,
+		LeaderElectionID:              "crossplane-leader-election-provider-sql",
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 leaseDuration,
+		RenewDeadline:                 renewDeadline,
+		RetryPeriod:                   retryPeriod,
+		GracefulShutdownTimeout:       &shutdownTimeout,
+		HealthProbeBindAddress:        *healthAddr,
+		Metrics:                       mo,
 		Cache: cache.Options{
 			SyncPeriod: syncPeriod,
 		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *certsDir,
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add health check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("ping", healthz.Ping), "Cannot add readiness check")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add SQL APIs to scheme")
 
 	o := xpcontroller.Options{
 		Logger:       log,
 		PollInterval: *pollInterval,
+		Features:     &feature.Flags{},
+	}
+
+	for _, kind := range *noLateInit {
+		if kind == "All" || kind == "Keyspace" {
+			o.Features.Enable(features.DisableKeyspaceLateInit)
+		}
+		if kind == "All" || kind == "Role" {
+			o.Features.Enable(features.DisableRoleLateInit)
+		}
+	}
+
+	if *validatePCs || *terminateOnErr {
+		// The manager's cache is not started yet, so read directly.
+		kube, err := client.New(cfg, client.Options{Scheme: mgr.GetScheme()})
+		kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+		vctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+		results, err := preflight.NewValidator(kube, *validateProbe).ValidateAll(vctx)
+		cancel()
+		kingpin.FatalIfError(err, "Cannot validate Cassandra ProviderConfigs")
+
+		invalid := 0
+		for _, r := range results {
+			if r.Err != nil {
+				invalid++
+				log.Info("Invalid Cassandra ProviderConfig", "name", r.Name, "error", r.Err)
+			}
+		}
+		log.Info("Validated Cassandra ProviderConfigs", "valid", len(results)-invalid, "invalid", invalid)
+		if *terminateOnErr && invalid > 0 {
+			kingpin.Fatalf("%d Cassandra ProviderConfigs are invalid", invalid)
+		}
 	}
 
+	lo := []latency.Option{latency.WithAllowList(*metricsAllow), latency.WithHashBuckets(*metricsBuckets)}
+	if *exemplars {
+		lo = append(lo, latency.WithExemplars())
+	}
+	latency.Default = latency.NewRecorder(lo...)
+
+	// Statements that are in flight when the provider shuts down complete
+	// before their sessions are closed, rather than being aborted.
+	drain.Default = drain.NewDrainer(*gracePeriod, log.WithValues("component", "drain"))
+	kingpin.FatalIfError(mgr.Add(drain.Default), "Cannot setup Cassandra statement draining")
+
 	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup SQL controllers")
+	if *enableWebhooks {
+		kingpin.FatalIfError(role.SetupWebhook(mgr), "Cannot setup Cassandra Role webhook")
+		kingpin.FatalIfError(config.SetupWebhook(mgr), "Cannot setup Cassandra ProviderConfig webhook")
+	}
+	if *auditInterval > 0 {
+		kingpin.FatalIfError(mgr.Add(driftreport.NewReporter(mgr.GetClient(), log.WithValues("component", "driftreport"), *auditInterval)), "Cannot setup Cassandra drift report")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
+
+// renderManifests prints the CQL statements of the Cassandra manifests in the
+// supplied file, or stdin if it is -.
+func renderManifests(file string) error {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		return err
+	}
+
+	in := os.Stdin
+	if file != "-" {
+		f, err := os.Open(filepath.Clean(file))
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck // Only read.
+		in = f
+	}
+
+	stmts, err := render.Manifests(in, s)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		fmt.Printf("%s;\n", stmt)
+	}
+	return nil
+}
+
+// exportSchemas exports the schema of the supplied keyspaces of the cluster of
+// the supplied ProviderConfig, in the supplied format, to a file per keyspace
+// in the supplied directory or stdout if it is empty.
+func exportSchemas(cfg *rest.Config, log logging.Logger, pc string, keyspaces []string, format, namespace, dir string) error {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		return err
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		return err
+	}
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return err
+	}
+
+	schemas, err := export.NewExporter(kube, log).Export(context.Background(), pc, keyspaces)
+	if err != nil {
+		return err
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return err
+		}
+	}
+	for _, schema := range schemas {
+		if !schema.Described {
+			log.Info("Server does not support DESCRIBE, exported schema lacks table options, functions, aggregates and views", "keyspace", schema.Keyspace)
+		}
+
+		out, name := []byte(schema.CQL()), schema.Keyspace+".cql"
+		if format == "ConfigMap" {
+			cm := schema.ConfigMap(namespace)
+			if out, err = yaml.Marshal(cm); err != nil {
+				return err
+			}
+			out, name = append([]byte("---\n"), out...), cm.GetName()+".yaml"
+		}
+
+		if dir == "" {
+			fmt.Print(string(out))
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), out, 0o600); err != nil {
+			return err
+		}
+		fmt.Println(filepath.Join(dir, name))
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Access is what a role is allowed to do, including what it is allowed to do
+// through the roles granted to it.
+type Access struct {
+	// Roles are the role and the roles granted to it, directly or through
+	// other roles, sorted by name.
+	Roles []string
+
+	// SuperUser is true if any of the roles is a superuser.
+	SuperUser bool
+
+	// Permissions are the permissions of all roles, merged by resource and
+	// sorted by resource.
+	Permissions []EffectivePermission
+}
+
+// An EffectivePermission lists the permissions held on a resource and the
+// roles they are granted to.
+type EffectivePermission struct {
+	Resource    string
+	Permissions []string
+	GrantedTo   []string
+}
+
+// EffectiveAccess returns the access of the named role, following the roles
+// granted to it. It reports false if the role does not exist.
+func (c *CassandraDB) EffectiveAccess(ctx context.Context, role string) (Access, bool, error) {
+	a := Access{}
+	seen := map[string]bool{role: true}
+	queue := []string{role}
+	var permissions []Permission
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+
+		super, memberOf, exists, err := c.roleMembership(ctx, r)
+		if err != nil {
+			return Access{}, false, err
+		}
+		if !exists {
+			if r == role {
+				return Access{}, false, nil
+			}
+			// A granted role was dropped while we were reading.
+			continue
+		}
+		a.Roles = append(a.Roles, r)
+		a.SuperUser = a.SuperUser || super
+
+		p, err := c.rolePermissions(ctx, r)
+		if err != nil {
+			return Access{}, false, err
+		}
+		permissions = append(permissions, p...)
+
+		for _, m := range memberOf {
+			if !seen[m] {
+				seen[m] = true
+				queue = append(queue, m)
+			}
+		}
+	}
+
+	sort.Strings(a.Roles)
+	a.Permissions = MergePermissions(permissions)
+	return a, true, nil
+}
+
+// MergePermissions merges the supplied permissions of several roles by
+// resource. Permissions, resources and roles are sorted and deduplicated.
+func MergePermissions(permissions []Permission) []EffectivePermission {
+	byResource := map[string]*EffectivePermission{}
+	for _, p := range permissions {
+		e, ok := byResource[p.Resource]
+		if !ok {
+			e = &EffectivePermission{Resource: p.Resource}
+			byResource[p.Resource] = e
+		}
+		e.Permissions = append(e.Permissions, p.Permissions...)
+		e.GrantedTo = append(e.GrantedTo, p.Role)
+	}
+
+	merged := make([]EffectivePermission, 0, len(byResource))
+	for _, e := range byResource {
+		e.Permissions = uniqueSorted(e.Permissions)
+		e.GrantedTo = uniqueSorted(e.GrantedTo)
+		merged = append(merged, *e)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Resource < merged[j].Resource })
+	return merged
+}
+
+func uniqueSorted(s []string) []string {
+	sort.Strings(s)
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// roleMembership returns whether the named role is a superuser and the roles
+// granted to it. It reports false if the role does not exist.
+func (c *CassandraDB) roleMembership(ctx context.Context, role string) (bool, []string, bool, error) {
+	iter, err := c.Query(ctx, "SELECT is_superuser, member_of FROM system_auth.roles WHERE role = ?", role)
+	if err != nil {
+		return false, nil, false, err
+	}
+
+	var super bool
+	var memberOf []string
+	exists := iter.Scan(&super, &memberOf)
+	if err := iter.Close(); err != nil {
+		return false, nil, false, fmt.Errorf("failed to select role membership: %w", err)
+	}
+	return super, memberOf, exists, nil
+}
+
+// rolePermissions returns the permissions granted directly to the named role
+// on all resources.
+func (c *CassandraDB) rolePermissions(ctx context.Context, role string) ([]Permission, error) {
+	iter, err := c.Query(ctx, "SELECT resource, permissions FROM system_auth.role_permissions WHERE role = ?", role)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []Permission
+	p := Permission{Role: role}
+	for iter.Scan(&p.Resource, &p.Permissions) {
+		permissions = append(permissions, p)
+		p = Permission{Role: role}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to select role permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// RoleGrants are what a role was granted directly, and the roles it was
+// granted to.
+type RoleGrants struct {
+	// MemberOf are the roles granted to the role.
+	MemberOf []string
+
+	// Members are the roles the role is granted to.
+	Members []string
+
+	// Permissions granted to the role.
+	Permissions []Permission
+}
+
+// Grants returns what the named role was granted directly, and the roles it
+// was granted to. It reports false if the role does not exist.
+func (c *CassandraDB) Grants(ctx context.Context, role string) (RoleGrants, bool, error) {
+	_, memberOf, exists, err := c.roleMembership(ctx, role)
+	if err != nil || !exists {
+		return RoleGrants{}, false, err
+	}
+
+	iter, err := c.Query(ctx, "SELECT member FROM system_auth.role_members WHERE role = ?", role)
+	if err != nil {
+		return RoleGrants{}, false, err
+	}
+	var members []string
+	var member string
+	for iter.Scan(&member) {
+		members = append(members, member)
+	}
+	if err := iter.Close(); err != nil {
+		return RoleGrants{}, false, fmt.Errorf("failed to select role members: %w", err)
+	}
+
+	permissions, err := c.rolePermissions(ctx, role)
+	if err != nil {
+		return RoleGrants{}, false, err
+	}
+
+	sort.Strings(memberOf)
+	sort.Strings(members)
+	return RoleGrants{MemberOf: memberOf, Members: members, Permissions: permissions}, true, nil
+}
@@ -0,0 +1,36 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergePermissions(t *testing.T) {
+	cases := map[string]struct {
+		in   []Permission
+		want []EffectivePermission
+	}{
+		"None": {
+			in:   nil,
+			want: []EffectivePermission{},
+		},
+		"Merged": {
+			in: []Permission{
+				{Role: "app", Resource: "data/shop", Permissions: []string{"SELECT", "MODIFY"}},
+				{Role: "readers", Resource: "data/shop", Permissions: []string{"SELECT"}},
+				{Role: "readers", Resource: "data", Permissions: []string{"DESCRIBE"}},
+			},
+			want: []EffectivePermission{
+				{Resource: "data", Permissions: []string{"DESCRIBE"}, GrantedTo: []string{"readers"}},
+				{Resource: "data/shop", Permissions: []string{"MODIFY", "SELECT"}, GrantedTo: []string{"app", "readers"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		if diff := cmp.Diff(tc.want, MergePermissions(tc.in)); diff != "" {
+			t.Errorf("%s: -want, +got:\n%s", name, diff)
+		}
+	}
+}
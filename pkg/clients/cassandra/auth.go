@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gocql/gocql"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AuthPassword is the built-in authentication mechanism, which authenticates
+// with the username and password of the credentials.
+const AuthPassword = "Password"
+
+// An AuthenticatorFactory returns an authenticator for the supplied
+// connection credentials.
+type AuthenticatorFactory func(creds map[string][]byte) (gocql.Authenticator, error)
+
+var (
+	authMu         sync.RWMutex
+	authenticators = map[string]AuthenticatorFactory{
+		AuthPassword: func(creds map[string][]byte) (gocql.Authenticator, error) {
+			return gocql.PasswordAuthenticator{
+				Username: string(creds[xpv1.ResourceCredentialsSecretUserKey]),
+				Password: string(creds[xpv1.ResourceCredentialsSecretPasswordKey]),
+			}, nil
+		},
+	}
+)
+
+// RegisterAuthenticator registers an authentication mechanism that
+// ProviderConfigs may select, e.g. GSSAPI. It is intended to be called from
+// the init function of the package implementing the mechanism. Registering a
+// mechanism again replaces it.
+func RegisterAuthenticator(mechanism string, f AuthenticatorFactory) {
+	authMu.Lock()
+	defer authMu.Unlock()
+	authenticators[mechanism] = f
+}
+
+// Authenticator returns an authenticator of the supplied mechanism for the
+// supplied credentials. Password authentication is used if mechanism is nil.
+func Authenticator(mechanism *string, creds map[string][]byte) (gocql.Authenticator, error) {
+	m := AuthPassword
+	if mechanism != nil {
+		m = *mechanism
+	}
+
+	authMu.RLock()
+	f, ok := authenticators[m]
+	known := make([]string, 0, len(authenticators))
+	for k := range authenticators {
+		known = append(known, k)
+	}
+	authMu.RUnlock()
+
+	if !ok {
+		sort.Strings(known)
+		return nil, fmt.Errorf("unknown authentication mechanism %q, must be one of %s", m, strings.Join(known, ", "))
+	}
+	return f(creds)
+}
+
+// WithAuthenticator authenticates to the cluster using the supplied
+// authenticator instead of the username and password of the credentials.
+func WithAuthenticator(a gocql.Authenticator) Option {
+	return func(cfg *gocql.ClusterConfig) {
+		if a != nil {
+			cfg.Authenticator = a
+		}
+	}
+}
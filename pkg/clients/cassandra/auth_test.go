@@ -0,0 +1,84 @@
+package cassandra
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type tokenAuthenticator struct{ token string }
+
+func (a tokenAuthenticator) Challenge(_ []byte) ([]byte, gocql.Authenticator, error) {
+	return []byte(a.token), nil, nil
+}
+
+func (a tokenAuthenticator) Success(_ []byte) error { return nil }
+
+func TestAuthenticator(t *testing.T) {
+	RegisterAuthenticator("Token", func(creds map[string][]byte) (gocql.Authenticator, error) {
+		if len(creds["token"]) == 0 {
+			return nil, errors.New("no token")
+		}
+		return tokenAuthenticator{token: string(creds["token"])}, nil
+	})
+	defer func() {
+		authMu.Lock()
+		delete(authenticators, "Token")
+		authMu.Unlock()
+	}()
+
+	mechanism := func(m string) *string { return &m }
+	creds := map[string][]byte{"username": []byte("admin"), "password": []byte("s3cr3t"), "token": []byte("t0k3n")}
+
+	cases := map[string]struct {
+		reason    string
+		mechanism *string
+		creds     map[string][]byte
+		want      gocql.Authenticator
+		err       bool
+	}{
+		"Default": {
+			reason: "Password authentication should be used if no mechanism is selected.",
+			creds:  creds,
+			want:   gocql.PasswordAuthenticator{Username: "admin", Password: "s3cr3t"},
+		},
+		"Password": {
+			reason:    "The built-in password mechanism should be selectable by name.",
+			mechanism: mechanism(AuthPassword),
+			creds:     creds,
+			want:      gocql.PasswordAuthenticator{Username: "admin", Password: "s3cr3t"},
+		},
+		"Registered": {
+			reason:    "Registered mechanisms should be selectable by name.",
+			mechanism: mechanism("Token"),
+			creds:     creds,
+			want:      tokenAuthenticator{token: "t0k3n"},
+		},
+		"FactoryError": {
+			reason:    "Errors of the mechanism should be returned.",
+			mechanism: mechanism("Token"),
+			err:       true,
+		},
+		"Unknown": {
+			reason:    "Unknown mechanisms should be rejected.",
+			mechanism: mechanism("GSSAPI"),
+			creds:     creds,
+			err:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Authenticator(tc.mechanism, tc.creds)
+			if (err != nil) != tc.err {
+				t.Errorf("\n%s\nAuthenticator(...): want error %t, got %v\n", tc.reason, tc.err, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateComparable(tokenAuthenticator{})); diff != "" {
+				t.Errorf("\n%s\nAuthenticator(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
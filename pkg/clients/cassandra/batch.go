@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	// DefaultBatchStatements is the default maximum number of statements
+	// per batch.
+	DefaultBatchStatements = 100
+
+	// DefaultBatchBytes is the default maximum estimated size of a batch. It
+	// matches the default batch_size_warn_threshold of Cassandra; batches
+	// ten times larger are rejected by default.
+	DefaultBatchBytes = 5 * 1024
+)
+
+// A BatchType is the type of a batch.
+type BatchType string
+
+// Batch types.
+const (
+	// BatchLogged batches are applied atomically, at the cost of writing
+	// them to the batchlog first.
+	BatchLogged BatchType = "LOGGED"
+
+	// BatchUnlogged batches are not atomic. They only save round trips
+	// when all their statements write to the same partition.
+	BatchUnlogged BatchType = "UNLOGGED"
+)
+
+// A Statement of a batch.
+type Statement struct {
+	Query string
+	Args  []interface{}
+
+	// PartitionKey identifies the partition the statement writes to, e.g.
+	// the table and the serialized partition key columns. Statements of
+	// unlogged batches are grouped by it.
+	PartitionKey string
+}
+
+// size estimates the size of the statement on the wire.
+func (s Statement) size() int {
+	n := len(s.Query)
+	for _, a := range s.Args {
+		switch v := a.(type) {
+		case string:
+			n += len(v)
+		case []byte:
+			n += len(v)
+		default:
+			n += 8
+		}
+	}
+	return n
+}
+
+// SplitBatch splits the supplied statements into batches of at most
+// maxStatements statements and an estimated maxBytes bytes. Statements of
+// unlogged batches are grouped by partition so that every batch writes to a
+// single partition. The order of the statements of a partition is preserved.
+// A statement larger than maxBytes is put into a batch of its own. Limits of
+// zero or less select the defaults.
+func SplitBatch(t BatchType, stmts []Statement, maxStatements, maxBytes int) [][]Statement {
+	if maxStatements <= 0 {
+		maxStatements = DefaultBatchStatements
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBatchBytes
+	}
+
+	groups := [][]Statement{stmts}
+	if t == BatchUnlogged {
+		groups = nil
+		idx := map[string]int{}
+		for _, s := range stmts {
+			i, ok := idx[s.PartitionKey]
+			if !ok {
+				i = len(groups)
+				idx[s.PartitionKey] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], s)
+		}
+	}
+
+	var out [][]Statement
+	for _, g := range groups {
+		var cur []Statement
+		size := 0
+		for _, s := range g {
+			n := s.size()
+			if len(cur) > 0 && (len(cur) == maxStatements || size+n > maxBytes) {
+				out = append(out, cur)
+				cur, size = nil, 0
+			}
+			cur = append(cur, s)
+			size += n
+		}
+		if len(cur) > 0 {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
+
+// ExecBatch executes the supplied statements in batches of the supplied type,
+// split using SplitBatch with the default limits. Logged batches are only
+// atomic individually; statements that must be applied together must fit into
+// a single batch. Execution stops at the first batch that fails.
+func (c *CassandraDB) ExecBatch(ctx context.Context, t BatchType, stmts []Statement) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+	ctx, done, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	bt := gocql.LoggedBatch
+	if t == BatchUnlogged {
+		bt = gocql.UnloggedBatch
+	}
+
+	for _, batch := range SplitBatch(t, stmts, 0, 0) {
+		b, cancel := c.batch(ctx, bt)
+		queries := make([]string, len(batch))
+		for i, s := range batch {
+			b.Query(s.Query, s.Args...)
+			queries[i] = s.Query
+		}
+		start := time.Now()
+		err := c.session.ExecuteBatch(b)
+		c.observeDuration(OperationWrite, start)
+		cancel()
+		c.record(ctx, "BEGIN "+string(t)+" BATCH "+strings.Join(queries, "; ")+"; APPLY BATCH", err)
+		if err != nil {
+			return fmt.Errorf("failed to execute batch: %w", err)
+		}
+		c.writes.Add(uint64(len(batch)))
+	}
+	return nil
+}
+
+// batch returns a batch configured with the write profile, and a function that
+// releases the context it executes with.
+func (c *CassandraDB) batch(ctx context.Context, bt gocql.BatchType) (*gocql.Batch, context.CancelFunc) {
+	b := c.session.NewBatch(bt)
+	if cl, err := gocql.ParseConsistencyWrapper(c.write.Consistency); c.write.Consistency != "" && err == nil {
+		b.SetConsistency(cl)
+	}
+	if c.write.Retries != nil {
+		b.RetryPolicy(&gocql.SimpleRetryPolicy{NumRetries: *c.write.Retries})
+	}
+	cancel := context.CancelFunc(func() {})
+	if c.write.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.write.Timeout)
+	}
+	return b.WithContext(ctx), cancel
+}
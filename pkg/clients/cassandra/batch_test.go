@@ -0,0 +1,77 @@
+package cassandra
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitBatch(t *testing.T) {
+	stmt := func(pk, q string) Statement { return Statement{Query: q, PartitionKey: pk} }
+	queries := func(batches [][]Statement) [][]string {
+		out := make([][]string, len(batches))
+		for i, b := range batches {
+			for _, s := range b {
+				out[i] = append(out[i], s.Query)
+			}
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		reason        string
+		t             BatchType
+		stmts         []Statement
+		maxStatements int
+		maxBytes      int
+		want          [][]string
+	}{
+		"Empty": {
+			reason: "No statements should result in no batches.",
+			t:      BatchLogged,
+			want:   [][]string{},
+		},
+		"LoggedSpansPartitions": {
+			reason: "Logged batches should not be grouped by partition.",
+			t:      BatchLogged,
+			stmts:  []Statement{stmt("a", "1"), stmt("b", "2"), stmt("a", "3")},
+			want:   [][]string{{"1", "2", "3"}},
+		},
+		"UnloggedByPartition": {
+			reason: "Unlogged batches should only write to a single partition, preserving the order of its statements.",
+			t:      BatchUnlogged,
+			stmts:  []Statement{stmt("a", "1"), stmt("b", "2"), stmt("a", "3")},
+			want:   [][]string{{"1", "3"}, {"2"}},
+		},
+		"MaxStatements": {
+			reason:        "Batches should not exceed the maximum number of statements.",
+			t:             BatchUnlogged,
+			stmts:         []Statement{stmt("a", "1"), stmt("a", "2"), stmt("a", "3")},
+			maxStatements: 2,
+			want:          [][]string{{"1", "2"}, {"3"}},
+		},
+		"MaxBytes": {
+			reason:   "Batches should not exceed the maximum size, and oversized statements should get a batch of their own.",
+			t:        BatchLogged,
+			stmts:    []Statement{stmt("", "aaaa"), stmt("", "bbbb"), stmt("", strings.Repeat("c", 20)), stmt("", "d")},
+			maxBytes: 10,
+			want:     [][]string{{"aaaa", "bbbb"}, {strings.Repeat("c", 20)}, {"d"}},
+		},
+		"ArgumentSize": {
+			reason:   "The size of arguments should count towards the size of a batch.",
+			t:        BatchLogged,
+			stmts:    []Statement{{Query: "q", Args: []interface{}{"123456789"}}, {Query: "r"}},
+			maxBytes: 10,
+			want:     [][]string{{"q"}, {"r"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := queries(SplitBatch(tc.t, tc.stmts, tc.maxStatements, tc.maxBytes))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSplitBatch(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,39 +18,153 @@ package cassandra
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gocql/gocql"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
 )
 
 const (
 	defaultCassandraPort = 9042
+
+	// MaxIdentifierLength is the maximum length of keyspace and table names.
+	MaxIdentifierLength = 48
+
+	// schemaAgreementTimeout is how long CheckSchemaAgreement waits for
+	// the schema versions of the nodes to converge.
+	schemaAgreementTimeout = 5 * time.Second
 )
 
+// ErrSchemaDisagreement is returned by CheckSchemaAgreement when the nodes of
+// the cluster do not agree on the schema version.
+var ErrSchemaDisagreement = errors.New("nodes do not agree on the schema version")
+
+// selectRolePermissions is bound rather than formatted so that gocql prepares
+// it once per session. Sessions last a single reconcile, so the prepared
+// statement is only reused for the further resources observed by it, e.g. the
+// tables of a grant applied to existing tables individually.
+const selectRolePermissions = "SELECT permissions FROM system_auth.role_permissions WHERE role = ? AND resource = ?"
+
+var validIdentifier = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+var redactPassword = regexp.MustCompile(`(?i)(PASSWORD\s*=\s*)'(?:[^']|'')*'`)
+
+// An AuditFn is called with every statement executed by a CassandraDB and the
+// error, if any, it returned. Passwords are redacted from the statement.
+type AuditFn func(ctx context.Context, statement string, err error)
+
 type CassandraDB struct {
-	session  *gocql.Session
-	endpoint string
-	port     string
+	cluster     *gocql.ClusterConfig
+	connectOnce sync.Once
+	onConnect   func(error)
+	session     *gocql.Session
+	sessionErr  error
+	endpoint    string
+	port        string
+	audit       AuditFn
+	read        ExecutionProfile
+	write       ExecutionProfile
+	drainer     Drainer
+
+	observeLatency LatencyObserver
+	writes         atomic.Uint64
+}
+
+// An ExecutionProfile configures how statements are executed. Its zero value
+// uses the defaults of the session.
+type ExecutionProfile struct {
+	// Consistency level, e.g. LOCAL_QUORUM. Unknown levels are ignored.
+	Consistency string
+
+	// Timeout of each statement, on top of the deadline of its context.
+	Timeout time.Duration
+
+	// Retries of failed statements.
+	Retries *int
+}
+
+// An Option configures the cluster a CassandraDB connects to.
+type Option func(cfg *gocql.ClusterConfig)
+
+// WithSerialConsistency sets the serial consistency level (SERIAL or
+// LOCAL_SERIAL) used by lightweight transactions, i.e. the conditional
+// statements of ExecCAS, and by reads of the owners they recorded. Other
+// statements are not conditional, so it does not apply to them. Unset or
+// unknown levels leave the server default, SERIAL, in place.
+func WithSerialConsistency(level *string) Option {
+	return func(cfg *gocql.ClusterConfig) {
+		if level == nil {
+			return
+		}
+		var sc gocql.SerialConsistency
+		if err := sc.UnmarshalText([]byte(*level)); err == nil {
+			cfg.SerialConsistency = sc
+		}
+	}
+}
+
+// serialConsistency returns the serial consistency level of the lightweight
+// transactions of the session.
+func (c *CassandraDB) serialConsistency() gocql.SerialConsistency {
+	if c.cluster.SerialConsistency == 0 {
+		return gocql.Serial
+	}
+	return c.cluster.SerialConsistency
+}
+
+// WithTLS encrypts the connections to the cluster using the supplied TLS
+// configuration. A nil configuration leaves the connections unencrypted.
+func WithTLS(tc *tls.Config) Option {
+	return func(cfg *gocql.ClusterConfig) {
+		if tc == nil {
+			return
+		}
+		cfg.SslOpts = &gocql.SslOptions{
+			Config:                 tc,
+			EnableHostVerification: !tc.InsecureSkipVerify,
+		}
+	}
+}
+
+// WithMaxPreparedStatements sets how many prepared statements the session
+// caches. Unset or non-positive sizes leave the driver default in place.
+func WithMaxPreparedStatements(n *int) Option {
+	return func(cfg *gocql.ClusterConfig) {
+		if n != nil && *n > 0 {
+			cfg.MaxPreparedStmts = *n
+		}
+	}
 }
 
 // New initializes a new Cassandra client.
-func New(creds map[string][]byte, keyspace string) *CassandraDB {
+func New(creds map[string][]byte, keyspace string, opts ...Option) *CassandraDB {
 	endpoint := string(creds[xpv1.ResourceCredentialsSecretEndpointKey])
 	port := string(creds[xpv1.ResourceCredentialsSecretPortKey])
 
-	// Combine endpoint and port
-	host := endpoint
-	if port != "" {
-		host = fmt.Sprintf("%s:%s", endpoint, port)
+	// The endpoint may list several comma separated hosts that share the
+	// port, e.g. the pod IPs backing a Service.
+	hosts := strings.Split(endpoint, ",")
+	for i, h := range hosts {
+		hosts[i] = strings.TrimSpace(h)
+		if port != "" {
+			hosts[i] = net.JoinHostPort(hosts[i], port)
+		}
 	}
 
-	cluster := gocql.NewCluster(host)
+	cluster := gocql.NewCluster(hosts...)
 
 	cluster.Authenticator = gocql.PasswordAuthenticator{
 		Username: string(creds[xpv1.ResourceCredentialsSecretUserKey]),
@@ -62,40 +176,401 @@ func New(creds map[string][]byte, keyspace string) *CassandraDB {
 	}
 
 	cluster.Consistency = gocql.All
-	session, _ := cluster.CreateSession()
+	for _, o := range opts {
+		o(cluster)
+	}
 
 	return &CassandraDB{
-		session:  session,
+		cluster:  cluster,
 		endpoint: endpoint,
 		port:     port,
 	}
 }
 
+// Connect connects the session to the cluster, dialing its nodes, unless it
+// connected before. It returns the error the session failed to connect with,
+// e.g. because no node of the cluster could be reached. Sessions that are not
+// connected explicitly connect with their first statement.
+func (c *CassandraDB) Connect() error {
+	c.connectOnce.Do(func() {
+		if c.cluster == nil {
+			c.sessionErr = errors.New("cassandra session is not initialized")
+			return
+		}
+		c.session, c.sessionErr = c.cluster.CreateSession()
+		if c.onConnect != nil {
+			c.onConnect(c.sessionErr)
+		}
+	})
+	return c.sessionErr
+}
+
+// SetConnectObserver sets the function called with the result of connecting
+// the session, e.g. to detect unavailable clusters when sessions connect
+// with their first statement.
+func (c *CassandraDB) SetConnectObserver(fn func(error)) {
+	c.onConnect = fn
+}
+
+// Warmup connects the session and executes a lightweight query, so that
+// problems that only show with the first statement, e.g. overloaded nodes,
+// surface while connecting rather than while resources are observed.
+func (c *CassandraDB) Warmup(ctx context.Context) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+	iter, err := c.Query(ctx, "SELECT release_version FROM system.local")
+	if err != nil {
+		return err
+	}
+	if err := iter.Close(); err != nil {
+		return fmt.Errorf("failed to warm up session: %w", err)
+	}
+	return nil
+}
+
+// SetAuditFn sets the function called with every executed statement.
+func (c *CassandraDB) SetAuditFn(fn AuditFn) {
+	c.audit = fn
+}
+
+// SetExecutionProfiles sets the profiles of the statements that read and write
+// data or schema. Exec, ExecCAS and ExecBatch write, all other methods read.
+func (c *CassandraDB) SetExecutionProfiles(read, write ExecutionProfile) {
+	c.read, c.write = read, write
+}
+
+// query returns the supplied statement configured with the supplied profile,
+// and a function that releases the context it executes with.
+func (c *CassandraDB) query(ctx context.Context, p ExecutionProfile, query string, args ...interface{}) (*gocql.Query, context.CancelFunc) {
+	q := c.session.Query(query, args...)
+	if cl, err := gocql.ParseConsistencyWrapper(p.Consistency); p.Consistency != "" && err == nil {
+		q.Consistency(cl)
+	}
+	if p.Retries != nil {
+		q.RetryPolicy(&gocql.SimpleRetryPolicy{NumRetries: *p.Retries})
+	}
+	cancel := context.CancelFunc(func() {})
+	if p.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+	}
+	return q.WithContext(ctx), cancel
+}
+
 // Exec executes a CQL statement and returns an error if the session is not available or the execution fails.
 func (c *CassandraDB) Exec(ctx context.Context, query string, args ...interface{}) error {
-	if c.session == nil {
-		return errors.New("Cassandra session is not initialized")
+	if err := c.Connect(); err != nil {
+		return err
 	}
+	ctx, done, err := c.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	err := c.session.Query(query, args...).WithContext(ctx).Exec()
+	q, cancel := c.query(ctx, c.write, query, args...)
+	defer cancel()
+	start := time.Now()
+	err = q.Exec()
+	c.observeDuration(OperationWrite, start)
+	c.record(ctx, query, err)
 	if err != nil {
-		return errors.New("failed to execute query: " + err.Error())
+		return fmt.Errorf("failed to execute query: %w", err)
 	}
+	c.writes.Add(1)
 
 	return nil
 }
 
+// ExecCAS executes a lightweight transaction, i.e. a conditional INSERT,
+// UPDATE or DELETE, and reports whether it was applied. Conditional schema
+// and role statements such as CREATE ROLE IF NOT EXISTS are not lightweight
+// transactions and do not report whether they were applied; they are
+// reported as applied if they succeed.
+func (c *CassandraDB) ExecCAS(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	if err := c.Connect(); err != nil {
+		return false, err
+	}
+	ctx, done, err := c.begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	q, cancel := c.query(ctx, c.write, query, args...)
+	defer cancel()
+	start := time.Now()
+	iter := q.Iter()
+	applied := true
+	if cols := iter.Columns(); len(cols) > 0 && cols[0].Name == "[applied]" {
+		row := map[string]interface{}{}
+		if iter.MapScan(row) {
+			applied, _ = row["[applied]"].(bool)
+		}
+	}
+	err = iter.Close()
+	c.observeDuration(OperationWrite, start)
+	c.record(ctx, query, err)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute query: %w", err)
+	}
+	if applied {
+		c.writes.Add(1)
+	}
+
+	return applied, nil
+}
+
+// Writes returns how many statements executed by Exec, ExecCAS and ExecBatch
+// succeeded, not counting conditional statements that were not applied.
+func (c *CassandraDB) Writes() uint64 {
+	return c.writes.Load()
+}
+
+func (c *CassandraDB) record(ctx context.Context, query string, err error) {
+	if c.audit != nil {
+		c.audit(ctx, RedactPasswords(query), err)
+	}
+}
+
+// IsUnauthorized reports whether err was returned because the connecting user
+// lacks the permission to perform the statement.
+func IsUnauthorized(err error) bool {
+	re, ok := AsRequestError(err)
+	return ok && re.Code() == gocql.ErrCodeUnauthorized
+}
+
+// AsRequestError returns the error the cluster responded with that caused
+// err, if any. Its code classifies the error, e.g. as gocql.ErrCodeInvalid for
+// statements the cluster rejected.
+func AsRequestError(err error) (gocql.RequestError, bool) {
+	var re gocql.RequestError
+	if !errors.As(err, &re) {
+		return nil, false
+	}
+	return re, true
+}
+
+// IsTimeout reports whether err was returned because a statement timed out,
+// in which case it may still complete on the cluster.
+func IsTimeout(err error) bool {
+	var wt *gocql.RequestErrWriteTimeout
+	var rt *gocql.RequestErrReadTimeout
+	return errors.Is(err, gocql.ErrTimeoutNoResponse) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &wt) || errors.As(err, &rt)
+}
+
+// RedactPasswords replaces the password literals in a CQL statement.
+func RedactPasswords(query string) string {
+	return redactPassword.ReplaceAllString(query, "${1}'*****'")
+}
+
+// An Iter iterates over the results of a query. Closing it releases the
+// context of the query, which bounds the fetching of further pages too.
+type Iter struct {
+	*gocql.Iter
+	cancel context.CancelFunc
+}
+
+// Close closes the iterator and returns any error that happened during the
+// query or while fetching its pages.
+func (i *Iter) Close() error {
+	defer i.cancel()
+	return i.Iter.Close()
+}
+
 // Query performs a query and returns an iterator for the results or an error if the session is not available.
-func (c *CassandraDB) Query(ctx context.Context, query string, args ...interface{}) (*gocql.Iter, error) {
-	if c.session == nil {
-		return nil, errors.New("cassandra session is not initialized")
+// Callers must close the iterator.
+func (c *CassandraDB) Query(ctx context.Context, query string, args ...interface{}) (*Iter, error) {
+	if err := c.Connect(); err != nil {
+		return nil, err
 	}
 
-	iter := c.session.Query(query, args...).WithContext(ctx).Iter()
+	q, cancel := c.query(ctx, c.read, query, args...)
+	start := time.Now()
+	iter := q.Iter()
+	c.observeDuration(OperationRead, start)
 	if iter == nil {
+		cancel()
 		return nil, errors.New("failed to execute query or no iterator returned")
 	}
 
+	return &Iter{Iter: iter, cancel: cancel}, nil
+}
+
+// CheckSchemaAgreement returns ErrSchemaDisagreement if the nodes of the
+// cluster do not agree on the schema version within a few seconds, e.g. because
+// earlier schema changes are still propagating. Schema changes issued while
+// the nodes disagree pile up and may cause the cluster to pull schema storms,
+// so callers should back off rather than issue DDL.
+func (c *CassandraDB) CheckSchemaAgreement(ctx context.Context) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+
+	actx, cancel := context.WithTimeout(ctx, schemaAgreementTimeout)
+	defer cancel()
+	err := c.session.AwaitSchemaAgreement(actx)
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return ctx.Err()
+	}
+	return fmt.Errorf("%w: %w", ErrSchemaDisagreement, err)
+}
+
+// KeyspaceExists reports whether the named keyspace exists. It scans for the
+// keyspace row rather than relying on the row count of the iterator, which is
+// unreliable when paging is enabled.
+func (c *CassandraDB) KeyspaceExists(ctx context.Context, name string) (bool, error) {
+	iter, err := c.Query(ctx, "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?", name)
+	if err != nil {
+		return false, err
+	}
+
+	var keyspaceName string
+	exists := iter.Scan(&keyspaceName)
+	if err := iter.Close(); err != nil {
+		return false, fmt.Errorf("failed to check keyspace existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// RoleExists reports whether the named role exists.
+func (c *CassandraDB) RoleExists(ctx context.Context, name string) (bool, error) {
+	iter, err := c.Query(ctx, "SELECT role FROM system_auth.roles WHERE role = ?", name)
+	if err != nil {
+		return false, err
+	}
+
+	var role string
+	exists := iter.Scan(&role)
+	return exists, iter.Close()
+}
+
+// RolePermissions returns the permissions the named role holds on the supplied
+// resource, as returned by Resource.String.
+func (c *CassandraDB) RolePermissions(ctx context.Context, role, resource string) (map[string]bool, error) {
+	iter, err := c.Query(ctx, selectRolePermissions, role, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string]bool)
+	var p []string
+	for iter.Scan(&p) {
+		for _, permission := range p {
+			permissions[permission] = true
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to select role permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// DescribeRole returns the named role as listed by LIST ROLES, which requires
+// the DESCRIBE permission on ALL ROLES rather than SELECT on system_auth. It
+// reports false if the role does not exist.
+func (c *CassandraDB) DescribeRole(ctx context.Context, name string) (RoleInfo, bool, error) {
+	iter, err := c.Query(ctx, "LIST ROLES OF "+QuoteIdentifier(name)+" NORECURSIVE")
+	if err != nil {
+		return RoleInfo{}, false, err
+	}
+
+	r := RoleInfo{Name: name}
+	found := false
+	row := map[string]interface{}{}
+	for iter.MapScan(row) {
+		if row["role"] == name {
+			r.SuperUser, _ = row["super"].(bool)
+			r.Login, _ = row["login"].(bool)
+			found = true
+		}
+		row = map[string]interface{}{}
+	}
+	err = iter.Close()
+	if re, ok := AsRequestError(err); ok && re.Code() == gocql.ErrCodeInvalid {
+		// Listing the roles of a role that does not exist is invalid.
+		return RoleInfo{}, false, nil
+	}
+	if err != nil {
+		return RoleInfo{}, false, fmt.Errorf("failed to list role: %w", err)
+	}
+	return r, found, nil
+}
+
+// RoleInfo is a role as listed by ListRoles.
+type RoleInfo struct {
+	Name      string
+	SuperUser bool
+	Login     bool
+}
+
+// A Permission lists the permissions a role holds on a resource.
+type Permission struct {
+	Role        string
+	Resource    string
+	Permissions []string
+}
+
+// ListRoles returns a page of at most pageSize roles, starting at the supplied
+// page state, and the page state of the next page. The next page state is
+// empty when there are no more roles. Pass a nil page state to start listing.
+func (c *CassandraDB) ListRoles(ctx context.Context, pageSize int, pageState []byte) ([]RoleInfo, []byte, error) {
+	iter, err := c.queryPage(ctx, pageSize, pageState, "SELECT role, is_superuser, can_login FROM system_auth.roles")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var roles []RoleInfo
+	r := RoleInfo{}
+	for iter.Scan(&r.Name, &r.SuperUser, &r.Login) {
+		roles = append(roles, r)
+		r = RoleInfo{}
+	}
+	next := iter.PageState()
+	if err := iter.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, next, nil
+}
+
+// ListPermissions returns a page of at most pageSize permissions of all roles,
+// like ListRoles.
+func (c *CassandraDB) ListPermissions(ctx context.Context, pageSize int, pageState []byte) ([]Permission, []byte, error) {
+	iter, err := c.queryPage(ctx, pageSize, pageState, "SELECT role, resource, permissions FROM system_auth.role_permissions")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var permissions []Permission
+	p := Permission{}
+	for iter.Scan(&p.Role, &p.Resource, &p.Permissions) {
+		permissions = append(permissions, p)
+		p = Permission{}
+	}
+	next := iter.PageState()
+	if err := iter.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	return permissions, next, nil
+}
+
+// queryPage performs a query that returns a single page of results. Setting
+// the page state, even to nil, stops the driver from fetching further pages.
+func (c *CassandraDB) queryPage(ctx context.Context, pageSize int, pageState []byte, query string, args ...interface{}) (*gocql.Iter, error) {
+	if err := c.Connect(); err != nil {
+		return nil, err
+	}
+	q, cancel := c.query(ctx, c.read, query, args...)
+	defer cancel()
+	start := time.Now()
+	iter := q.PageSize(pageSize).PageState(pageState).Iter()
+	c.observeDuration(OperationRead, start)
 	return iter, nil
 }
 
@@ -126,7 +601,68 @@ func parsePort(port string) int {
 }
 
 // QuoteIdentifier safely quotes an identifier to prevent SQL injection.
-// Cassandra uses double quotes to delimit identifiers.
+// Cassandra uses double quotes to delimit identifiers. Quoted identifiers are
+// case sensitive and may be reserved words, so the quoted form always refers
+// to exactly the name stored in the system tables.
 func QuoteIdentifier(id string) string {
-	return `"` + strings.ReplaceAll(id, `"`, `""`) + `"`
+	return builder.QuoteIdentifier(id)
+}
+
+// IfNotExists returns the IF NOT EXISTS clause of a CREATE statement, unless
+// adoption of existing objects was disabled by setting adopt to false.
+func IfNotExists(adopt *bool) string {
+	if adopt != nil && !*adopt {
+		return ""
+	}
+	return "IF NOT EXISTS "
+}
+
+// ParseReplicationFactor parses the replication factor option of a keyspace
+// into its numbers of replicas and transient replicas. Transient replication
+// encodes the option as "<replicas>/<transient replicas>", e.g. "3/1", and
+// requires at least one of the replicas to be full.
+func ParseReplicationFactor(rf string) (int, int, error) {
+	replicas, transient, isTransient := strings.Cut(rf, "/")
+	r, err := strconv.Atoi(replicas)
+	if err != nil || r < 1 {
+		return 0, 0, fmt.Errorf("invalid replication factor %q", rf)
+	}
+	if !isTransient {
+		return r, 0, nil
+	}
+	t, err := strconv.Atoi(transient)
+	if err != nil || t < 0 || t >= r {
+		return 0, 0, fmt.Errorf("invalid replication factor %q", rf)
+	}
+	return r, t, nil
+}
+
+// FormatReplicationFactor returns the replication factor option of a keyspace
+// with the supplied numbers of replicas and transient replicas.
+func FormatReplicationFactor(replicas, transient int) string {
+	if transient == 0 {
+		return strconv.Itoa(replicas)
+	}
+	return strconv.Itoa(replicas) + "/" + strconv.Itoa(transient)
+}
+
+// QuoteLiteral quotes a string literal, escaping embedded single quotes.
+func QuoteLiteral(s string) string {
+	return builder.QuoteLiteral(s)
+}
+
+// ValidateIdentifier returns an error if the supplied keyspace or table name
+// would be rejected by Cassandra, which only allows up to 48 alphanumeric
+// ASCII characters and underscores even when the name is quoted.
+func ValidateIdentifier(id string) error {
+	if id == "" {
+		return errors.New("identifier must not be empty")
+	}
+	if len(id) > MaxIdentifierLength {
+		return fmt.Errorf("identifier %q is longer than %d characters", id, MaxIdentifierLength)
+	}
+	if !validIdentifier.MatchString(id) {
+		return fmt.Errorf("identifier %q may only contain alphanumeric characters and underscores", id)
+	}
+	return nil
 }
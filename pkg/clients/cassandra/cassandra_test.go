@@ -0,0 +1,205 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsTimeout(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"NoResponse":       {err: fmt.Errorf("failed to execute query: %w", gocql.ErrTimeoutNoResponse), want: true},
+		"DeadlineExceeded": {err: fmt.Errorf("failed to execute query: %w", context.DeadlineExceeded), want: true},
+		"WriteTimeout":     {err: fmt.Errorf("failed to execute query: %w", &gocql.RequestErrWriteTimeout{}), want: true},
+		"OtherError":       {err: errors.New("boom")},
+		"NoError":          {},
+	}
+	for name, tc := range cases {
+		if got := IsTimeout(tc.err); got != tc.want {
+			t.Errorf("%s: IsTimeout(%v): want %t, got %t", name, tc.err, tc.want, got)
+		}
+	}
+}
+
+func TestAsRequestError(t *testing.T) {
+	timeout := &gocql.RequestErrWriteTimeout{}
+	cases := map[string]struct {
+		err  error
+		want gocql.RequestError
+	}{
+		"Wrapped":      {err: fmt.Errorf("cannot create keyspace: %w", fmt.Errorf("failed to execute query: %w", timeout)), want: timeout},
+		"SchemaChange": {err: fmt.Errorf("%w: %w", ErrSchemaDisagreement, timeout), want: timeout},
+		"OtherError":   {err: errors.New("boom")},
+		"NoError":      {},
+	}
+	for name, tc := range cases {
+		got, ok := AsRequestError(tc.err)
+		if got != tc.want || ok != (tc.want != nil) {
+			t.Errorf("%s: AsRequestError(%v): want %v, got %v, %t", name, tc.err, tc.want, got, ok)
+		}
+	}
+}
+
+func TestRedactPasswords(t *testing.T) {
+	cases := map[string]string{
+		`CREATE ROLE IF NOT EXISTS "app" WITH SUPERUSER = false AND LOGIN = true AND PASSWORD = 's3cr''et'`: `CREATE ROLE IF NOT EXISTS "app" WITH SUPERUSER = false AND LOGIN = true AND PASSWORD = '*****'`,
+		`ALTER ROLE "app" WITH password='abc'`: `ALTER ROLE "app" WITH password='*****'`,
+		`DROP ROLE IF EXISTS "app"`:            `DROP ROLE IF EXISTS "app"`,
+	}
+	for in, want := range cases {
+		if got := RedactPasswords(in); got != want {
+			t.Errorf("RedactPasswords(%q): want %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"orders":     `"orders"`,
+		"MixedCase":  `"MixedCase"`,
+		"select":     `"select"`,
+		"keyspace":   `"keyspace"`,
+		`we"ird`:     `"we""ird"`,
+		"zażółć":     `"zażółć"`,
+		`"; DROP --`: `"""; DROP --"`,
+	}
+	for in, want := range cases {
+		if got := QuoteIdentifier(in); got != want {
+			t.Errorf("QuoteIdentifier(%q): want %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestIfNotExists(t *testing.T) {
+	f, tr := false, true
+	cases := map[string]struct {
+		adopt *bool
+		want  string
+	}{
+		"Default": {want: "IF NOT EXISTS "},
+		"Adopt":   {adopt: &tr, want: "IF NOT EXISTS "},
+		"NoAdopt": {adopt: &f, want: ""},
+	}
+	for name, tc := range cases {
+		if got := IfNotExists(tc.adopt); got != tc.want {
+			t.Errorf("%s: IfNotExists(...): want %q, got %q", name, tc.want, got)
+		}
+	}
+}
+
+func TestIterClose(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := &Iter{Iter: &gocql.Iter{}, cancel: cancel}
+	if err := iter.Close(); err != nil {
+		t.Errorf("Close(): want no error, got %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Close(): want the context of the query to be released")
+	}
+}
+
+func TestParseReplicationFactor(t *testing.T) {
+	cases := map[string]struct {
+		rf        string
+		replicas  int
+		transient int
+		err       bool
+	}{
+		"Full":          {rf: "3", replicas: 3},
+		"Transient":     {rf: "3/1", replicas: 3, transient: 1},
+		"NoFull":        {rf: "3/3", err: true},
+		"TooTransient":  {rf: "2/3", err: true},
+		"NoReplicas":    {rf: "0", err: true},
+		"Negative":      {rf: "3/-1", err: true},
+		"Invalid":       {rf: "three", err: true},
+		"InvalidSuffix": {rf: "3/x", err: true},
+	}
+	for name, tc := range cases {
+		r, tr, err := ParseReplicationFactor(tc.rf)
+		if (err != nil) != tc.err || r != tc.replicas || tr != tc.transient {
+			t.Errorf("%s: ParseReplicationFactor(%q): want %d, %d, error %t, got %d, %d, %v", name, tc.rf, tc.replicas, tc.transient, tc.err, r, tr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if got := FormatReplicationFactor(r, tr); got != tc.rf {
+			t.Errorf("%s: FormatReplicationFactor(%d, %d): want %q, got %q", name, r, tr, tc.rf, got)
+		}
+	}
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	cases := map[string]bool{
+		"orders":                true,
+		"MixedCase_01":          true,
+		"select":                true,
+		"keyspace":              true,
+		strings.Repeat("a", 48): true,
+		strings.Repeat("a", 49): false,
+		"":                      false,
+		"zażółć":                false,
+		"with-dash":             false,
+		"with space":            false,
+		`we"ird`:                false,
+		"ks.table":              false,
+		strings.Repeat("ż", 24): false,
+	}
+	for in, valid := range cases {
+		err := ValidateIdentifier(in)
+		if valid && err != nil {
+			t.Errorf("ValidateIdentifier(%q): unexpected error: %v", in, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("ValidateIdentifier(%q): expected an error", in)
+		}
+	}
+}
+
+type requestError int
+
+func (e requestError) Code() int       { return int(e) }
+func (e requestError) Message() string { return "denied" }
+func (e requestError) Error() string   { return "denied" }
+
+func TestIsUnauthorized(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Unauthorized":        {err: requestError(gocql.ErrCodeUnauthorized), want: true},
+		"WrappedUnauthorized": {err: fmt.Errorf("failed to execute query: %w", requestError(gocql.ErrCodeUnauthorized)), want: true},
+		"OtherRequestError":   {err: requestError(gocql.ErrCodeSyntax)},
+		"OtherError":          {err: errors.New("boom")},
+		"NoError":             {},
+	}
+	for name, tc := range cases {
+		if got := IsUnauthorized(tc.err); got != tc.want {
+			t.Errorf("%s: IsUnauthorized(%v): want %t, got %t", name, tc.err, tc.want, got)
+		}
+	}
+}
+
+func TestSerialConsistency(t *testing.T) {
+	cases := map[string]struct {
+		level *string
+		want  gocql.SerialConsistency
+	}{
+		"Default":     {want: gocql.Serial},
+		"LocalSerial": {level: ptr.To("LOCAL_SERIAL"), want: gocql.LocalSerial},
+		"Unknown":     {level: ptr.To("QUORUM"), want: gocql.Serial},
+	}
+	for name, tc := range cases {
+		db := New(map[string][]byte{}, "", WithSerialConsistency(tc.level))
+		if got := db.serialConsistency(); got != tc.want {
+			t.Errorf("%s: serialConsistency(): want %s, got %s", name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+// Formats of connection details that are published in addition to the
+// standard username, password, endpoint and port keys.
+const (
+	// FormatContactPoints publishes the contact points and local
+	// datacenter expected by most drivers.
+	FormatContactPoints = "ContactPoints"
+	// FormatCQLSHRC publishes a cqlshrc file.
+	FormatCQLSHRC = "CQLSHRC"
+	// FormatJavaDriver publishes a DataStax Java driver application.conf.
+	FormatJavaDriver = "JavaDriver"
+)
+
+// Keys of the connection details published by the supported formats.
+const (
+	ContactPointsKey   = "contact-points"
+	LocalDatacenterKey = "local-datacenter"
+	CQLSHRCKey         = "cqlshrc"
+	JavaDriverKey      = "application.conf"
+)
+
+// LocalDatacenter returns the datacenter of the node the session is connected
+// to, which drivers use as their local datacenter.
+func (c *CassandraDB) LocalDatacenter(ctx context.Context) (string, error) {
+	_, dc, err := c.Identity(ctx)
+	return dc, err
+}
+
+// Identity returns the name of the cluster and the datacenter of the node the
+// session is connected to.
+func (c *CassandraDB) Identity(ctx context.Context) (string, string, error) {
+	iter, err := c.Query(ctx, "SELECT cluster_name, data_center FROM system.local")
+	if err != nil {
+		return "", "", err
+	}
+
+	var cluster, dc string
+	iter.Scan(&cluster, &dc)
+	return cluster, dc, iter.Close()
+}
+
+// Datacenters returns the sorted names of the datacenters of the nodes of the
+// cluster, as known to the node the session is connected to.
+func (c *CassandraDB) Datacenters(ctx context.Context) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, query := range []string{"SELECT data_center FROM system.local", "SELECT data_center FROM system.peers"} {
+		iter, err := c.Query(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		var dc string
+		for iter.Scan(&dc) {
+			seen[dc] = true
+		}
+		if err := iter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to select datacenters: %w", err)
+		}
+	}
+
+	dcs := make([]string, 0, len(seen))
+	for dc := range seen {
+		dcs = append(dcs, dc)
+	}
+	sort.Strings(dcs)
+	return dcs, nil
+}
+
+// A NodeSchema is the schema version a node of the cluster reports.
+type NodeSchema struct {
+	Datacenter string
+	Version    string
+}
+
+// SchemaVersions returns the schema version of every node of the cluster, as
+// known to the node the session is connected to. The connected node is
+// returned first.
+func (c *CassandraDB) SchemaVersions(ctx context.Context) ([]NodeSchema, error) {
+	var nodes []NodeSchema
+	for _, query := range []string{"SELECT data_center, schema_version FROM system.local", "SELECT data_center, schema_version FROM system.peers"} {
+		iter, err := c.Query(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		var dc string
+		var version gocql.UUID
+		for iter.Scan(&dc, &version) {
+			nodes = append(nodes, NodeSchema{Datacenter: dc, Version: version.String()})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, fmt.Errorf("failed to select schema versions: %w", err)
+		}
+	}
+	return nodes, nil
+}
+
+// DatacentersWithout returns the sorted names of the datacenters none of
+// whose nodes report the supplied schema version.
+func DatacentersWithout(nodes []NodeSchema, version string) []string {
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		seen[n.Datacenter] = seen[n.Datacenter] || n.Version == version
+	}
+
+	var dcs []string
+	for dc, ok := range seen {
+		if !ok {
+			dcs = append(dcs, dc)
+		}
+	}
+	sort.Strings(dcs)
+	return dcs
+}
+
+// FormatConnectionDetails returns the supplied standard connection details
+// augmented with the keys of the supplied formats. The local datacenter is
+// only included if it is known.
+func FormatConnectionDetails(cd managed.ConnectionDetails, dc string, formats ...string) managed.ConnectionDetails {
+	username := string(cd[xpv1.ResourceCredentialsSecretUserKey])
+	password := string(cd[xpv1.ResourceCredentialsSecretPasswordKey])
+	port := string(cd[xpv1.ResourceCredentialsSecretPortKey])
+
+	hosts := strings.Split(string(cd[xpv1.ResourceCredentialsSecretEndpointKey]), ",")
+	points := make([]string, len(hosts))
+	for i, h := range hosts {
+		points[i] = h
+		if port != "" {
+			points[i] = net.JoinHostPort(h, port)
+		}
+	}
+
+	out := make(managed.ConnectionDetails, len(cd)+len(formats))
+	for k, v := range cd {
+		out[k] = v
+	}
+
+	for _, f := range formats {
+		switch f {
+		case FormatContactPoints:
+			out[ContactPointsKey] = []byte(strings.Join(points, ","))
+			if dc != "" {
+				out[LocalDatacenterKey] = []byte(dc)
+			}
+		case FormatCQLSHRC:
+			b := &strings.Builder{}
+			b.WriteString("[authentication]\n")
+			b.WriteString("username = " + username + "\n")
+			b.WriteString("password = " + password + "\n")
+			b.WriteString("\n[connection]\n")
+			b.WriteString("hostname = " + hosts[0] + "\n")
+			if port != "" {
+				b.WriteString("port = " + port + "\n")
+			}
+			out[CQLSHRCKey] = []byte(b.String())
+		case FormatJavaDriver:
+			quoted := make([]string, len(points))
+			for i, p := range points {
+				quoted[i] = strconv.Quote(p)
+			}
+			b := &strings.Builder{}
+			b.WriteString("datastax-java-driver {\n")
+			b.WriteString("  basic.contact-points = [" + strings.Join(quoted, ", ") + "]\n")
+			if dc != "" {
+				b.WriteString("  basic.load-balancing-policy.local-datacenter = " + strconv.Quote(dc) + "\n")
+			}
+			b.WriteString("  advanced.auth-provider {\n")
+			b.WriteString("    class = PlainTextAuthProvider\n")
+			b.WriteString("    username = " + strconv.Quote(username) + "\n")
+			b.WriteString("    password = " + strconv.Quote(password) + "\n")
+			b.WriteString("  }\n")
+			b.WriteString("}\n")
+			out[JavaDriverKey] = []byte(b.String())
+		}
+	}
+	return out
+}
+
+// WithKeyNames returns the supplied connection details with the values of
+// standard keys also published under the key names they map to. Standard keys
+// without a value are skipped.
+func WithKeyNames(cd managed.ConnectionDetails, names map[string]string) managed.ConnectionDetails {
+	out := make(managed.ConnectionDetails, len(cd)+len(names))
+	for k, v := range cd {
+		out[k] = v
+	}
+	for std, name := range names {
+		if v, ok := cd[std]; ok {
+			out[name] = v
+		}
+	}
+	return out
+}
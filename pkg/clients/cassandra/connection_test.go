@@ -0,0 +1,131 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+func TestFormatConnectionDetails(t *testing.T) {
+	details := func(endpoint, port string) managed.ConnectionDetails {
+		return managed.ConnectionDetails{
+			xpv1.ResourceCredentialsSecretUserKey:     []byte("app"),
+			xpv1.ResourceCredentialsSecretPasswordKey: []byte(`pa"ss`),
+			xpv1.ResourceCredentialsSecretEndpointKey: []byte(endpoint),
+			xpv1.ResourceCredentialsSecretPortKey:     []byte(port),
+		}
+	}
+	with := func(cd managed.ConnectionDetails, kv ...string) managed.ConnectionDetails {
+		for i := 0; i+1 < len(kv); i += 2 {
+			cd[kv[i]] = []byte(kv[i+1])
+		}
+		return cd
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cd      managed.ConnectionDetails
+		dc      string
+		formats []string
+		want    managed.ConnectionDetails
+	}{
+		"NoFormats": {
+			reason: "The standard connection details should be returned unchanged.",
+			cd:     details("cassandra", "9042"),
+			want:   details("cassandra", "9042"),
+		},
+		"ContactPoints": {
+			reason:  "Every host should be published as a contact point with the port.",
+			cd:      details("10.0.0.1,10.0.0.2", "9042"),
+			dc:      "dc1",
+			formats: []string{FormatContactPoints},
+			want: with(details("10.0.0.1,10.0.0.2", "9042"),
+				ContactPointsKey, "10.0.0.1:9042,10.0.0.2:9042",
+				LocalDatacenterKey, "dc1"),
+		},
+		"UnknownDatacenter": {
+			reason:  "The local datacenter should be omitted if it is unknown.",
+			cd:      details("cassandra", ""),
+			formats: []string{FormatContactPoints},
+			want:    with(details("cassandra", ""), ContactPointsKey, "cassandra"),
+		},
+		"CQLSHRC": {
+			reason:  "A cqlshrc connecting to the first host should be published.",
+			cd:      details("10.0.0.1,10.0.0.2", "9042"),
+			formats: []string{FormatCQLSHRC},
+			want: with(details("10.0.0.1,10.0.0.2", "9042"), CQLSHRCKey,
+				"[authentication]\nusername = app\npassword = pa\"ss\n\n[connection]\nhostname = 10.0.0.1\nport = 9042\n"),
+		},
+		"JavaDriver": {
+			reason:  "An application.conf with quoted strings should be published.",
+			cd:      details("cassandra", "9042"),
+			dc:      "dc1",
+			formats: []string{FormatJavaDriver},
+			want: with(details("cassandra", "9042"), JavaDriverKey, `datastax-java-driver {
+  basic.contact-points = ["cassandra:9042"]
+  basic.load-balancing-policy.local-datacenter = "dc1"
+  advanced.auth-provider {
+    class = PlainTextAuthProvider
+    username = "app"
+    password = "pa\"ss"
+  }
+}
+`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FormatConnectionDetails(tc.cd, tc.dc, tc.formats...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFormatConnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithKeyNames(t *testing.T) {
+	cd := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte("app"),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+	}
+	got := WithKeyNames(cd, map[string]string{
+		xpv1.ResourceCredentialsSecretPasswordKey: "CASSANDRA_PASSWORD",
+		xpv1.ResourceCredentialsSecretPortKey:     "CASSANDRA_PORT",
+	})
+	want := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte("app"),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+		"CASSANDRA_PASSWORD":                      []byte("secret"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WithKeyNames(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestDatacentersWithout(t *testing.T) {
+	cases := map[string]struct {
+		nodes []NodeSchema
+		want  []string
+	}{
+		"Agreed": {
+			nodes: []NodeSchema{{Datacenter: "dc1", Version: "v2"}, {Datacenter: "dc2", Version: "v2"}},
+		},
+		"OneNodePerDatacenter": {
+			nodes: []NodeSchema{{Datacenter: "dc1", Version: "v2"}, {Datacenter: "dc2", Version: "v1"}, {Datacenter: "dc2", Version: "v2"}},
+		},
+		"Propagating": {
+			nodes: []NodeSchema{{Datacenter: "dc1", Version: "v2"}, {Datacenter: "dc3", Version: "v1"}, {Datacenter: "dc2", Version: "v1"}},
+			want:  []string{"dc2", "dc3"},
+		},
+	}
+
+	for name, tc := range cases {
+		if diff := cmp.Diff(tc.want, DatacentersWithout(tc.nodes, "v2")); diff != "" {
+			t.Errorf("%s: -want, +got:\n%s", name, diff)
+		}
+	}
+}
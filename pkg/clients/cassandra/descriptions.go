@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+)
+
+// Kinds of the objects descriptions and owners are kept for.
+const (
+	KindKeyspace = "keyspace"
+	KindRole     = "role"
+)
+
+// A DescriptionTable is a table in which the descriptions of the keyspaces and
+// roles managed by the provider are kept, since Cassandra cannot comment on
+// them. Descriptions are keyed by the kind and name of the object they
+// describe, e.g. by role and app.
+type DescriptionTable struct {
+	// Keyspace the table is in. It must exist.
+	Keyspace string
+
+	// Table is created when the first description is set.
+	Table string
+}
+
+func (t DescriptionTable) name() string {
+	return builder.QuoteIdentifier(t.Keyspace) + "." + builder.QuoteIdentifier(t.Table)
+}
+
+func (t DescriptionTable) create() string {
+	return builder.CreateTable(t.Keyspace, t.Table).
+		IfNotExists(true).
+		PartitionKey("kind", "text").
+		ClusteringKey("name", "text", "").
+		Column("description", "text").
+		Column("resource", "text").
+		Column("updated_at", "timestamp").
+		String()
+}
+
+func (t DescriptionTable) selectDescription() string {
+	return "SELECT description FROM " + t.name() + " WHERE kind = ? AND name = ?"
+}
+
+func (t DescriptionTable) insert() string {
+	return "INSERT INTO " + t.name() + " (kind, name, description, resource, updated_at) VALUES (?, ?, ?, ?, toTimestamp(now()))"
+}
+
+func (t DescriptionTable) delete() string {
+	return "DELETE FROM " + t.name() + " WHERE kind = ? AND name = ?"
+}
+
+// Description returns the description of the object of the supplied kind and
+// name, and whether it has one. Objects have none before the table is created.
+func (c *CassandraDB) Description(ctx context.Context, t DescriptionTable, kind, name string) (string, bool, error) {
+	iter, err := c.Query(ctx, t.selectDescription(), kind, name)
+	if err != nil {
+		return "", false, err
+	}
+
+	var description string
+	exists := iter.Scan(&description)
+	if err := iter.Close(); err != nil {
+		if unconfiguredTable(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to select description: %w", err)
+	}
+	return description, exists, nil
+}
+
+// SetDescription creates the description table if necessary and stores the
+// description of the object of the supplied kind and name, together with the
+// name of the managed resource that manages it.
+func (c *CassandraDB) SetDescription(ctx context.Context, t DescriptionTable, kind, name, description, resource string) error {
+	err := c.Exec(ctx, t.insert(), kind, name, description, resource)
+	if !unconfiguredTable(err) {
+		return err
+	}
+	if err := c.Exec(ctx, t.create()); err != nil {
+		return err
+	}
+	return c.Exec(ctx, t.insert(), kind, name, description, resource)
+}
+
+// DeleteDescription deletes the description of the object of the supplied kind
+// and name, if it has one.
+func (c *CassandraDB) DeleteDescription(ctx context.Context, t DescriptionTable, kind, name string) error {
+	err := c.Exec(ctx, t.delete(), kind, name)
+	if unconfiguredTable(err) {
+		return nil
+	}
+	return err
+}
+
+// unconfiguredTable reports whether err was returned because a table does not
+// exist. Statements on tables the cluster does not know are rejected as
+// invalid.
+func unconfiguredTable(err error) bool {
+	re, ok := AsRequestError(err)
+	return ok && re.Code() == gocql.ErrCodeInvalid
+}
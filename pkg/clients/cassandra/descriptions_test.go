@@ -0,0 +1,56 @@
+package cassandra
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestDescriptionTableStatements(t *testing.T) {
+	tbl := DescriptionTable{Keyspace: "ops", Table: "descriptions"}
+	cases := map[string]struct {
+		got  string
+		want string
+	}{
+		"Create": {
+			got:  tbl.create(),
+			want: `CREATE TABLE IF NOT EXISTS "ops"."descriptions" ("kind" text, "name" text, "description" text, "resource" text, "updated_at" timestamp, PRIMARY KEY (("kind"), "name"))`,
+		},
+		"Select": {
+			got:  tbl.selectDescription(),
+			want: `SELECT description FROM "ops"."descriptions" WHERE kind = ? AND name = ?`,
+		},
+		"Insert": {
+			got:  tbl.insert(),
+			want: `INSERT INTO "ops"."descriptions" (kind, name, description, resource, updated_at) VALUES (?, ?, ?, ?, toTimestamp(now()))`,
+		},
+		"Delete": {
+			got:  tbl.delete(),
+			want: `DELETE FROM "ops"."descriptions" WHERE kind = ? AND name = ?`,
+		},
+	}
+	for name, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: want %q, got %q", name, tc.want, tc.got)
+		}
+	}
+}
+
+func TestUnconfiguredTable(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Invalid":      {err: fmt.Errorf("failed to execute query: %w", requestError(gocql.ErrCodeInvalid)), want: true},
+		"Unauthorized": {err: fmt.Errorf("failed to execute query: %w", requestError(gocql.ErrCodeUnauthorized))},
+		"OtherError":   {err: errors.New("boom")},
+		"NoError":      {},
+	}
+	for name, tc := range cases {
+		if got := unconfiguredTable(tc.err); got != tc.want {
+			t.Errorf("%s: unconfiguredTable(%v): want %t, got %t", name, tc.err, tc.want, got)
+		}
+	}
+}
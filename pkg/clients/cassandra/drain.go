@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import "context"
+
+// A Drainer admits the statements that write data or schema, so that those in
+// flight may complete before the provider shuts down.
+type Drainer interface {
+	// Begin admits a statement of the supplied session. It returns the
+	// context the statement executes with and a function to call once it
+	// completed, or an error if statements are no longer admitted.
+	Begin(ctx context.Context, db *CassandraDB) (context.Context, func(), error)
+}
+
+// SetDrainer sets the drainer that admits the statements of Exec, ExecCAS and
+// ExecBatch.
+func (c *CassandraDB) SetDrainer(d Drainer) {
+	c.drainer = d
+}
+
+// begin admits a statement that writes data or schema.
+func (c *CassandraDB) begin(ctx context.Context) (context.Context, func(), error) {
+	if c.drainer == nil {
+		return ctx, func() {}, nil
+	}
+	return c.drainer.Begin(ctx, c)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import "time"
+
+// Operations whose duration is observed.
+const (
+	// OperationRead statements read data or schema.
+	OperationRead = "read"
+	// OperationWrite statements write data or schema, including batches.
+	OperationWrite = "write"
+)
+
+// A LatencyObserver is called with the duration of every statement the
+// session executes. The duration of reads covers their first page.
+type LatencyObserver func(operation string, d time.Duration)
+
+// SetLatencyObserver sets the function called with the duration of every
+// executed statement.
+func (c *CassandraDB) SetLatencyObserver(fn LatencyObserver) {
+	c.observeLatency = fn
+}
+
+// observeDuration reports the time since the supplied start of a statement
+// of the supplied operation.
+func (c *CassandraDB) observeDuration(operation string, start time.Time) {
+	if c.observeLatency != nil {
+		c.observeLatency(operation, time.Since(start))
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+)
+
+// Thread pools whose queues indicate that a node is under stress.
+const (
+	poolCompaction = "CompactionExecutor"
+	poolMutation   = "MutationStage"
+)
+
+// Load is how busy the node the session is connected to is.
+type Load struct {
+	// PendingCompactions is how many compactions are waiting to run.
+	PendingCompactions int
+
+	// PendingMutations is how many writes are queued rather than applied.
+	PendingMutations int
+}
+
+// Load returns the load of the node the session is connected to, as reported
+// by the system_views.thread_pools virtual table of Cassandra 4.0 and later.
+func (c *CassandraDB) Load(ctx context.Context) (Load, error) {
+	iter, err := c.Query(ctx, "SELECT name, pending_tasks FROM system_views.thread_pools")
+	if err != nil {
+		return Load{}, err
+	}
+
+	pending := map[string]int{}
+	var name string
+	var tasks int
+	for iter.Scan(&name, &tasks) {
+		pending[name] = tasks
+	}
+	if err := iter.Close(); err != nil {
+		return Load{}, fmt.Errorf("failed to select thread pools: %w", err)
+	}
+	return loadOf(pending), nil
+}
+
+// loadOf returns the load of a node whose thread pools have the supplied
+// pending tasks, by name.
+func loadOf(pending map[string]int) Load {
+	return Load{
+		PendingCompactions: pending[poolCompaction],
+		PendingMutations:   pending[poolMutation],
+	}
+}
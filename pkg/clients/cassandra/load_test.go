@@ -0,0 +1,29 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadOf(t *testing.T) {
+	cases := map[string]struct {
+		pending map[string]int
+		want    Load
+	}{
+		"Idle": {
+			pending: nil,
+			want:    Load{},
+		},
+		"Busy": {
+			pending: map[string]int{"CompactionExecutor": 42, "MutationStage": 7, "ReadStage": 3},
+			want:    Load{PendingCompactions: 42, PendingMutations: 7},
+		},
+	}
+
+	for name, tc := range cases {
+		if diff := cmp.Diff(tc.want, loadOf(tc.pending)); diff != "" {
+			t.Errorf("%s: -want, +got:\n%s", name, diff)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// driverErrorMsg is logged with the lines of the driver that report errors.
+const driverErrorMsg = "Cassandra driver error"
+
+// The driver logs unstructured lines without a level, so those that report
+// errors are recognized by their wording.
+var driverError = regexp.MustCompile(`(?i)\b(error|unable|failed|cannot|refused|timed? ?out)\b`)
+
+// A Logger writes the lines logged by the driver, e.g. about connection errors
+// and retries, to a structured logger. Lines that report errors are logged at
+// info level with an error key, and all other lines at debug level.
+type Logger struct {
+	log logging.Logger
+}
+
+// NewLogger returns a Logger that writes to l.
+func NewLogger(l logging.Logger) *Logger {
+	return &Logger{log: l}
+}
+
+// Print logs a line of the driver.
+func (l *Logger) Print(v ...interface{}) {
+	l.emit(fmt.Sprint(v...))
+}
+
+// Printf logs a line of the driver.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	l.emit(fmt.Sprintf(format, v...))
+}
+
+// Println logs a line of the driver.
+func (l *Logger) Println(v ...interface{}) {
+	l.emit(fmt.Sprintln(v...))
+}
+
+func (l *Logger) emit(line string) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "gocql: "))
+	if driverError.MatchString(line) {
+		l.log.Info(driverErrorMsg, "error", line)
+		return
+	}
+	l.log.Debug(line)
+}
+
+// WithLogger writes the lines logged by the driver to l rather than to the
+// standard library logger. A nil logger leaves the driver default in place.
+func WithLogger(l logging.Logger) Option {
+	return func(cfg *gocql.ClusterConfig) {
+		if l == nil {
+			return
+		}
+		cfg.Logger = NewLogger(l)
+	}
+}
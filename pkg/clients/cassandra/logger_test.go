@@ -0,0 +1,47 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+type line struct {
+	level string
+	msg   string
+	kv    []any
+}
+
+type recordingLogger struct {
+	lines *[]line
+}
+
+func (l recordingLogger) Info(msg string, kv ...any) {
+	*l.lines = append(*l.lines, line{level: "info", msg: msg, kv: kv})
+}
+
+func (l recordingLogger) Debug(msg string, kv ...any) {
+	*l.lines = append(*l.lines, line{level: "debug", msg: msg, kv: kv})
+}
+
+func (l recordingLogger) WithValues(_ ...any) logging.Logger { return l }
+
+func TestLogger(t *testing.T) {
+	var got []line
+	l := NewLogger(recordingLogger{lines: &got})
+
+	l.Printf("gocql: unable to dial control conn %v:%v: %v", "10.0.0.1", 9042, "connection refused")
+	l.Println("gocql: Session.handleNodeUp:", "10.0.0.2")
+	l.Print("gocql: ", "control connection timed out")
+
+	want := []line{
+		{level: "info", msg: driverErrorMsg, kv: []any{"error", "unable to dial control conn 10.0.0.1:9042: connection refused"}},
+		{level: "debug", msg: "Session.handleNodeUp: 10.0.0.2"},
+		{level: "info", msg: driverErrorMsg, kv: []any{"error", "control connection timed out"}},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(line{})); diff != "" {
+		t.Errorf("Logger: -want, +got:\n%s\n", diff)
+	}
+}
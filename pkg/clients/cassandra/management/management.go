@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package management reads Cassandra metadata from the management API sidecar
+// of the nodes, e.g. the one k8ssandra deploys. The API keeps answering while
+// the CQL interface of a node restarts, which makes observations more
+// reliable during rolling restarts.
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// DefaultTimeout of requests to the management API.
+const DefaultTimeout = 10 * time.Second
+
+// A Client of the management API. It implements cassandra.MetadataReader.
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+// New returns a Client of the management API served at the supplied base URL,
+// e.g. http://cluster-dc1-service.cassandra.svc:8080. A nil HTTP client uses
+// one with the DefaultTimeout.
+func New(baseURL string, hc *http.Client) *Client {
+	if hc == nil {
+		hc = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Client{url: strings.TrimSuffix(baseURL, "/"), http: hc}
+}
+
+// Keyspace returns the replication of the named keyspace, and whether it
+// exists. The management API does not report whether writes are durable.
+func (c *Client) Keyspace(ctx context.Context, name string) (cassandra.KeyspaceMetadata, bool, error) {
+	var names []string
+	if err := c.get(ctx, "/api/v0/ops/keyspace", url.Values{"keyspaceName": {name}}, &names); err != nil {
+		return cassandra.KeyspaceMetadata{}, false, err
+	}
+	exists := false
+	for _, n := range names {
+		exists = exists || n == name
+	}
+	if !exists {
+		return cassandra.KeyspaceMetadata{}, false, nil
+	}
+
+	md := cassandra.KeyspaceMetadata{Replication: map[string]string{}}
+	if err := c.get(ctx, "/api/v0/ops/keyspace/replication", url.Values{"keyspace": {name}}, &md.Replication); err != nil {
+		return cassandra.KeyspaceMetadata{}, false, err
+	}
+	return md, true, nil
+}
+
+// get decodes the JSON response to a GET request of the supplied path into v.
+func (c *Client) get(ctx context.Context, path string, q url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path+"?"+q.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("cannot build management API request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("management API request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("management API responded to %s with %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("cannot decode management API response to %s: %w", path, err)
+	}
+	return nil
+}
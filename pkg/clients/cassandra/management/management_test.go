@@ -0,0 +1,85 @@
+package management
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestKeyspace(t *testing.T) {
+	type want struct {
+		md     cassandra.KeyspaceMetadata
+		exists bool
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.HandlerFunc
+		want    want
+	}{
+		"Exists": {
+			reason: "The replication of an existing keyspace should be returned",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v0/ops/keyspace":
+					if r.URL.Query().Get("keyspaceName") != "app" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					_, _ = w.Write([]byte(`["app"]`))
+				case "/api/v0/ops/keyspace/replication":
+					_, _ = w.Write([]byte(`{"class":"org.apache.cassandra.locator.SimpleStrategy","replication_factor":"3"}`))
+				}
+			},
+			want: want{
+				md:     cassandra.KeyspaceMetadata{Replication: map[string]string{"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "3"}},
+				exists: true,
+			},
+		},
+		"NotExists": {
+			reason: "A keyspace that is not listed should not exist",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`[]`))
+			},
+		},
+		"ErrStatus": {
+			reason: "Responses other than 200 OK should be returned as errors",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+		"ErrDecode": {
+			reason: "Responses that aren't JSON should be returned as errors",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`<html>`))
+			},
+			want: want{err: cmpopts.AnyError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+
+			md, exists, err := New(srv.URL+"/", nil).Keyspace(context.Background(), "app")
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nKeyspace(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, exists); diff != "" {
+				t.Errorf("\n%s\nKeyspace(...): -want exists, +got exists:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.md, md); diff != "" {
+				t.Errorf("\n%s\nKeyspace(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
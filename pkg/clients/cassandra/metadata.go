@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+)
+
+// KeyspaceMetadata is the metadata of a keyspace.
+type KeyspaceMetadata struct {
+	// Replication options of the keyspace, including its class.
+	Replication map[string]string
+
+	// DurableWrites is nil if the reader does not report it.
+	DurableWrites *bool
+}
+
+// A MetadataReader reads the metadata of keyspaces, e.g. using CQL or the
+// management API of the nodes.
+type MetadataReader interface {
+	// Keyspace returns the metadata of the named keyspace, and whether it
+	// exists.
+	Keyspace(ctx context.Context, name string) (KeyspaceMetadata, bool, error)
+}
+
+// Keyspace returns the metadata of the named keyspace from
+// system_schema.keyspaces, and whether it exists.
+func (c *CassandraDB) Keyspace(ctx context.Context, name string) (KeyspaceMetadata, bool, error) {
+	iter, err := c.Query(ctx, "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?", name)
+	if err != nil {
+		return KeyspaceMetadata{}, false, err
+	}
+
+	md := KeyspaceMetadata{Replication: map[string]string{}, DurableWrites: new(bool)}
+	exists := iter.Scan(&md.Replication, md.DurableWrites)
+	if err := iter.Close(); err != nil {
+		return KeyspaceMetadata{}, false, fmt.Errorf("failed to select keyspace: %w", err)
+	}
+	return md, exists, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+)
+
+// An OwnerTable is a table in which the UIDs of the managed resources that
+// manage the keyspaces and roles of a cluster are recorded, so that a resource
+// of another team cannot adopt them by using the same name. Owners are keyed
+// by the kind and name of the object they manage, e.g. by role and app.
+type OwnerTable struct {
+	// Keyspace the table is in. It must exist.
+	Keyspace string
+
+	// Table is created when the first owner is recorded.
+	Table string
+}
+
+func (t OwnerTable) name() string {
+	return builder.QuoteIdentifier(t.Keyspace) + "." + builder.QuoteIdentifier(t.Table)
+}
+
+func (t OwnerTable) create() string {
+	return builder.CreateTable(t.Keyspace, t.Table).
+		IfNotExists(true).
+		PartitionKey("kind", "text").
+		ClusteringKey("name", "text", "").
+		Column("uid", "text").
+		Column("resource", "text").
+		Column("claimed_at", "timestamp").
+		String()
+}
+
+func (t OwnerTable) selectOwner() string {
+	return "SELECT uid, resource FROM " + t.name() + " WHERE kind = ? AND name = ?"
+}
+
+func (t OwnerTable) insert() string {
+	return "INSERT INTO " + t.name() + " (kind, name, uid, resource, claimed_at) VALUES (?, ?, ?, ?, toTimestamp(now())) IF NOT EXISTS"
+}
+
+func (t OwnerTable) delete() string {
+	return "DELETE FROM " + t.name() + " WHERE kind = ? AND name = ? IF uid = ?"
+}
+
+// An Owner of an object.
+type Owner struct {
+	// UID of the managed resource.
+	UID string
+
+	// Resource is the name of the managed resource.
+	Resource string
+}
+
+// Owner returns the recorded owner of the object of the supplied kind and
+// name, and whether it has one. Objects have none before the table is created.
+func (c *CassandraDB) Owner(ctx context.Context, t OwnerTable, kind, name string) (Owner, bool, error) {
+	return c.owner(ctx, t, kind, name, false)
+}
+
+// owner is like Owner, but reads the owner at the serial consistency level of
+// the session if serial is true, so that it observes every owner whose
+// lightweight transaction was accepted, even if it has not been committed at
+// the consistency level of reads yet.
+func (c *CassandraDB) owner(ctx context.Context, t OwnerTable, kind, name string, serial bool) (Owner, bool, error) {
+	if err := c.Connect(); err != nil {
+		return Owner{}, false, err
+	}
+	q, cancel := c.query(ctx, c.read, t.selectOwner(), kind, name)
+	defer cancel()
+	if serial {
+		q.Consistency(gocql.Consistency(c.serialConsistency()))
+	}
+
+	start := time.Now()
+	iter := q.Iter()
+	o := Owner{}
+	exists := iter.Scan(&o.UID, &o.Resource)
+	err := iter.Close()
+	c.observeDuration(OperationRead, start)
+	if err != nil {
+		if unconfiguredTable(err) {
+			return Owner{}, false, nil
+		}
+		return Owner{}, false, fmt.Errorf("failed to select owner: %w", err)
+	}
+	return o, exists, nil
+}
+
+// ClaimOwner records the supplied owner of the object of the supplied kind and
+// name, unless it has one already, and returns its owner. The owner is only
+// recorded if the object has none, which is checked and written atomically,
+// so concurrent claims cannot both succeed.
+func (c *CassandraDB) ClaimOwner(ctx context.Context, t OwnerTable, kind, name string, o Owner) (Owner, error) {
+	cur, exists, err := c.Owner(ctx, t, kind, name)
+	if err != nil || exists {
+		return cur, err
+	}
+
+	applied, err := c.ExecCAS(ctx, t.insert(), kind, name, o.UID, o.Resource)
+	if unconfiguredTable(err) {
+		if err := c.Exec(ctx, t.create()); err != nil {
+			return Owner{}, err
+		}
+		applied, err = c.ExecCAS(ctx, t.insert(), kind, name, o.UID, o.Resource)
+	}
+	if err != nil || applied {
+		return o, err
+	}
+
+	// Another owner was recorded concurrently. Its claim is read serially,
+	// since it may not be visible at the consistency level of reads yet.
+	cur, _, err = c.owner(ctx, t, kind, name, true)
+	return cur, err
+}
+
+// ReleaseOwner deletes the recorded owner of the object of the supplied kind
+// and name if it is the supplied one.
+func (c *CassandraDB) ReleaseOwner(ctx context.Context, t OwnerTable, kind, name, uid string) error {
+	_, err := c.ExecCAS(ctx, t.delete(), kind, name, uid)
+	if unconfiguredTable(err) {
+		return nil
+	}
+	return err
+}
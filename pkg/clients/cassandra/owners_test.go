@@ -0,0 +1,35 @@
+package cassandra
+
+import (
+	"testing"
+)
+
+func TestOwnerTableStatements(t *testing.T) {
+	tbl := OwnerTable{Keyspace: "ops", Table: "owners"}
+	cases := map[string]struct {
+		got  string
+		want string
+	}{
+		"Create": {
+			got:  tbl.create(),
+			want: `CREATE TABLE IF NOT EXISTS "ops"."owners" ("kind" text, "name" text, "uid" text, "resource" text, "claimed_at" timestamp, PRIMARY KEY (("kind"), "name"))`,
+		},
+		"Select": {
+			got:  tbl.selectOwner(),
+			want: `SELECT uid, resource FROM "ops"."owners" WHERE kind = ? AND name = ?`,
+		},
+		"Insert": {
+			got:  tbl.insert(),
+			want: `INSERT INTO "ops"."owners" (kind, name, uid, resource, claimed_at) VALUES (?, ?, ?, ?, toTimestamp(now())) IF NOT EXISTS`,
+		},
+		"Delete": {
+			got:  tbl.delete(),
+			want: `DELETE FROM "ops"."owners" WHERE kind = ? AND name = ? IF uid = ?`,
+		},
+	}
+	for name, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s: want %q, got %q", name, tc.want, tc.got)
+		}
+	}
+}
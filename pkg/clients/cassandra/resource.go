@@ -0,0 +1,474 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of resources permissions can be granted on.
+const (
+	ResourceData  = "data"
+	ResourceRoles = "roles"
+
+	// ResourceFunctions are user defined functions.
+	ResourceFunctions = "functions"
+
+	// ResourceMBeans are the JMX MBeans of Cassandra.
+	ResourceMBeans = "mbean"
+
+	// ResourceServiceLevels are the service levels of Scylla.
+	ResourceServiceLevels = "service_levels"
+)
+
+// A Flavor is an implementation of CQL. Flavors record some resources
+// differently in the resource column of role_permissions.
+type Flavor string
+
+// Supported flavors.
+const (
+	// FlavorCassandra records the argument types of functions as the class
+	// names of their marshal types, e.g. org.apache.cassandra.db.marshal.Int32Type.
+	FlavorCassandra Flavor = "Cassandra"
+
+	// FlavorScylla records the argument types of functions as CQL types, e.g.
+	// int, and has service levels but no MBeans.
+	FlavorScylla Flavor = "Scylla"
+)
+
+const marshalPackage = "org.apache.cassandra.db.marshal."
+
+// marshalTypes are the CQL types of the marshal types of Cassandra.
+var marshalTypes = map[string]string{
+	"AsciiType":         "ascii",
+	"BooleanType":       "boolean",
+	"ByteType":          "tinyint",
+	"BytesType":         "blob",
+	"CounterColumnType": "counter",
+	"DecimalType":       "decimal",
+	"DoubleType":        "double",
+	"DurationType":      "duration",
+	"FloatType":         "float",
+	"InetAddressType":   "inet",
+	"Int32Type":         "int",
+	"IntegerType":       "varint",
+	"LongType":          "bigint",
+	"ShortType":         "smallint",
+	"SimpleDateType":    "date",
+	"TimeType":          "time",
+	"TimeUUIDType":      "timeuuid",
+	"TimestampType":     "timestamp",
+	"UTF8Type":          "text",
+	"UUIDType":          "uuid",
+}
+
+// marshalCollections are the CQL collections of the parameterized marshal
+// types of Cassandra.
+var marshalCollections = map[string]string{
+	"FrozenType": "frozen",
+	"ListType":   "list",
+	"MapType":    "map",
+	"SetType":    "set",
+	"TupleType":  "tuple",
+}
+
+// Permissions as written in GRANT statements and recorded in
+// system_auth.role_permissions, except for PermissionAll which is recorded as
+// the individual permissions it applies to the resource.
+const (
+	PermissionAll       = "ALL PERMISSIONS"
+	PermissionAlter     = "ALTER"
+	PermissionAuthorize = "AUTHORIZE"
+	PermissionCreate    = "CREATE"
+	PermissionDescribe  = "DESCRIBE"
+	PermissionDrop      = "DROP"
+	PermissionExecute   = "EXECUTE"
+	PermissionModify    = "MODIFY"
+	PermissionSelect    = "SELECT"
+)
+
+// A Resource is an object permissions are granted on. Its String form is the
+// one recorded in the resource column of system_auth.role_permissions, e.g.
+// data/shop/orders or roles/app.
+type Resource struct {
+	// Kind of the resource, either data or roles.
+	Kind string
+
+	// Keyspace of a data resource. All keyspaces if empty.
+	Keyspace string
+
+	// Table of a data resource. The whole keyspace if empty.
+	Table string
+
+	// Role of a roles resource. All roles if empty.
+	Role string
+
+	// Function of a functions resource, whose keyspace is Keyspace. All
+	// functions of the keyspace if empty.
+	Function string
+
+	// Arguments are the CQL types of the arguments of Function, separated by
+	// commas, e.g. "int, text".
+	Arguments string
+
+	// MBean of an mbean resource, or a pattern that matches MBeans. All
+	// MBeans if empty.
+	MBean string
+
+	// Flavor records the resource. Cassandra if empty.
+	Flavor Flavor
+}
+
+// KeyspaceResource returns the name system_auth.role_permissions records for
+// permissions granted on the supplied keyspace.
+func KeyspaceResource(keyspace string) string {
+	return Resource{Kind: ResourceData, Keyspace: keyspace}.String()
+}
+
+// TableResource returns the name system_auth.role_permissions records for
+// permissions granted on the supplied table.
+func TableResource(keyspace, table string) string {
+	return Resource{Kind: ResourceData, Keyspace: keyspace, Table: table}.String()
+}
+
+// RoleResource returns the name system_auth.role_permissions records for
+// permissions granted on the supplied role, or on all roles if it is empty.
+func RoleResource(role string) string {
+	return Resource{Kind: ResourceRoles, Role: role}.String()
+}
+
+// ParseResource parses a data or roles resource as recorded in
+// system_auth.role_permissions, the resources a Grant may grant permissions
+// on. Role names may contain slashes, so everything after roles/ is the role.
+func ParseResource(s string) (Resource, error) {
+	kind, _, _ := strings.Cut(s, "/")
+	if kind != ResourceData && kind != ResourceRoles {
+		return Resource{}, fmt.Errorf("resource %q is neither a data nor a roles resource", s)
+	}
+	return ParseResourceFor(FlavorCassandra, s)
+}
+
+// ParseResourceFor parses any resource as recorded in role_permissions by the
+// supplied flavor, e.g. to report the permissions of roles that were granted
+// outside of Grants.
+func ParseResourceFor(flavor Flavor, s string) (Resource, error) {
+	kind, name, _ := strings.Cut(s, "/")
+	switch {
+	case kind == ResourceFunctions:
+		return parseFunction(flavor, s, name)
+	case kind == ResourceMBeans && flavor != FlavorScylla:
+		if s != ResourceMBeans && name == "" {
+			return Resource{}, fmt.Errorf("resource %q names an empty MBean", s)
+		}
+		return Resource{Kind: ResourceMBeans, MBean: name}, nil
+	case kind == ResourceServiceLevels && flavor == FlavorScylla:
+		if s != ResourceServiceLevels {
+			return Resource{}, fmt.Errorf("resource %q is not all service levels", s)
+		}
+		return Resource{Kind: ResourceServiceLevels, Flavor: FlavorScylla}, nil
+	}
+	switch kind {
+	case ResourceRoles:
+		if s != ResourceRoles && name == "" {
+			return Resource{}, fmt.Errorf("resource %q names an empty role", s)
+		}
+		return Resource{Kind: ResourceRoles, Role: name}, nil
+	case ResourceData:
+		if s == ResourceData {
+			return Resource{Kind: ResourceData}, nil
+		}
+		keyspace, table, _ := strings.Cut(name, "/")
+		if keyspace == "" || strings.HasSuffix(name, "/") || strings.Contains(table, "/") {
+			return Resource{}, fmt.Errorf("resource %q is not a valid keyspace or table", s)
+		}
+		return Resource{Kind: ResourceData, Keyspace: keyspace, Table: table}, nil
+	}
+	return Resource{}, fmt.Errorf("resource %q is not a %s resource", s, flavor)
+}
+
+// parseFunction parses a functions resource, e.g. functions/shop/total[int]
+// as recorded by Scylla or functions/shop/total[org.apache.cassandra.db.marshal.Int32Type]
+// as recorded by Cassandra.
+func parseFunction(flavor Flavor, s, name string) (Resource, error) {
+	r := Resource{Kind: ResourceFunctions}
+	if flavor == FlavorScylla {
+		r.Flavor = FlavorScylla
+	}
+	if s == ResourceFunctions {
+		return r, nil
+	}
+	keyspace, signature, _ := strings.Cut(name, "/")
+	if keyspace == "" || strings.HasSuffix(name, "/") {
+		return Resource{}, fmt.Errorf("resource %q is not a valid keyspace or function", s)
+	}
+	r.Keyspace = keyspace
+	if signature == "" {
+		return r, nil
+	}
+
+	fn, args, ok := strings.Cut(signature, "[")
+	if !ok || fn == "" || !strings.HasSuffix(args, "]") {
+		return Resource{}, fmt.Errorf("resource %q is not a valid function signature", s)
+	}
+	r.Function = fn
+	args = strings.TrimSuffix(args, "]")
+	if args == "" {
+		return r, nil
+	}
+	types := strings.Split(args, "^")
+	for i := range types {
+		if flavor != FlavorScylla {
+			types[i] = cqlType(types[i])
+		}
+	}
+	r.Arguments = strings.Join(types, ", ")
+	return r, nil
+}
+
+// cqlType returns the CQL type of the supplied marshal type, e.g. int for
+// org.apache.cassandra.db.marshal.Int32Type or list<int> for
+// org.apache.cassandra.db.marshal.ListType(org.apache.cassandra.db.marshal.Int32Type).
+// Types it does not know, e.g. user defined types, are returned as is.
+func cqlType(marshal string) string {
+	name := strings.TrimPrefix(marshal, marshalPackage)
+	if t, ok := marshalTypes[name]; ok {
+		return t
+	}
+	collection, params, ok := strings.Cut(name, "(")
+	c, known := marshalCollections[collection]
+	if !ok || !known || !strings.HasSuffix(params, ")") {
+		return marshal
+	}
+	parts := splitTopLevel(strings.TrimSuffix(params, ")"))
+	for i := range parts {
+		parts[i] = cqlType(parts[i])
+	}
+	return c + "<" + strings.Join(parts, ", ") + ">"
+}
+
+// marshalType returns the marshal type of the supplied CQL type. It is the
+// inverse of cqlType.
+func marshalType(cql string) string {
+	for m, t := range marshalTypes {
+		if t == cql {
+			return marshalPackage + m
+		}
+	}
+	collection, params, ok := strings.Cut(cql, "<")
+	if !ok || !strings.HasSuffix(params, ">") {
+		return cql
+	}
+	for m, c := range marshalCollections {
+		if c != collection {
+			continue
+		}
+		parts := splitTopLevel(strings.TrimSuffix(params, ">"))
+		for i := range parts {
+			parts[i] = marshalType(strings.TrimSpace(parts[i]))
+		}
+		return marshalPackage + m + "(" + strings.Join(parts, ",") + ")"
+	}
+	return cql
+}
+
+// splitTopLevel splits the supplied type parameters at the commas that are
+// not nested in other parameters.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(', '<':
+			depth++
+		case ')', '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// signature returns the function and its argument types as recorded by the
+// flavor of the resource.
+func (r Resource) signature() string {
+	if r.Arguments == "" {
+		return r.Function + "[]"
+	}
+	types := splitTopLevel(r.Arguments)
+	for i := range types {
+		if r.Flavor != FlavorScylla {
+			types[i] = marshalType(types[i])
+		}
+	}
+	return r.Function + "[" + strings.Join(types, "^") + "]"
+}
+
+// String returns the resource as recorded in system_auth.role_permissions.
+func (r Resource) String() string {
+	switch {
+	case r.Kind == ResourceFunctions && r.Function != "":
+		return ResourceFunctions + "/" + r.Keyspace + "/" + r.signature()
+	case r.Kind == ResourceFunctions && r.Keyspace != "":
+		return ResourceFunctions + "/" + r.Keyspace
+	case r.Kind == ResourceFunctions:
+		return ResourceFunctions
+	case r.Kind == ResourceMBeans && r.MBean != "":
+		return ResourceMBeans + "/" + r.MBean
+	case r.Kind == ResourceMBeans:
+		return ResourceMBeans
+	case r.Kind == ResourceServiceLevels:
+		return ResourceServiceLevels
+	case r.Kind == ResourceRoles && r.Role != "":
+		return ResourceRoles + "/" + r.Role
+	case r.Kind == ResourceRoles:
+		return ResourceRoles
+	case r.Table != "":
+		return ResourceData + "/" + r.Keyspace + "/" + r.Table
+	case r.Keyspace != "":
+		return ResourceData + "/" + r.Keyspace
+	}
+	return ResourceData
+}
+
+// CQL returns the resource as referred to by GRANT and REVOKE statements.
+func (r Resource) CQL() string {
+	switch {
+	case r.Kind == ResourceFunctions && r.Function != "":
+		return "FUNCTION " + QuoteIdentifier(r.Keyspace) + "." + QuoteIdentifier(r.Function) + "(" + r.Arguments + ")"
+	case r.Kind == ResourceFunctions && r.Keyspace != "":
+		return "ALL FUNCTIONS IN KEYSPACE " + QuoteIdentifier(r.Keyspace)
+	case r.Kind == ResourceFunctions:
+		return "ALL FUNCTIONS"
+	case r.Kind == ResourceMBeans && r.MBean != "":
+		return "MBEAN '" + strings.ReplaceAll(r.MBean, "'", "''") + "'"
+	case r.Kind == ResourceMBeans:
+		return "ALL MBEANS"
+	case r.Kind == ResourceServiceLevels:
+		return "ALL SERVICE_LEVELS"
+	case r.Kind == ResourceRoles && r.Role != "":
+		return "ROLE " + QuoteIdentifier(r.Role)
+	case r.Kind == ResourceRoles:
+		return "ALL ROLES"
+	case r.Table != "":
+		return "TABLE " + QuoteIdentifier(r.Keyspace) + "." + QuoteIdentifier(r.Table)
+	case r.Keyspace != "":
+		return "KEYSPACE " + QuoteIdentifier(r.Keyspace)
+	}
+	return "ALL KEYSPACES"
+}
+
+// Parents returns the resources that enclose the resource, nearest first.
+// Permissions granted on a parent are inherited by the resource, e.g. a role
+// granted SELECT on ALL KEYSPACES may select from every table.
+func (r Resource) Parents() []Resource {
+	switch {
+	case r.Kind == ResourceFunctions && r.Function != "":
+		return []Resource{{Kind: ResourceFunctions, Keyspace: r.Keyspace, Flavor: r.Flavor}, {Kind: ResourceFunctions, Flavor: r.Flavor}}
+	case r.Kind == ResourceFunctions && r.Keyspace != "":
+		return []Resource{{Kind: ResourceFunctions, Flavor: r.Flavor}}
+	case r.Kind == ResourceMBeans && r.MBean != "":
+		return []Resource{{Kind: ResourceMBeans}}
+	case r.Kind == ResourceFunctions, r.Kind == ResourceMBeans, r.Kind == ResourceServiceLevels:
+		return nil
+	case r.Kind == ResourceRoles && r.Role != "":
+		return []Resource{{Kind: ResourceRoles}}
+	case r.Kind == ResourceRoles:
+		return nil
+	case r.Table != "":
+		return []Resource{{Kind: ResourceData, Keyspace: r.Keyspace}, {Kind: ResourceData}}
+	case r.Keyspace != "":
+		return []Resource{{Kind: ResourceData}}
+	}
+	return nil
+}
+
+// Permissions returns the permissions that apply to the resource.
+func (r Resource) Permissions() []string {
+	switch {
+	case r.Kind == ResourceFunctions && r.Function != "":
+		return []string{PermissionAlter, PermissionDrop, PermissionAuthorize, PermissionExecute}
+	case r.Kind == ResourceFunctions:
+		return []string{PermissionCreate, PermissionAlter, PermissionDrop, PermissionAuthorize, PermissionExecute}
+	case r.Kind == ResourceMBeans:
+		return []string{PermissionAuthorize, PermissionDescribe, PermissionExecute, PermissionModify, PermissionSelect}
+	case r.Kind == ResourceServiceLevels:
+		return []string{PermissionCreate, PermissionAlter, PermissionDrop, PermissionAuthorize, PermissionDescribe}
+	case r.Kind == ResourceRoles && r.Role != "":
+		return []string{PermissionAlter, PermissionDrop, PermissionAuthorize}
+	case r.Kind == ResourceRoles:
+		return []string{PermissionCreate, PermissionAlter, PermissionDrop, PermissionAuthorize, PermissionDescribe}
+	case r.Table != "":
+		return []string{PermissionAlter, PermissionDrop, PermissionSelect, PermissionModify, PermissionAuthorize}
+	}
+	return []string{PermissionCreate, PermissionAlter, PermissionDrop, PermissionSelect, PermissionModify, PermissionAuthorize}
+}
+
+// ValidatePermissions returns an error if any of the supplied permissions does
+// not apply to the resource, in which case Cassandra would reject granting it.
+func ValidatePermissions(r Resource, permissions []string) error {
+	applies := map[string]bool{PermissionAll: true}
+	for _, p := range r.Permissions() {
+		applies[p] = true
+	}
+	var invalid []string
+	for _, p := range permissions {
+		if !applies[p] {
+			invalid = append(invalid, p)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("%s cannot be granted on %s", strings.Join(invalid, ", "), r.CQL())
+	}
+	return nil
+}
+
+// resourcesSince lists the releases of Cassandra that introduced kinds of
+// resources, and thereby the permissions on them, e.g. DESCRIBE on ALL ROLES
+// or on MBeans.
+var resourcesSince = map[string]Version{
+	ResourceRoles:     {Major: 2, Minor: 2},
+	ResourceFunctions: {Major: 2, Minor: 2},
+	ResourceMBeans:    {Major: 3, Minor: 6},
+}
+
+// RequiredVersion returns the earliest release of Cassandra that allows
+// granting permissions on the resource, or the zero Version if every release
+// does. Releases of Scylla are not comparable and never required.
+func RequiredVersion(r Resource) Version {
+	if r.Flavor == FlavorScylla {
+		return Version{}
+	}
+	return resourcesSince[r.Kind]
+}
+
+// ValidatePermissionsFor is like ValidatePermissions, but also returns an
+// error if the supplied release of Cassandra does not allow granting
+// permissions on the resource. Releases are not checked if v is zero.
+func ValidatePermissionsFor(v Version, r Resource, permissions []string) error {
+	if err := ValidatePermissions(r, permissions); err != nil {
+		return err
+	}
+	if since := RequiredVersion(r); !v.IsZero() && v.Less(since) {
+		return fmt.Errorf("%s cannot be granted on %s before Cassandra %d.%d, but the cluster runs %s", strings.Join(permissions, ", "), r.CQL(), since.Major, since.Minor, v)
+	}
+	return nil
+}
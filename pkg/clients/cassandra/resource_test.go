@@ -0,0 +1,246 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseResource(t *testing.T) {
+	cases := map[string]struct {
+		resource Resource
+		cql      string
+		err      bool
+	}{
+		"data":               {resource: Resource{Kind: ResourceData}, cql: "ALL KEYSPACES"},
+		"data/shop":          {resource: Resource{Kind: ResourceData, Keyspace: "shop"}, cql: `KEYSPACE "shop"`},
+		"data/shop/orders":   {resource: Resource{Kind: ResourceData, Keyspace: "shop", Table: "orders"}, cql: `TABLE "shop"."orders"`},
+		"data/Shop/Orders":   {resource: Resource{Kind: ResourceData, Keyspace: "Shop", Table: "Orders"}, cql: `TABLE "Shop"."Orders"`},
+		"roles":              {resource: Resource{Kind: ResourceRoles}, cql: "ALL ROLES"},
+		"roles/app":          {resource: Resource{Kind: ResourceRoles, Role: "app"}, cql: `ROLE "app"`},
+		"roles/App Admin":    {resource: Resource{Kind: ResourceRoles, Role: "App Admin"}, cql: `ROLE "App Admin"`},
+		"roles/team/app":     {resource: Resource{Kind: ResourceRoles, Role: "team/app"}, cql: `ROLE "team/app"`},
+		`roles/we"ird`:       {resource: Resource{Kind: ResourceRoles, Role: `we"ird`}, cql: `ROLE "we""ird"`},
+		"roles/":             {err: true},
+		"data/":              {err: true},
+		"data//orders":       {err: true},
+		"data/shop/":         {err: true},
+		"data/shop/orders/x": {err: true},
+		"functions/shop":     {err: true},
+		"mbean":              {err: true},
+		"":                   {err: true},
+	}
+
+	for in, tc := range cases {
+		t.Run(in, func(t *testing.T) {
+			got, err := ParseResource(in)
+			if tc.err {
+				if err == nil {
+					t.Errorf("ParseResource(%q): want error, got %+v", in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseResource(%q): %s", in, err)
+			}
+			if diff := cmp.Diff(tc.resource, got); diff != "" {
+				t.Errorf("ParseResource(%q): -want, +got:\n%s\n", in, diff)
+			}
+			if s := got.String(); s != in {
+				t.Errorf("ParseResource(%q).String(): want round trip, got %q", in, s)
+			}
+			if cql := got.CQL(); cql != tc.cql {
+				t.Errorf("ParseResource(%q).CQL(): want %q, got %q", in, tc.cql, cql)
+			}
+		})
+	}
+}
+
+func TestParseResourceFor(t *testing.T) {
+	cases := map[string]struct {
+		flavor   Flavor
+		in       string
+		resource Resource
+		cql      string
+		err      bool
+	}{
+		"CassandraData": {
+			flavor:   FlavorCassandra,
+			in:       "data/shop/orders",
+			resource: Resource{Kind: ResourceData, Keyspace: "shop", Table: "orders"},
+			cql:      `TABLE "shop"."orders"`,
+		},
+		"ScyllaData": {
+			flavor:   FlavorScylla,
+			in:       "data/shop/orders",
+			resource: Resource{Kind: ResourceData, Keyspace: "shop", Table: "orders"},
+			cql:      `TABLE "shop"."orders"`,
+		},
+		"CassandraAllFunctions": {
+			flavor:   FlavorCassandra,
+			in:       "functions",
+			resource: Resource{Kind: ResourceFunctions},
+			cql:      "ALL FUNCTIONS",
+		},
+		"ScyllaKeyspaceFunctions": {
+			flavor:   FlavorScylla,
+			in:       "functions/shop",
+			resource: Resource{Kind: ResourceFunctions, Keyspace: "shop", Flavor: FlavorScylla},
+			cql:      `ALL FUNCTIONS IN KEYSPACE "shop"`,
+		},
+		"CassandraFunction": {
+			flavor:   FlavorCassandra,
+			in:       "functions/shop/total[org.apache.cassandra.db.marshal.Int32Type^org.apache.cassandra.db.marshal.ListType(org.apache.cassandra.db.marshal.UTF8Type)]",
+			resource: Resource{Kind: ResourceFunctions, Keyspace: "shop", Function: "total", Arguments: "int, list<text>"},
+			cql:      `FUNCTION "shop"."total"(int, list<text>)`,
+		},
+		"CassandraMapFunction": {
+			flavor:   FlavorCassandra,
+			in:       "functions/shop/merge[org.apache.cassandra.db.marshal.MapType(org.apache.cassandra.db.marshal.UTF8Type,org.apache.cassandra.db.marshal.LongType)]",
+			resource: Resource{Kind: ResourceFunctions, Keyspace: "shop", Function: "merge", Arguments: "map<text, bigint>"},
+			cql:      `FUNCTION "shop"."merge"(map<text, bigint>)`,
+		},
+		"ScyllaFunction": {
+			flavor:   FlavorScylla,
+			in:       "functions/shop/total[int^list<text>]",
+			resource: Resource{Kind: ResourceFunctions, Keyspace: "shop", Function: "total", Arguments: "int, list<text>", Flavor: FlavorScylla},
+			cql:      `FUNCTION "shop"."total"(int, list<text>)`,
+		},
+		"FunctionWithoutArguments": {
+			flavor:   FlavorScylla,
+			in:       "functions/shop/now[]",
+			resource: Resource{Kind: ResourceFunctions, Keyspace: "shop", Function: "now", Flavor: FlavorScylla},
+			cql:      `FUNCTION "shop"."now"()`,
+		},
+		"CassandraMBean": {
+			flavor:   FlavorCassandra,
+			in:       "mbean/org.apache.cassandra.db:type=Tables,*",
+			resource: Resource{Kind: ResourceMBeans, MBean: "org.apache.cassandra.db:type=Tables,*"},
+			cql:      "MBEAN 'org.apache.cassandra.db:type=Tables,*'",
+		},
+		"ScyllaServiceLevels": {
+			flavor:   FlavorScylla,
+			in:       "service_levels",
+			resource: Resource{Kind: ResourceServiceLevels, Flavor: FlavorScylla},
+			cql:      "ALL SERVICE_LEVELS",
+		},
+		"ScyllaMBean":           {flavor: FlavorScylla, in: "mbean", err: true},
+		"CassandraServiceLevel": {flavor: FlavorCassandra, in: "service_levels", err: true},
+		"FunctionSignature":     {flavor: FlavorCassandra, in: "functions/shop/total", err: true},
+		"FunctionKeyspace":      {flavor: FlavorCassandra, in: "functions//total[]", err: true},
+		"Unknown":               {flavor: FlavorScylla, in: "tables/shop", err: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseResourceFor(tc.flavor, tc.in)
+			if tc.err {
+				if err == nil {
+					t.Errorf("ParseResourceFor(%s, %q): want error, got %+v", tc.flavor, tc.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseResourceFor(%s, %q): %s", tc.flavor, tc.in, err)
+			}
+			if diff := cmp.Diff(tc.resource, got); diff != "" {
+				t.Errorf("ParseResourceFor(%s, %q): -want, +got:\n%s\n", tc.flavor, tc.in, diff)
+			}
+			if s := got.String(); s != tc.in {
+				t.Errorf("ParseResourceFor(%s, %q).String(): want round trip, got %q", tc.flavor, tc.in, s)
+			}
+			if cql := got.CQL(); cql != tc.cql {
+				t.Errorf("ParseResourceFor(%s, %q).CQL(): want %q, got %q", tc.flavor, tc.in, tc.cql, cql)
+			}
+		})
+	}
+}
+
+func TestResourceNames(t *testing.T) {
+	cases := map[string]string{
+		KeyspaceResource("shop"):        "data/shop",
+		TableResource("shop", "orders"): "data/shop/orders",
+		RoleResource("app"):             "roles/app",
+		RoleResource(""):                "roles",
+		// Resources are bound as query parameters, so they are never escaped.
+		KeyspaceResource("x' OR '1'='1"): "data/x' OR '1'='1",
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	}
+}
+
+func TestParents(t *testing.T) {
+	cases := map[string][]string{
+		"data":             nil,
+		"data/shop":        {"data"},
+		"data/shop/orders": {"data/shop", "data"},
+		"roles":            nil,
+		"roles/team/app":   {"roles"},
+		"functions/shop/total[org.apache.cassandra.db.marshal.Int32Type]": {"functions/shop", "functions"},
+		"mbean/org.apache.cassandra.db:type=Tables,*":                     {"mbean"},
+	}
+
+	for in, want := range cases {
+		r, err := ParseResourceFor(FlavorCassandra, in)
+		if err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		var got []string
+		for _, p := range r.Parents() {
+			got = append(got, p.String())
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%s: -want, +got:\n%s", in, diff)
+		}
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	cases := map[string]struct {
+		resource    Resource
+		permissions []string
+		err         bool
+	}{
+		"KeyspacePermissions": {resource: Resource{Kind: ResourceData, Keyspace: "shop"}, permissions: []string{PermissionSelect, PermissionModify, PermissionCreate}},
+		"AllPermissions":      {resource: Resource{Kind: ResourceRoles, Role: "app"}, permissions: []string{PermissionAll}},
+		"TableCreate":         {resource: Resource{Kind: ResourceData, Keyspace: "shop", Table: "orders"}, permissions: []string{PermissionSelect, PermissionCreate}, err: true},
+		"KeyspaceDescribe":    {resource: Resource{Kind: ResourceData, Keyspace: "shop"}, permissions: []string{PermissionDescribe}, err: true},
+		"AllRolesDescribe":    {resource: Resource{Kind: ResourceRoles}, permissions: []string{PermissionDescribe, PermissionCreate}},
+		"RoleSelect":          {resource: Resource{Kind: ResourceRoles, Role: "app"}, permissions: []string{PermissionSelect}, err: true},
+		"Execute":             {resource: Resource{Kind: ResourceData}, permissions: []string{PermissionExecute}, err: true},
+	}
+
+	for name, tc := range cases {
+		if err := ValidatePermissions(tc.resource, tc.permissions); (err != nil) != tc.err {
+			t.Errorf("%s: ValidatePermissions(%+v, %v): want error %t, got %v", name, tc.resource, tc.permissions, tc.err, err)
+		}
+	}
+}
+
+func TestValidatePermissionsFor(t *testing.T) {
+	cases := map[string]struct {
+		version     Version
+		resource    Resource
+		permissions []string
+		err         bool
+	}{
+		"UnknownVersion":       {resource: Resource{Kind: ResourceMBeans}, permissions: []string{PermissionDescribe}},
+		"AllRolesDescribe":     {version: Version{Major: 2, Minor: 2}, resource: Resource{Kind: ResourceRoles}, permissions: []string{PermissionDescribe}},
+		"AllRolesBeforeRoles":  {version: Version{Major: 2, Minor: 1, Patch: 22}, resource: Resource{Kind: ResourceRoles}, permissions: []string{PermissionDescribe}, err: true},
+		"MBeanDescribe":        {version: Version{Major: 4}, resource: Resource{Kind: ResourceMBeans}, permissions: []string{PermissionDescribe}},
+		"MBeanBeforeMBeans":    {version: Version{Major: 3, Minor: 0, Patch: 29}, resource: Resource{Kind: ResourceMBeans}, permissions: []string{PermissionDescribe}, err: true},
+		"ScyllaServiceLevels":  {version: Version{Major: 3, Minor: 0, Patch: 8}, resource: Resource{Kind: ResourceServiceLevels, Flavor: FlavorScylla}, permissions: []string{PermissionDescribe}},
+		"KeyspaceSelect":       {version: Version{Major: 2, Minor: 1}, resource: Resource{Kind: ResourceData, Keyspace: "shop"}, permissions: []string{PermissionSelect}},
+		"KeyspaceDescribe":     {version: Version{Major: 5}, resource: Resource{Kind: ResourceData, Keyspace: "shop"}, permissions: []string{PermissionDescribe}, err: true},
+		"FunctionsBeforeRoles": {version: Version{Major: 2, Minor: 1}, resource: Resource{Kind: ResourceFunctions}, permissions: []string{PermissionExecute}, err: true},
+	}
+
+	for name, tc := range cases {
+		if err := ValidatePermissionsFor(tc.version, tc.resource, tc.permissions); (err != nil) != tc.err {
+			t.Errorf("%s: ValidatePermissionsFor(%s, %+v, %v): want error %t, got %v", name, tc.version, tc.resource, tc.permissions, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+)
+
+// Column kinds as stored in system_schema.columns.
+const (
+	ColumnPartitionKey = "partition_key"
+	ColumnClustering   = "clustering"
+	ColumnRegular      = "regular"
+	ColumnStatic       = "static"
+)
+
+// Index kinds as stored in system_schema.indexes.
+const (
+	IndexCustom = "CUSTOM"
+)
+
+var typeName = regexp.MustCompile(`"(?:[^"]|"")*"|[a-zA-Z_][a-zA-Z0-9_]*`)
+
+// A Schema is the schema of a keyspace, without its data.
+type Schema struct {
+	Types   []Type
+	Tables  []Table
+	Indexes []Index
+}
+
+// A Type is a user defined type.
+type Type struct {
+	Name       string
+	FieldNames []string
+	FieldTypes []string
+}
+
+// A Table is a table and its columns.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// A Column of a table.
+type Column struct {
+	Name string
+	Type string
+	Kind string
+	// Position of partition key and clustering columns within their kind.
+	Position int
+	// ClusteringOrder is asc or desc for clustering columns.
+	ClusteringOrder string
+}
+
+// An Index is a secondary index of a table.
+type Index struct {
+	Name    string
+	Table   string
+	Kind    string
+	Options map[string]string
+}
+
+// KeyspaceSchema reads the schema of the named keyspace from system_schema.
+// Materialized views, functions and aggregates are not read.
+func (c *CassandraDB) KeyspaceSchema(ctx context.Context, keyspace string) (*Schema, error) {
+	s := &Schema{}
+
+	iter, err := c.Query(ctx, "SELECT type_name, field_names, field_types FROM system_schema.types WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return nil, err
+	}
+	t := Type{}
+	for iter.Scan(&t.Name, &t.FieldNames, &t.FieldTypes) {
+		s.Types = append(s.Types, t)
+		t = Type{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter, err = c.Query(ctx, "SELECT table_name, column_name, type, kind, position, clustering_order FROM system_schema.columns WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return nil, err
+	}
+	tables := map[string]*Table{}
+	var table string
+	col := Column{}
+	for iter.Scan(&table, &col.Name, &col.Type, &col.Kind, &col.Position, &col.ClusteringOrder) {
+		if tables[table] == nil {
+			tables[table] = &Table{Name: table}
+		}
+		tables[table].Columns = append(tables[table].Columns, col)
+		col = Column{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	// Views are listed in system_schema.columns too, but not in
+	// system_schema.tables.
+	iter, err = c.Query(ctx, "SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return nil, err
+	}
+	for iter.Scan(&table) {
+		if t, ok := tables[table]; ok {
+			s.Tables = append(s.Tables, *t)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter, err = c.Query(ctx, "SELECT table_name, index_name, kind, options FROM system_schema.indexes WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return nil, err
+	}
+	idx := Index{}
+	for iter.Scan(&idx.Table, &idx.Name, &idx.Kind, &idx.Options) {
+		s.Indexes = append(s.Indexes, idx)
+		idx = Index{}
+	}
+	return s, iter.Close()
+}
+
+// Names returns the names of the types, tables and indexes of this schema,
+// e.g. table orders.
+func (s *Schema) Names() []string {
+	names := make([]string, 0, len(s.Types)+len(s.Tables)+len(s.Indexes))
+	for _, t := range s.Types {
+		names = append(names, "type "+t.Name)
+	}
+	for _, t := range s.Tables {
+		names = append(names, "table "+t.Name)
+	}
+	for _, i := range s.Indexes {
+		names = append(names, "index "+i.Name)
+	}
+	return names
+}
+
+// Without returns the types, tables and indexes of this schema that the
+// supplied schema lacks, e.g. the objects of a template that are missing from
+// a keyspace bootstrapped from it. Their definitions are not compared.
+func (s *Schema) Without(other *Schema) *Schema {
+	have := map[string]bool{}
+	for _, t := range other.Types {
+		have["type "+t.Name] = true
+	}
+	for _, t := range other.Tables {
+		have["table "+t.Name] = true
+	}
+	for _, i := range other.Indexes {
+		have["index "+i.Name] = true
+	}
+
+	w := &Schema{}
+	for _, t := range s.Types {
+		if !have["type "+t.Name] {
+			w.Types = append(w.Types, t)
+		}
+	}
+	for _, t := range s.Tables {
+		if !have["table "+t.Name] {
+			w.Tables = append(w.Tables, t)
+		}
+	}
+	for _, i := range s.Indexes {
+		if !have["index "+i.Name] {
+			w.Indexes = append(w.Indexes, i)
+		}
+	}
+	return w
+}
+
+// Statements returns the CQL statements that create this schema in the named
+// keyspace. Types are created before the types and tables that use them, and
+// all statements use IF NOT EXISTS so that they can be replayed. Table options
+// are not copied, so tables are created with the server defaults.
+func (s *Schema) Statements(keyspace string) []string {
+	stmts := make([]string, 0, len(s.Types)+len(s.Tables)+len(s.Indexes))
+
+	for _, t := range sortTypes(s.Types) {
+		b := builder.CreateType(keyspace, t.Name).IfNotExists(true)
+		for i := range t.FieldNames {
+			b.Field(t.FieldNames[i], t.FieldTypes[i])
+		}
+		stmts = append(stmts, b.String())
+	}
+
+	for _, t := range s.Tables {
+		stmts = append(stmts, createTable(keyspace, t))
+	}
+
+	for _, i := range s.Indexes {
+		b := builder.CreateIndex(keyspace, i.Table, i.Name, i.Options["target"]).IfNotExists(true)
+		if i.Kind == IndexCustom {
+			opts := make(map[string]string, len(i.Options))
+			for k, v := range i.Options {
+				if k != "target" && k != "class_name" {
+					opts[k] = v
+				}
+			}
+			b.Custom(i.Options["class_name"], opts)
+		}
+		stmts = append(stmts, b.String())
+	}
+
+	return stmts
+}
+
+func createTable(keyspace string, t Table) string {
+	var pk, ck, other []Column
+	for _, c := range t.Columns {
+		switch c.Kind {
+		case ColumnPartitionKey:
+			pk = append(pk, c)
+		case ColumnClustering:
+			ck = append(ck, c)
+		default:
+			other = append(other, c)
+		}
+	}
+	sort.Slice(pk, func(i, j int) bool { return pk[i].Position < pk[j].Position })
+	sort.Slice(ck, func(i, j int) bool { return ck[i].Position < ck[j].Position })
+	sort.Slice(other, func(i, j int) bool { return other[i].Name < other[j].Name })
+
+	b := builder.CreateTable(keyspace, t.Name).IfNotExists(true)
+	for _, c := range pk {
+		b.PartitionKey(c.Name, c.Type)
+	}
+	for _, c := range ck {
+		b.ClusteringKey(c.Name, c.Type, c.ClusteringOrder)
+	}
+	for _, c := range other {
+		if c.Kind == ColumnStatic {
+			b.StaticColumn(c.Name, c.Type)
+			continue
+		}
+		b.Column(c.Name, c.Type)
+	}
+	return b.String()
+}
+
+// sortTypes orders the supplied types so that every type follows the types its
+// fields use.
+func sortTypes(types []Type) []Type {
+	byName := make(map[string]Type, len(types))
+	for _, t := range types {
+		byName[t.Name] = t
+	}
+
+	out := make([]Type, 0, len(types))
+	done := map[string]bool{}
+	var visit func(t Type)
+	visit = func(t Type) {
+		if done[t.Name] {
+			return
+		}
+		done[t.Name] = true
+		for _, ft := range t.FieldTypes {
+			for _, n := range typeName.FindAllString(ft, -1) {
+				if strings.HasPrefix(n, `"`) {
+					n = strings.ReplaceAll(n[1:len(n)-1], `""`, `"`)
+				}
+				if dep, ok := byName[n]; ok {
+					visit(dep)
+				}
+			}
+		}
+		out = append(out, t)
+	}
+	for _, t := range types {
+		visit(t)
+	}
+	return out
+}
+
+// SystemKeyspaces are the keyspaces Cassandra maintains itself.
+var SystemKeyspaces = map[string]bool{
+	"system":                true,
+	"system_auth":           true,
+	"system_distributed":    true,
+	"system_schema":         true,
+	"system_traces":         true,
+	"system_views":          true,
+	"system_virtual_schema": true,
+}
+
+// Keyspaces returns the sorted names of the keyspaces of the cluster, other
+// than the system keyspaces.
+func (c *CassandraDB) Keyspaces(ctx context.Context) ([]string, error) {
+	iter, err := c.Query(ctx, "SELECT keyspace_name FROM system_schema.keyspaces")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	var name string
+	for iter.Scan(&name) {
+		if !SystemKeyspaces[name] {
+			names = append(names, name)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DescribeKeyspace returns the statements that recreate the named keyspace
+// and its schema, including table options, functions, aggregates and views,
+// as described by the server-side DESCRIBE statement of Cassandra 4.0 and
+// later. It reports false if the cluster does not support it.
+func (c *CassandraDB) DescribeKeyspace(ctx context.Context, keyspace string) ([]string, bool, error) {
+	iter, err := c.Query(ctx, "DESCRIBE KEYSPACE "+QuoteIdentifier(keyspace))
+	if err != nil {
+		return nil, false, err
+	}
+	var stmts []string
+	for row := map[string]interface{}{}; iter.MapScan(row); row = map[string]interface{}{} {
+		if s, ok := row["create_statement"].(string); ok {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(s), ";"))
+		}
+	}
+	err = iter.Close()
+	if re, ok := AsRequestError(err); ok && re.Code() == gocql.ErrCodeSyntax {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return stmts, true, nil
+}
@@ -0,0 +1,69 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchemaStatements(t *testing.T) {
+	s := &Schema{
+		Types: []Type{
+			{Name: "customer", FieldNames: []string{"name", "address"}, FieldTypes: []string{"text", "frozen<address>"}},
+			{Name: "address", FieldNames: []string{"street"}, FieldTypes: []string{"text"}},
+		},
+		Tables: []Table{
+			{Name: "orders", Columns: []Column{
+				{Name: "note", Type: "text", Kind: ColumnRegular, Position: -1},
+				{Name: "created", Type: "timestamp", Kind: ColumnClustering, Position: 0, ClusteringOrder: "desc"},
+				{Name: "region", Type: "text", Kind: ColumnPartitionKey, Position: 1},
+				{Name: "shop", Type: "text", Kind: ColumnPartitionKey, Position: 0},
+				{Name: "customer", Type: "frozen<customer>", Kind: ColumnStatic, Position: -1},
+			}},
+			{Name: "Tags", Columns: []Column{
+				{Name: "id", Type: "uuid", Kind: ColumnPartitionKey},
+				{Name: "at", Type: "timestamp", Kind: ColumnClustering, ClusteringOrder: "asc"},
+			}},
+		},
+		Indexes: []Index{
+			{Name: "orders_note", Table: "orders", Kind: "COMPOSITES", Options: map[string]string{"target": "note"}},
+			{Name: "orders_sai", Table: "orders", Kind: IndexCustom, Options: map[string]string{
+				"target":         "note",
+				"class_name":     "StorageAttachedIndex",
+				"case_sensitive": "false",
+				"normalize":      "true",
+			}},
+		},
+	}
+
+	want := []string{
+		`CREATE TYPE IF NOT EXISTS "tenant"."address" ("street" text)`,
+		`CREATE TYPE IF NOT EXISTS "tenant"."customer" ("name" text, "address" frozen<address>)`,
+		`CREATE TABLE IF NOT EXISTS "tenant"."orders" ("shop" text, "region" text, "created" timestamp, "customer" frozen<customer> STATIC, "note" text, PRIMARY KEY (("shop", "region"), "created")) WITH CLUSTERING ORDER BY ("created" DESC)`,
+		`CREATE TABLE IF NOT EXISTS "tenant"."Tags" ("id" uuid, "at" timestamp, PRIMARY KEY (("id"), "at"))`,
+		`CREATE INDEX IF NOT EXISTS "orders_note" ON "tenant"."orders" (note)`,
+		`CREATE CUSTOM INDEX IF NOT EXISTS "orders_sai" ON "tenant"."orders" (note) USING 'StorageAttachedIndex' WITH OPTIONS = {'case_sensitive': 'false', 'normalize': 'true'}`,
+	}
+	if diff := cmp.Diff(want, s.Statements("tenant")); diff != "" {
+		t.Errorf("Statements(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSchemaWithout(t *testing.T) {
+	template := &Schema{
+		Types:   []Type{{Name: "address"}},
+		Tables:  []Table{{Name: "orders"}, {Name: "customers"}},
+		Indexes: []Index{{Name: "orders_note"}},
+	}
+	target := &Schema{
+		Tables: []Table{{Name: "orders"}, {Name: "extra"}},
+	}
+
+	want := []string{"type address", "table customers", "index orders_note"}
+	if diff := cmp.Diff(want, template.Without(target).Names()); diff != "" {
+		t.Errorf("Without(...): -want, +got:\n%s", diff)
+	}
+	if got := template.Without(template).Names(); len(got) != 0 {
+		t.Errorf("Without(self): want none, got %v", got)
+	}
+}
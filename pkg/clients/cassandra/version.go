@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Version is a release of Cassandra, e.g. 4.1.3.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a release version as reported by system.local, e.g.
+// 4.1.3 or 5.0-rc1. Qualifiers such as rc1 or SNAPSHOT are ignored.
+func ParseVersion(s string) (Version, error) {
+	release, _, _ := strings.Cut(s, "-")
+	parts := strings.Split(release, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid release version %q", s)
+	}
+	var n [3]int
+	for i, p := range parts {
+		var err error
+		if n[i], err = strconv.Atoi(p); err != nil || n[i] < 0 {
+			return Version{}, fmt.Errorf("invalid release version %q", s)
+		}
+	}
+	return Version{Major: n[0], Minor: n[1], Patch: n[2]}, nil
+}
+
+// Less reports whether v is an earlier release than o.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// IsZero reports whether v is the zero Version, i.e. unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ReleaseVersion returns the release of Cassandra the node the session is
+// connected to runs.
+func (c *CassandraDB) ReleaseVersion(ctx context.Context) (Version, error) {
+	iter, err := c.Query(ctx, "SELECT release_version FROM system.local")
+	if err != nil {
+		return Version{}, err
+	}
+
+	var release string
+	iter.Scan(&release)
+	if err := iter.Close(); err != nil {
+		return Version{}, fmt.Errorf("failed to select release version: %w", err)
+	}
+	return ParseVersion(release)
+}
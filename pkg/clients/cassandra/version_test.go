@@ -0,0 +1,50 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := map[string]struct {
+		want Version
+		err  bool
+	}{
+		"4.1.3":          {want: Version{Major: 4, Minor: 1, Patch: 3}},
+		"3.11.16":        {want: Version{Major: 3, Minor: 11, Patch: 16}},
+		"5.0-rc1":        {want: Version{Major: 5}},
+		"4.0.0-SNAPSHOT": {want: Version{Major: 4}},
+		"4":              {err: true},
+		"4.x":            {err: true},
+		"":               {err: true},
+	}
+
+	for in, tc := range cases {
+		got, err := ParseVersion(in)
+		if (err != nil) != tc.err {
+			t.Errorf("%q: ParseVersion(...): want error %t, got %v", in, tc.err, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%q: -want, +got:\n%s", in, diff)
+		}
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	cases := map[string]struct {
+		v, o Version
+		want bool
+	}{
+		"Major": {v: Version{Major: 3, Minor: 11}, o: Version{Major: 4}, want: true},
+		"Minor": {v: Version{Major: 3, Minor: 6}, o: Version{Major: 3, Minor: 11}, want: true},
+		"Patch": {v: Version{Major: 4, Patch: 2}, o: Version{Major: 4, Patch: 1}},
+		"Equal": {v: Version{Major: 2, Minor: 2}, o: Version{Major: 2, Minor: 2}},
+	}
+
+	for name, tc := range cases {
+		if got := tc.v.Less(tc.o); got != tc.want {
+			t.Errorf("%s: %s.Less(%s): want %t, got %t", name, tc.v, tc.o, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit attributes the CQL statements executed by the Cassandra
+// controllers to the provider identity and the claim or composite resource
+// that manages the affected object.
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// Labels Crossplane adds to composed resources.
+const (
+	LabelKeyClaimName      = "crossplane.io/claim-name"
+	LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+	LabelKeyComposite      = "crossplane.io/composite"
+)
+
+const (
+	reasonExecutedStatement event.Reason = "ExecutedStatement"
+
+	unknownIdentity = "unknown"
+)
+
+// An Auditor records the CQL statements executed on behalf of managed
+// resources as log lines and Kubernetes events.
+type Auditor struct {
+	log      logging.Logger
+	record   event.Recorder
+	identity string
+}
+
+// New returns an Auditor that attributes statements to the supplied identity.
+func New(l logging.Logger, r event.Recorder, identity string) *Auditor {
+	return &Auditor{log: l, record: r, identity: identity}
+}
+
+// For returns an AuditFn that records statements executed on behalf of the
+// supplied managed resource.
+func (a *Auditor) For(mg resource.Managed) cassandra.AuditFn {
+	kv := KeysAndValues(a.identity, mg)
+	return func(_ context.Context, statement string, err error) {
+		logKV := make([]interface{}, 0, len(kv)+6)
+		for _, v := range kv {
+			logKV = append(logKV, v)
+		}
+		logKV = append(logKV, "name", mg.GetName(), "statement", statement)
+
+		if err != nil {
+			a.log.Info("Failed to execute CQL statement", append(logKV, "error", err.Error())...)
+			return
+		}
+		a.log.Info("Executed CQL statement", logKV...)
+		a.record.Event(mg, event.Normal(reasonExecutedStatement, statement, kv...))
+	}
+}
+
+// KeysAndValues returns the audit annotations of the supplied managed resource
+// as alternating keys and values.
+func KeysAndValues(identity string, mg resource.Managed) []string {
+	kv := []string{"identity", identity}
+	l := mg.GetLabels()
+	for _, k := range []string{LabelKeyClaimNamespace, LabelKeyClaimName, LabelKeyComposite} {
+		if v, ok := l[k]; ok {
+			kv = append(kv, strings.TrimPrefix(k, "crossplane.io/"), v)
+		}
+	}
+	return kv
+}
+
+// IdentityFromConfig returns the identity the provider authenticates to the
+// API server as. This is the subject of its ServiceAccount token when running
+// in a cluster, e.g. system:serviceaccount:crossplane-system:provider-sql.
+func IdentityFromConfig(cfg *rest.Config) string {
+	if cfg == nil {
+		return unknownIdentity
+	}
+	if cfg.Impersonate.UserName != "" {
+		return cfg.Impersonate.UserName
+	}
+
+	token := cfg.BearerToken
+	if token == "" && cfg.BearerTokenFile != "" {
+		if b, err := os.ReadFile(cfg.BearerTokenFile); err == nil {
+			token = string(b)
+		}
+	}
+	if sub := tokenSubject(token); sub != "" {
+		return sub
+	}
+
+	if cfg.Username != "" {
+		return cfg.Username
+	}
+	return unknownIdentity
+}
+
+// tokenSubject returns the subject claim of the supplied JWT. The token is not
+// verified; it is only used to label audit records.
+func tokenSubject(token string) string {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	claims := struct {
+		Subject string `json:"sub"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
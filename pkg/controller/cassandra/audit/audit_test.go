@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestIdentityFromConfig(t *testing.T) {
+	jwt := func(payload string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	cases := map[string]struct {
+		reason string
+		cfg    *rest.Config
+		want   string
+	}{
+		"NilConfig": {
+			reason: "A nil config should result in an unknown identity",
+			want:   unknownIdentity,
+		},
+		"ServiceAccountToken": {
+			reason: "The subject of a bearer token should be used as the identity",
+			cfg:    &rest.Config{BearerToken: jwt(`{"sub":"system:serviceaccount:crossplane-system:provider-sql"}`)},
+			want:   "system:serviceaccount:crossplane-system:provider-sql",
+		},
+		"OpaqueToken": {
+			reason: "The username should be used if the token is not a JWT",
+			cfg:    &rest.Config{BearerToken: "opaque", Username: "admin"},
+			want:   "admin",
+		},
+		"Impersonation": {
+			reason: "An impersonated user takes precedence",
+			cfg: &rest.Config{
+				BearerToken: jwt(`{"sub":"system:serviceaccount:crossplane-system:provider-sql"}`),
+				Impersonate: rest.ImpersonationConfig{UserName: "alice"},
+			},
+			want: "alice",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IdentityFromConfig(tc.cfg)); diff != "" {
+				t.Errorf("\n%s\nIdentityFromConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestKeysAndValues(t *testing.T) {
+	mg := &v1alpha1.Keyspace{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{
+		LabelKeyClaimName:      "orders",
+		LabelKeyClaimNamespace: "team-a",
+		LabelKeyComposite:      "orders-x7k2p",
+		"unrelated":            "label",
+	}}}
+
+	want := []string{
+		"identity", "provider",
+		"claim-namespace", "team-a",
+		"claim-name", "orders",
+		"composite", "orders-x7k2p",
+	}
+	if diff := cmp.Diff(want, KeysAndValues("provider", mg)); diff != "" {
+		t.Errorf("KeysAndValues(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backoff retries operations with an exponential backoff. Time is
+// read from and waited for on an injectable Clock, so backoffs can be tested
+// deterministically with a FakeClock.
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// A Backoff computes the delays between the attempts of an operation.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Factor multiplies the delay after every retry. Delays are constant if
+	// it is less than 1.
+	Factor float64
+
+	// Jitter lengthens every delay by a random fraction of up to Jitter, so
+	// that operations that failed together are not retried together.
+	Jitter float64
+
+	// Cap is the longest delay, including jitter. Delays are not capped if
+	// it is zero.
+	Cap time.Duration
+
+	// Steps is the number of attempts, including the first one.
+	Steps int
+}
+
+// Delay returns the delay before the retry that follows the supplied number
+// of failed attempts, which must be at least one. The jitter is scaled by r,
+// which must be in [0, 1).
+func (b Backoff) Delay(failures int, r float64) time.Duration {
+	d := float64(b.Initial)
+	for i := 1; i < failures && b.Factor > 1; i++ {
+		d *= b.Factor
+		if b.Cap > 0 && d > float64(b.Cap) {
+			break
+		}
+	}
+	d += d * b.Jitter * r
+	if b.Cap > 0 && d > float64(b.Cap) {
+		return b.Cap
+	}
+	return time.Duration(d)
+}
+
+// A Retrier retries operations with a Backoff.
+type Retrier struct {
+	backoff Backoff
+	clock   Clock
+	rand    func() float64
+}
+
+// An Option configures a Retrier.
+type Option func(*Retrier)
+
+// WithClock makes a Retrier wait on the supplied clock rather than the clock
+// of the system.
+func WithClock(c Clock) Option {
+	return func(r *Retrier) {
+		r.clock = c
+	}
+}
+
+// WithRand makes a Retrier scale jitter by the supplied source of numbers in
+// [0, 1) rather than by math/rand.
+func WithRand(fn func() float64) Option {
+	return func(r *Retrier) {
+		r.rand = fn
+	}
+}
+
+// NewRetrier returns a Retrier that retries with the supplied Backoff.
+func NewRetrier(b Backoff, o ...Option) *Retrier {
+	r := &Retrier{backoff: b, clock: RealClock, rand: rand.Float64}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
+}
+
+// Do calls fn until it succeeds, it was attempted as many times as the
+// Backoff allows or the supplied context is done, and returns the error of
+// its last attempt.
+func (r *Retrier) Do(ctx context.Context, fn func() error) error {
+	var err error
+	for i := 0; i < max(r.backoff.Steps, 1); i++ {
+		if i > 0 {
+			if ctx.Err() != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return err
+			case <-r.clock.After(r.backoff.Delay(i, r.rand())):
+			}
+		}
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+func TestDelay(t *testing.T) {
+	type args struct {
+		failures int
+		r        float64
+	}
+
+	cases := map[string]struct {
+		reason  string
+		backoff Backoff
+		args    args
+		want    time.Duration
+	}{
+		"First": {
+			reason:  "The first retry should be delayed by the initial delay.",
+			backoff: Backoff{Initial: time.Second, Factor: 2},
+			args:    args{failures: 1},
+			want:    time.Second,
+		},
+		"Exponential": {
+			reason:  "Every retry should multiply the delay by the factor.",
+			backoff: Backoff{Initial: time.Second, Factor: 2},
+			args:    args{failures: 4},
+			want:    8 * time.Second,
+		},
+		"Constant": {
+			reason:  "A factor of less than 1 should keep the delay constant.",
+			backoff: Backoff{Initial: time.Second},
+			args:    args{failures: 4},
+			want:    time.Second,
+		},
+		"Jitter": {
+			reason:  "Jitter should lengthen the delay by a fraction scaled by r.",
+			backoff: Backoff{Initial: 10 * time.Second, Factor: 2, Jitter: 0.5},
+			args:    args{failures: 2, r: 0.5},
+			want:    25 * time.Second,
+		},
+		"Capped": {
+			reason:  "The delay should never exceed the cap.",
+			backoff: Backoff{Initial: time.Second, Factor: 2, Cap: 5 * time.Second},
+			args:    args{failures: 100},
+			want:    5 * time.Second,
+		},
+		"CappedJitter": {
+			reason:  "Jitter should not lengthen the delay beyond the cap.",
+			backoff: Backoff{Initial: 4 * time.Second, Jitter: 1, Cap: 5 * time.Second},
+			args:    args{failures: 1, r: 0.9},
+			want:    5 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.backoff.Delay(tc.args.failures, tc.args.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDelay(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	type want struct {
+		err      error
+		attempts int
+		slept    []time.Duration
+	}
+
+	cases := map[string]struct {
+		reason  string
+		ctx     context.Context
+		backoff Backoff
+		fails   int
+		want    want
+	}{
+		"Success": {
+			reason:  "An operation that succeeds should not be retried.",
+			ctx:     context.Background(),
+			backoff: Backoff{Initial: time.Second, Factor: 2, Steps: 3},
+			want:    want{attempts: 1},
+		},
+		"TransientFailure": {
+			reason:  "An operation should be retried with growing delays until it succeeds.",
+			ctx:     context.Background(),
+			backoff: Backoff{Initial: time.Second, Factor: 2, Jitter: 0.5, Steps: 5},
+			fails:   3,
+			want:    want{attempts: 4, slept: []time.Duration{1500 * time.Millisecond, 3 * time.Second, 6 * time.Second}},
+		},
+		"PersistentFailure": {
+			reason:  "The error of the last attempt should be returned once all attempts failed.",
+			ctx:     context.Background(),
+			backoff: Backoff{Initial: time.Second, Factor: 2, Cap: 3 * time.Second, Steps: 4},
+			fails:   10,
+			want:    want{err: errBoom, attempts: 4, slept: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}},
+		},
+		"NoSteps": {
+			reason: "An operation should be attempted once even if the backoff allows no steps.",
+			ctx:    context.Background(),
+			fails:  10,
+			want:   want{err: errBoom, attempts: 1},
+		},
+		"ContextDone": {
+			reason: "An operation should not be retried once the context is done.",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			backoff: Backoff{Initial: time.Second, Steps: 3},
+			fails:   10,
+			want:    want{err: errBoom, attempts: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			c := NewFakeClock(start)
+			r := NewRetrier(tc.backoff, WithClock(c), WithRand(func() float64 { return 1 }))
+
+			attempts := 0
+			err := r.Do(tc.ctx, func() error {
+				attempts++
+				if attempts <= tc.fails {
+					return errBoom
+				}
+				return nil
+			})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.attempts, attempts); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want attempts, +got attempts:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.slept, c.Slept()); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want slept, +got slept:\n%s\n", tc.reason, diff)
+			}
+			var total time.Duration
+			for _, d := range tc.want.slept {
+				total += d
+			}
+			if diff := cmp.Diff(start.Add(total), c.Now()); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want time, +got time:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package breaker stops the resources of a ProviderConfig from connecting to
+// its cluster after repeated connection failures, so that a cluster that is
+// down is not hammered by every resource retrying at once.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+// Defaults apply to ProviderConfigs that do not configure their breaker.
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+)
+
+// A State of a breaker.
+type State int
+
+// States of a breaker, as reported by the cassandra_circuit_breaker_state
+// metric.
+const (
+	// Closed breakers let every resource connect.
+	Closed State = iota
+
+	// HalfOpen breakers let a single resource probe the cluster.
+	HalfOpen
+
+	// Open breakers let no resource connect until their cooldown expires.
+	Open
+)
+
+var (
+	state = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cassandra_circuit_breaker_state",
+		Help: "State of the circuit breaker of a ProviderConfig: 0 closed, 1 half-open or 2 open.",
+	}, []string{"provider_config"})
+
+	rejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cassandra_circuit_breaker_rejected_total",
+		Help: "Connections that were not attempted because the circuit breaker of a ProviderConfig was open.",
+	}, []string{"provider_config"})
+
+	failures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cassandra_connection_failures_total",
+		Help: "Connections to the cluster of a ProviderConfig that failed.",
+	}, []string{"provider_config"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(state, rejected, failures)
+}
+
+// An UnavailableError is returned instead of connecting to a cluster while
+// the breaker of its ProviderConfig is open.
+type UnavailableError struct {
+	ProviderConfig string
+	RetryAfter     time.Duration
+	Cause          error
+}
+
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("cluster of ProviderConfig %q is unavailable, not connecting for %s: %v", e.ProviderConfig, e.RetryAfter.Round(time.Second), e.Cause)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Cause
+}
+
+// IsUnavailable reports whether err was returned because a breaker was open.
+func IsUnavailable(err error) bool {
+	var ue *UnavailableError
+	return errors.As(err, &ue)
+}
+
+// A Breaker tracks the connections to the cluster of a ProviderConfig.
+type Breaker struct {
+	name string
+	now  func() time.Time
+
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     State
+	failures  int
+	openedAt  time.Time
+	cause     error
+}
+
+// Allow returns an UnavailableError if the breaker is open. Once the cooldown
+// expires the breaker is half-open and allows a single connection, whose
+// outcome must be recorded with Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case Closed:
+		return nil
+	case Open:
+		if wait := b.cooldown - b.now().Sub(b.openedAt); wait > 0 {
+			rejected.WithLabelValues(b.name).Inc()
+			return &UnavailableError{ProviderConfig: b.name, RetryAfter: wait, Cause: b.cause}
+		}
+		b.set(HalfOpen)
+		return nil
+	}
+	// Another resource is probing the cluster.
+	rejected.WithLabelValues(b.name).Inc()
+	return &UnavailableError{ProviderConfig: b.name, RetryAfter: b.cooldown, Cause: b.cause}
+}
+
+// Success records that a connection succeeded, closing the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.cause = nil
+	b.set(Closed)
+}
+
+// Failure records that a connection failed with the supplied error. The
+// breaker opens once the failure threshold is reached, or right away if the
+// connection probed a half-open breaker.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failures.WithLabelValues(b.name).Inc()
+	b.failures++
+	b.cause = err
+	if b.state == HalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.set(Open)
+	}
+}
+
+// Record records the outcome of a connection: a Success if err is nil, and a
+// Failure otherwise.
+func (b *Breaker) Record(err error) {
+	if err != nil {
+		b.Failure(err)
+		return
+	}
+	b.Success()
+}
+
+// A Session connects to a cluster.
+type Session interface {
+	// Connect connects to the cluster.
+	Connect() error
+
+	// Warmup connects to the cluster and executes a lightweight query.
+	Warmup(ctx context.Context) error
+
+	// SetConnectObserver sets the function called with the result of
+	// connecting, if the session connects with its first statement.
+	SetConnectObserver(fn func(error))
+}
+
+// Connect connects the supplied session as the supplied ProviderConfig
+// configures and records the outcome. Sessions of ProviderConfigs that
+// connect lazily connect with their first statement instead, and record its
+// outcome then, unless the breaker probes the cluster, which must learn the
+// outcome before it allows other connections.
+func (b *Breaker) Connect(ctx context.Context, s Session, pc *v1alpha1.ProviderConfig) error {
+	if ptr.Deref(pc.Spec.LazyConnect, false) && b.State() == Closed {
+		s.SetConnectObserver(b.Record)
+		return nil
+	}
+	err := s.Connect()
+	if err == nil && ptr.Deref(pc.Spec.Warmup, false) {
+		err = s.Warmup(ctx)
+	}
+	b.Record(err)
+	return err
+}
+
+// State returns the state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) set(s State) {
+	b.state = s
+	state.WithLabelValues(b.name).Set(float64(s))
+}
+
+// A Registry holds the breakers of ProviderConfigs. It is kept in memory and
+// shared by the controllers of all kinds, so a cluster that is down is
+// detected by all of them at once.
+type Registry struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{now: time.Now, breakers: map[string]*Breaker{}}
+}
+
+// Default is the Registry of the provider.
+var Default = NewRegistry()
+
+// For returns the breaker of the supplied ProviderConfig, configured as it
+// configures it.
+func (r *Registry) For(pc *v1alpha1.ProviderConfig) *Breaker {
+	threshold, cooldown := DefaultFailureThreshold, DefaultCooldown
+	if cfg := pc.Spec.CircuitBreaker; cfg != nil {
+		if cfg.FailureThreshold != nil {
+			threshold = *cfg.FailureThreshold
+		}
+		if cfg.Cooldown != nil {
+			cooldown = cfg.Cooldown.Duration
+		}
+	}
+
+	r.mu.Lock()
+	b, ok := r.breakers[pc.GetName()]
+	if !ok {
+		b = &Breaker{name: pc.GetName(), now: r.now}
+		r.breakers[pc.GetName()] = b
+	}
+	r.mu.Unlock()
+
+	b.mu.Lock()
+	b.threshold, b.cooldown = threshold, cooldown
+	b.mu.Unlock()
+	return b
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	r := NewRegistry()
+	r.now = func() time.Time { return now }
+	pc := &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1alpha1.ProviderConfigSpec{
+			CircuitBreaker: &v1alpha1.CircuitBreakerConfig{FailureThreshold: ptr.To(2), Cooldown: &metav1.Duration{Duration: time.Minute}},
+		},
+	}
+	b := r.For(pc)
+	down := errors.New("no hosts available")
+
+	type result struct {
+		State       State
+		Unavailable bool
+	}
+	allow := func() result {
+		err := b.Allow()
+		return result{State: b.State(), Unavailable: IsUnavailable(err)}
+	}
+
+	got := []result{allow()}
+	b.Failure(down)
+	got = append(got, allow())
+	b.Failure(down)
+	got = append(got, allow())
+	now = now.Add(time.Minute)
+	got = append(got, allow(), allow())
+	b.Failure(down)
+	got = append(got, allow())
+	now = now.Add(time.Minute)
+	got = append(got, allow())
+	b.Success()
+	got = append(got, allow())
+
+	want := []result{
+		{State: Closed},
+		// Below the threshold.
+		{State: Closed},
+		// Opened.
+		{State: Open, Unavailable: true},
+		// The cooldown expired, a single resource probes the cluster.
+		{State: HalfOpen},
+		{State: HalfOpen, Unavailable: true},
+		// The probe failed.
+		{State: Open, Unavailable: true},
+		// The probe succeeded.
+		{State: HalfOpen},
+		{State: Closed},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nAllow(...): -want, +got:\n%s\n", diff)
+	}
+
+	if r.For(pc) != b {
+		t.Errorf("For(...): want the breaker to be shared per ProviderConfig")
+	}
+}
+
+// session records how it was connected.
+type session struct {
+	connectErr error
+	warmupErr  error
+	calls      []string
+	observer   func(error)
+}
+
+func (s *session) Connect() error {
+	s.calls = append(s.calls, "Connect")
+	return s.connectErr
+}
+
+func (s *session) Warmup(_ context.Context) error {
+	s.calls = append(s.calls, "Warmup")
+	return s.warmupErr
+}
+
+func (s *session) SetConnectObserver(fn func(error)) {
+	s.observer = fn
+}
+
+func TestConnect(t *testing.T) {
+	down := errors.New("no hosts available")
+
+	type want struct {
+		err      error
+		calls    []string
+		observed bool
+		state    State
+	}
+
+	cases := map[string]struct {
+		reason  string
+		spec    v1alpha1.ProviderConfigSpec
+		state   State
+		session *session
+		want    want
+	}{
+		"Eager": {
+			reason:  "Sessions should connect right away by default.",
+			session: &session{},
+			want:    want{calls: []string{"Connect"}, state: Closed},
+		},
+		"EagerFailure": {
+			reason:  "Connection failures should be recorded.",
+			spec:    v1alpha1.ProviderConfigSpec{CircuitBreaker: &v1alpha1.CircuitBreakerConfig{FailureThreshold: ptr.To(1)}},
+			session: &session{connectErr: down},
+			want:    want{err: down, calls: []string{"Connect"}, state: Open},
+		},
+		"Warmup": {
+			reason:  "Sessions should be warmed up after connecting if the ProviderConfig asks for it.",
+			spec:    v1alpha1.ProviderConfigSpec{Warmup: ptr.To(true)},
+			session: &session{},
+			want:    want{calls: []string{"Connect", "Warmup"}, state: Closed},
+		},
+		"WarmupFailure": {
+			reason:  "Warmup failures should be recorded as connection failures.",
+			spec:    v1alpha1.ProviderConfigSpec{Warmup: ptr.To(true), CircuitBreaker: &v1alpha1.CircuitBreakerConfig{FailureThreshold: ptr.To(1)}},
+			session: &session{warmupErr: down},
+			want:    want{err: down, calls: []string{"Connect", "Warmup"}, state: Open},
+		},
+		"Lazy": {
+			reason:  "Lazy sessions should not connect, but report connecting with their first statement.",
+			spec:    v1alpha1.ProviderConfigSpec{LazyConnect: ptr.To(true), Warmup: ptr.To(true)},
+			session: &session{},
+			want:    want{observed: true, state: Closed},
+		},
+		"LazyProbe": {
+			reason:  "Lazy sessions should connect right away while the breaker probes the cluster.",
+			spec:    v1alpha1.ProviderConfigSpec{LazyConnect: ptr.To(true)},
+			state:   HalfOpen,
+			session: &session{},
+			want:    want{calls: []string{"Connect"}, state: Closed},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: tc.spec}
+			b := NewRegistry().For(pc)
+			b.state = tc.state
+
+			err := b.Connect(context.Background(), tc.session, pc)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, tc.session.calls); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, tc.session.observer != nil); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want observed, +got observed:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.state, b.State()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want state, +got state:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/config"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/effectiveaccess"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/keyspace"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/role"
 	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/grant"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/trigger"
 )
 
 // Setup creates all cassandra controllers with the supplied logger and adds
@@ -35,6 +37,8 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		keyspace.Setup,
 		role.Setup,
 		grant.Setup,
+		trigger.Setup,
+		effectiveaccess.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/preflight"
+)
+
+const errNotProviderConfig = "object is not a ProviderConfig"
+
+// +kubebuilder:webhook:path=/validate-cassandra-cql-crossplane-io-v1alpha1-providerconfig,mutating=false,failurePolicy=ignore,sideEffects=None,groups=cassandra.cql.crossplane.io,resources=providerconfigs,verbs=create;update,versions=v1alpha1,name=providerconfigs.cassandra.cql.crossplane.io,admissionReviewVersions=v1
+
+// SetupWebhook adds a webhook that warns about ProviderConfigs whose
+// credentials Secret is missing or lacks the keys needed to connect, rather
+// than letting each of their managed resources fail to connect later.
+func SetupWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.ProviderConfig{}).
+		WithValidator(&validator{preflight: preflight.NewValidator(mgr.GetClient(), false)}).
+		Complete()
+}
+
+// validator validates ProviderConfigs. It only ever warns: the credentials
+// Secret of a ProviderConfig is often created after it, e.g. by the same
+// GitOps sync or by the composition that creates the cluster.
+type validator struct {
+	preflight *preflight.Validator
+}
+
+func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.warnings(ctx, obj)
+}
+
+func (v *validator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.warnings(ctx, newObj)
+}
+
+func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *validator) warnings(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pc, ok := obj.(*v1alpha1.ProviderConfig)
+	if !ok {
+		return nil, errors.New(errNotProviderConfig)
+	}
+	return v.preflight.Warnings(ctx, pc), nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/preflight"
+)
+
+func TestValidateCreate(t *testing.T) {
+	secret := func(data map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		}
+	}
+	pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+		Credentials: v1alpha1.ProviderCredentials{ConnectionSecretRef: &xpv1.SecretReference{Name: "cassandra", Namespace: "crossplane-system"}},
+	}}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		warn   bool
+	}{
+		"Incomplete": {
+			reason: "A ProviderConfig whose credentials Secret lacks a password should be admitted with a warning.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra", "username": "admin"})},
+			warn:   true,
+		},
+		"Complete": {
+			reason: "A ProviderConfig with complete credentials should be admitted without warnings.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra", "username": "admin", "password": "secret"})},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := &validator{preflight: preflight.NewValidator(tc.kube, false)}
+			warnings, err := v.ValidateCreate(context.Background(), pc)
+			if err != nil {
+				t.Errorf("\n%s\nValidateCreate(...): unexpected error: %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.warn, len(warnings) > 0); diff != "" {
+				t.Errorf("\n%s\nValidateCreate(...): -want warnings, +got warnings:\n%s\n%v\n", tc.reason, diff, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection loads what clients need to connect to the cluster of a
+// Cassandra ProviderConfig, so that every connector fails the same way when
+// its credentials are missing or incomplete.
+package connection
+
+import (
+	"context"
+	stdtls "crypto/tls"
+	stderrors "errors"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/discovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/tls"
+)
+
+const (
+	errNoSecretRef       = "ProviderConfig does not reference a credentials Secret"
+	errGetSecret         = "cannot get credentials Secret"
+	errLoadTLS           = "cannot load TLS configuration"
+	errResolveService    = "cannot resolve Service endpoint"
+	errResolveDatacenter = "cannot resolve CassandraDatacenter"
+	errAuthenticator     = "cannot configure authentication"
+	errNoEndpoint        = "credentials Secret has no endpoint"
+	errIdentity          = "identity %q"
+)
+
+// Details are what a client needs to connect to the cluster of a
+// ProviderConfig.
+type Details struct {
+	// Credentials the client connects with, including the endpoint and port
+	// of the cluster.
+	Credentials map[string][]byte
+
+	// TLS configuration of the connections, or nil if they are not
+	// encrypted.
+	TLS *stdtls.Config
+
+	// Authenticator the client authenticates with.
+	Authenticator gocql.Authenticator
+}
+
+// Load returns the connection details of the supplied ProviderConfig, read
+// using the supplied client. It returns an error if its credentials Secret
+// is not referenced or can't be read, or if the credentials have no endpoint.
+func Load(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) (Details, error) {
+	ref, svc, err := discovery.Source(ctx, kube, pc)
+	if err != nil {
+		return Details{}, errors.Wrap(err, errResolveDatacenter)
+	}
+	if ref == nil {
+		return Details{}, errors.New(errNoSecretRef)
+	}
+	return load(ctx, kube, pc, *ref, svc)
+}
+
+// load returns the details of connecting with the referenced credentials
+// Secret and Service, if any, with the TLS configuration and authentication
+// mechanism of the supplied ProviderConfig.
+func load(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig, ref xpv1.SecretReference, svc *v1alpha1.ServiceReference) (Details, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return Details{}, errors.Wrap(err, errGetSecret)
+	}
+
+	tc, err := tls.LoadConfig(ctx, kube, pc.Spec.TLS)
+	if err != nil {
+		return Details{}, errors.Wrap(err, errLoadTLS)
+	}
+
+	creds, err := discovery.Credentials(ctx, kube, svc, s.Data)
+	if err != nil {
+		return Details{}, errors.Wrap(err, errResolveService)
+	}
+	// Without an endpoint the client would only fail once it connects, with
+	// an error that does not point at the Secret.
+	if strings.TrimSpace(string(creds[xpv1.ResourceCredentialsSecretEndpointKey])) == "" {
+		return Details{}, errors.New(errNoEndpoint)
+	}
+
+	auth, err := cassandra.Authenticator(pc.Spec.AuthMechanism, creds)
+	if err != nil {
+		return Details{}, errors.Wrap(err, errAuthenticator)
+	}
+
+	return Details{Credentials: creds, TLS: tc, Authenticator: auth}, nil
+}
+
+// An identity the provider may connect with.
+type identity struct {
+	name string
+	load func() (Details, error)
+}
+
+// identities returns the identities of the supplied ProviderConfig in priority
+// order: its credentials first, followed by its failover identities.
+func identities(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) []identity {
+	ids := []identity{{name: v1alpha1.PrimaryIdentity, load: func() (Details, error) { return Load(ctx, kube, pc) }}}
+	for _, fi := range pc.Spec.FailoverIdentities {
+		fi := fi
+		ids = append(ids, identity{name: fi.Name, load: func() (Details, error) {
+			return load(ctx, kube, pc, fi.ConnectionSecretRef, fi.ServiceRef)
+		}})
+	}
+	return ids
+}
+
+// failures are the errors of the identities of a ProviderConfig, kept in the
+// priority order of their identities.
+type failures struct {
+	ids  []identity
+	errs []error
+}
+
+func newFailures(ids []identity) *failures {
+	return &failures{ids: ids, errs: make([]error, len(ids))}
+}
+
+// failover returns whether the ProviderConfig has failover identities.
+func (f *failures) failover() bool {
+	return len(f.ids) > 1
+}
+
+// add records the error of the identity at the supplied index.
+func (f *failures) add(i int, err error) {
+	if f.failover() {
+		err = errors.Wrapf(err, errIdentity, f.ids[i].name)
+	}
+	f.errs[i] = err
+}
+
+// err returns the error of the primary identity of a ProviderConfig without
+// failover identities, or the errors of all identities otherwise.
+func (f *failures) err() error {
+	if !f.failover() {
+		return f.errs[0]
+	}
+	return stderrors.Join(f.errs...)
+}
+
+// LoadFirst returns the connection details of the first identity of the
+// supplied ProviderConfig, in priority order, whose details load and pass the
+// supplied check. Unlike Connect it neither connects to the cluster nor
+// reports the identity, so it suits validating a ProviderConfig.
+func LoadFirst(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig, check func(Details) error) (Details, error) {
+	all := identities(ctx, kube, pc)
+	f := newFailures(all)
+	for i, id := range all {
+		d, err := id.load()
+		if err == nil {
+			err = check(d)
+		}
+		if err != nil {
+			f.add(i, err)
+			continue
+		}
+		return d, nil
+	}
+	return Details{}, f.err()
+}
+
+// Connect connects to the cluster of the supplied ProviderConfig using the
+// supplied function, trying its identities in priority order, and returns the
+// client of the first identity that connects. Identities whose details can't
+// be loaded, e.g. because their Service has no ready endpoints, are skipped.
+// Nothing is connected while the supplied breaker does not allow it. The
+// identity that connected is reported in the status of ProviderConfigs with
+// failover identities.
+func Connect(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig, b *breaker.Breaker, connect func(Details) (*cassandra.CassandraDB, error)) (*cassandra.CassandraDB, error) {
+	all := identities(ctx, kube, pc)
+	f := newFailures(all)
+
+	ds := make([]*Details, len(all))
+	loaded := false
+	for i, id := range all {
+		d, err := id.load()
+		if err != nil {
+			f.add(i, err)
+			continue
+		}
+		ds[i], loaded = &d, true
+	}
+	if !loaded {
+		return nil, f.err()
+	}
+
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	if err := b.Allow(); err != nil {
+		return nil, err
+	}
+	for i, d := range ds {
+		if d == nil {
+			continue
+		}
+		db, err := connect(*d)
+		if err != nil {
+			f.add(i, err)
+			continue
+		}
+		if f.failover() {
+			reportIdentity(ctx, kube, pc, all[i].name, string(d.Credentials[xpv1.ResourceCredentialsSecretEndpointKey]))
+		}
+		return db, nil
+	}
+	return nil, f.err()
+}
+
+// reportIdentity reports the supplied identity and endpoint as the active
+// identity of the supplied ProviderConfig, unless it already is. Failing to
+// report it does not fail the connection, since it is reported again by the
+// next one.
+func reportIdentity(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig, name, endpoint string) {
+	if a := pc.Status.ActiveIdentity; a != nil && a.Name == name && a.Endpoint == endpoint {
+		return
+	}
+	orig := pc.DeepCopy()
+	pc.Status.ActiveIdentity = &v1alpha1.ActiveIdentity{Name: name, Endpoint: endpoint, LastTransitionTime: metav1.Now()}
+	_ = kube.Status().Patch(ctx, pc, client.MergeFrom(orig))
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+)
+
+func TestLoad(t *testing.T) {
+	errBoom := errors.New("boom")
+	secret := func(data map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		}
+	}
+	ref := &xpv1.SecretReference{Name: "cassandra", Namespace: "crossplane-system"}
+	pc := func(ref *xpv1.SecretReference, mechanism *string) *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+			Credentials:   v1alpha1.ProviderCredentials{ConnectionSecretRef: ref},
+			AuthMechanism: mechanism,
+		}}
+	}
+
+	type want struct {
+		d   Details
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		pc     *v1alpha1.ProviderConfig
+		want   want
+	}{
+		"NoSecretRef": {
+			reason: "A ProviderConfig that does not reference a credentials Secret should return an error.",
+			pc:     pc(nil, nil),
+			want:   want{err: errors.New(errNoSecretRef)},
+		},
+		"ErrGetSecret": {
+			reason: "A credentials Secret that can't be read should return an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			pc:     pc(ref, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetSecret)},
+		},
+		"NoEndpoint": {
+			reason: "A credentials Secret without an endpoint should return an error rather than fail to connect later.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"username": "admin", "password": "secret"})},
+			pc:     pc(ref, nil),
+			want:   want{err: errors.New(errNoEndpoint)},
+		},
+		"BlankEndpoint": {
+			reason: "A credentials Secret whose endpoint is blank should return an error as if it had none.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": " ", "username": "admin", "password": "secret"})},
+			pc:     pc(ref, nil),
+			want:   want{err: errors.New(errNoEndpoint)},
+		},
+		"PartialKeys": {
+			reason: "A credentials Secret with only an endpoint should be returned as is, for clusters that do not require authentication.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra"})},
+			pc:     pc(ref, nil),
+			want: want{d: Details{
+				Credentials:   map[string][]byte{"endpoint": []byte("cassandra")},
+				Authenticator: gocql.PasswordAuthenticator{},
+			}},
+		},
+		"UnknownAuthMechanism": {
+			reason: "An authentication mechanism that is not registered should return an error.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra"})},
+			pc:     pc(ref, ptr.To("Unknown")),
+			want:   want{err: errors.Wrap(errors.New(`unknown authentication mechanism "Unknown", must be one of Password`), errAuthenticator)},
+		},
+		"Success": {
+			reason: "Complete credentials should be returned with a password authenticator.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra", "username": "admin", "password": "secret"})},
+			pc:     pc(ref, nil),
+			want: want{d: Details{
+				Credentials:   map[string][]byte{"endpoint": []byte("cassandra"), "username": []byte("admin"), "password": []byte("secret")},
+				Authenticator: gocql.PasswordAuthenticator{Username: "admin", Password: "secret"},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := Load(context.Background(), tc.kube, tc.pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.d, d); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	secrets := map[string]map[string][]byte{
+		"dc1": {"endpoint": []byte("dc1.cassandra"), "username": []byte("admin")},
+		"dc2": {"endpoint": []byte("dc2.cassandra"), "username": []byte("admin")},
+	}
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		data, ok := secrets[key.Name]
+		if !ok {
+			return errBoom
+		}
+		obj.(*corev1.Secret).Data = data
+		return nil
+	}
+	pc := func(name string, failover ...string) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+			Credentials: v1alpha1.ProviderCredentials{ConnectionSecretRef: &xpv1.SecretReference{Name: name}},
+		}}
+		pc.SetName(name)
+		for _, f := range failover {
+			pc.Spec.FailoverIdentities = append(pc.Spec.FailoverIdentities, v1alpha1.FailoverIdentity{Name: f, ConnectionSecretRef: xpv1.SecretReference{Name: f}})
+		}
+		return pc
+	}
+	// Only dc2 can be connected to.
+	connect := func(d Details) (*cassandra.CassandraDB, error) {
+		if string(d.Credentials["endpoint"]) != "dc2.cassandra" {
+			return nil, errBoom
+		}
+		return &cassandra.CassandraDB{}, nil
+	}
+
+	type want struct {
+		err    error
+		active *v1alpha1.ActiveIdentity
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *v1alpha1.ProviderConfig
+		want   want
+	}{
+		"NoFailover": {
+			reason: "Errors of ProviderConfigs without failover identities should be returned as is.",
+			pc:     pc("dc1"),
+			want:   want{err: errBoom},
+		},
+		"Primary": {
+			reason: "The primary identity should be connected with if it connects.",
+			pc:     pc("dc2", "dc1"),
+			want:   want{active: &v1alpha1.ActiveIdentity{Name: v1alpha1.PrimaryIdentity, Endpoint: "dc2.cassandra"}},
+		},
+		"Failover": {
+			reason: "Identities that can't be loaded or connected with should be skipped.",
+			pc:     pc("dc1", "missing", "dc2"),
+			want:   want{active: &v1alpha1.ActiveIdentity{Name: "dc2", Endpoint: "dc2.cassandra"}},
+		},
+		"AllFailed": {
+			reason: "The errors of every identity should be returned if none connects.",
+			pc:     pc("dc1", "missing"),
+			want: want{err: stderrors.Join(
+				errors.Wrapf(errBoom, errIdentity, v1alpha1.PrimaryIdentity),
+				errors.Wrapf(errors.Wrap(errBoom, errGetSecret), errIdentity, "missing"),
+			)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var active *v1alpha1.ActiveIdentity
+			kube := &test.MockClient{
+				MockGet: get,
+				MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					active = obj.(*v1alpha1.ProviderConfig).Status.ActiveIdentity
+					return nil
+				},
+			}
+			_, err := Connect(context.Background(), kube, tc.pc, breaker.NewRegistry().For(tc.pc), connect)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.active, active, cmpopts.IgnoreFields(v1alpha1.ActiveIdentity{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want active identity, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLoadFirst(t *testing.T) {
+	errBoom := errors.New("boom")
+	secrets := map[string]map[string][]byte{
+		"dc1": {"endpoint": []byte("dc1.cassandra")},
+		"dc2": {"endpoint": []byte("dc2.cassandra")},
+	}
+	kube := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		data, ok := secrets[key.Name]
+		if !ok {
+			return errBoom
+		}
+		obj.(*corev1.Secret).Data = data
+		return nil
+	}}
+	pc := func(name string, failover ...string) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+			Credentials: v1alpha1.ProviderCredentials{ConnectionSecretRef: &xpv1.SecretReference{Name: name}},
+		}}
+		for _, f := range failover {
+			pc.Spec.FailoverIdentities = append(pc.Spec.FailoverIdentities, v1alpha1.FailoverIdentity{Name: f, ConnectionSecretRef: xpv1.SecretReference{Name: f}})
+		}
+		return pc
+	}
+	// Only the details of dc2 pass the check.
+	check := func(d Details) error {
+		if string(d.Credentials["endpoint"]) != "dc2.cassandra" {
+			return errBoom
+		}
+		return nil
+	}
+
+	type want struct {
+		endpoint string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *v1alpha1.ProviderConfig
+		want   want
+	}{
+		"NoFailover": {
+			reason: "Errors of ProviderConfigs without failover identities should be returned as is.",
+			pc:     pc("dc1"),
+			want:   want{err: errBoom},
+		},
+		"Failover": {
+			reason: "Identities that can't be loaded or don't pass the check should be skipped.",
+			pc:     pc("missing", "dc1", "dc2"),
+			want:   want{endpoint: "dc2.cassandra"},
+		},
+		"AllFailed": {
+			reason: "The errors of every identity should be returned if none passes the check.",
+			pc:     pc("dc1", "missing"),
+			want: want{err: stderrors.Join(
+				errors.Wrapf(errBoom, errIdentity, v1alpha1.PrimaryIdentity),
+				errors.Wrapf(errors.Wrap(errBoom, errGetSecret), errIdentity, "missing"),
+			)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := LoadFirst(context.Background(), kube, tc.pc, check)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLoadFirst(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.endpoint, string(d.Credentials["endpoint"])); diff != "" {
+				t.Errorf("\n%s\nLoadFirst(...): -want endpoint, +got endpoint:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials enqueues Cassandra managed resources when the Secrets
+// their ProviderConfig connects with change, so that rotated credentials are
+// used without waiting for the next poll.
+package credentials
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/discovery"
+)
+
+const (
+	errListPCs = "cannot list ProviderConfigs"
+	errList    = "cannot list managed resources"
+)
+
+// EnqueueUsers returns an event handler that enqueues the managed resources of
+// the kind listed by newList whose ProviderConfig connects with the Secret of
+// an event. Every controller watching Secrets shares the Secret informer of
+// the manager.
+func EnqueueUsers(kube client.Client, newList func() resource.ManagedList, log logging.Logger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, s client.Object) []reconcile.Request {
+		reqs, err := Users(ctx, kube, s, newList())
+		if err != nil {
+			log.Debug("Cannot enqueue the users of a Secret", "secret", client.ObjectKeyFromObject(s), "error", err)
+		}
+		return reqs
+	})
+}
+
+// Users returns requests for the managed resources of the supplied list's kind
+// whose ProviderConfig connects with the supplied Secret.
+func Users(ctx context.Context, kube client.Client, s client.Object, l resource.ManagedList) ([]reconcile.Request, error) {
+	pcs, err := ProviderConfigs(ctx, kube, s)
+	if err != nil || len(pcs) == 0 {
+		return nil, err
+	}
+
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+
+	var reqs []reconcile.Request
+	for _, mg := range l.GetItems() {
+		if ref := mg.GetProviderConfigReference(); ref != nil && pcs[ref.Name] {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+		}
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].Name < reqs[j].Name })
+	return reqs, nil
+}
+
+// ProviderConfigs returns the names of the ProviderConfigs that connect with
+// the supplied Secret, i.e. read their credentials or TLS certificates from
+// it. ProviderConfigs whose credentials Secret cannot be resolved are skipped.
+func ProviderConfigs(ctx context.Context, kube client.Client, s client.Object) (map[string]bool, error) {
+	l := &v1alpha1.ProviderConfigList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListPCs)
+	}
+
+	key := types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()}
+	pcs := map[string]bool{}
+	for i := range l.Items {
+		if uses(ctx, kube, &l.Items[i], key) {
+			pcs[l.Items[i].GetName()] = true
+		}
+	}
+	return pcs, nil
+}
+
+func uses(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig, key types.NamespacedName) bool {
+	if t := pc.Spec.TLS; t != nil {
+		if t.CACert != nil && (types.NamespacedName{Namespace: t.CACert.Namespace, Name: t.CACert.Name}) == key {
+			return true
+		}
+		if t.ClientCertSecretRef != nil && (types.NamespacedName{Namespace: t.ClientCertSecretRef.Namespace, Name: t.ClientCertSecretRef.Name}) == key {
+			return true
+		}
+	}
+
+	// Resolving the Secret of a CassandraDatacenter requires reading it, so
+	// it is only done if the Secret may belong to it.
+	if dc := pc.Spec.Credentials.DatacenterRef; pc.Spec.Credentials.Source == v1alpha1.CredentialsSourceCassandraDatacenter && (dc == nil || dc.Namespace != key.Namespace) {
+		return false
+	}
+	ref, _, err := discovery.Source(ctx, kube, pc)
+	return err == nil && ref != nil && (types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}) == key
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestUsers(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	secret := &corev1.Secret{ObjectMeta: v1.ObjectMeta{Namespace: "db", Name: "admin"}}
+
+	pc := func(name string, modify func(pc *v1alpha1.ProviderConfig)) v1alpha1.ProviderConfig {
+		pc := v1alpha1.ProviderConfig{ObjectMeta: v1.ObjectMeta{Name: name}}
+		pc.Spec.Credentials.Source = v1alpha1.CredentialsSourceCassandraConnectionSecret
+		pc.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{Namespace: "db", Name: "other"}
+		if modify != nil {
+			modify(&pc)
+		}
+		return pc
+	}
+	keyspace := func(name, pc string) v1alpha1.Keyspace {
+		return v1alpha1.Keyspace{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Spec: v1alpha1.KeyspaceSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: pc}},
+			},
+		}
+	}
+	list := func(pcs []v1alpha1.ProviderConfig, ks []v1alpha1.Keyspace, err error) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *v1alpha1.ProviderConfigList:
+				l.Items = pcs
+			case *v1alpha1.KeyspaceList:
+				l.Items = ks
+				return err
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		reqs []reconcile.Request
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		want   want
+	}{
+		"ErrListProviderConfigs": {
+			reason: "Errors listing ProviderConfigs should be returned",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errListPCs)},
+		},
+		"Unused": {
+			reason: "Nothing should be enqueued if no ProviderConfig connects with the Secret",
+			kube:   &test.MockClient{MockList: list([]v1alpha1.ProviderConfig{pc("default", nil)}, []v1alpha1.Keyspace{keyspace("shop", "default")}, nil)},
+		},
+		"ErrList": {
+			reason: "Errors listing managed resources should be returned",
+			kube: &test.MockClient{MockList: list([]v1alpha1.ProviderConfig{pc("default", func(pc *v1alpha1.ProviderConfig) {
+				pc.Spec.Credentials.ConnectionSecretRef.Name = "admin"
+			})}, nil, errBoom)},
+			want: want{err: errors.Wrap(errBoom, errList)},
+		},
+		"Users": {
+			reason: "Resources of the ProviderConfigs reading their credentials or certificates from the Secret should be enqueued",
+			kube: &test.MockClient{
+				MockList: list([]v1alpha1.ProviderConfig{
+					pc("credentials", func(pc *v1alpha1.ProviderConfig) {
+						pc.Spec.Credentials.ConnectionSecretRef.Name = "admin"
+					}),
+					pc("tls", func(pc *v1alpha1.ProviderConfig) {
+						pc.Spec.TLS = &v1alpha1.TLSConfig{ClientCertSecretRef: &xpv1.SecretReference{Namespace: "db", Name: "admin"}}
+					}),
+					pc("datacenter", func(pc *v1alpha1.ProviderConfig) {
+						pc.Spec.Credentials.Source = v1alpha1.CredentialsSourceCassandraDatacenter
+						pc.Spec.Credentials.DatacenterRef = &v1alpha1.DatacenterReference{Namespace: "other", Name: "dc1"}
+					}),
+					pc("unused", nil),
+				}, []v1alpha1.Keyspace{
+					keyspace("blog", "tls"),
+					keyspace("logs", "datacenter"),
+					keyspace("shop", "credentials"),
+					keyspace("temp", "unused"),
+				}, nil),
+			},
+			want: want{reqs: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "blog"}},
+				{NamespacedName: types.NamespacedName{Name: "shop"}},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reqs, err := Users(context.Background(), tc.kube, secret, &v1alpha1.KeyspaceList{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUsers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reqs, reqs); diff != "" {
+				t.Errorf("\n%s\nUsers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dedup collapses the identical errors that are logged and recorded
+// as events every time a failing managed resource is polled into periodic
+// summaries.
+package dedup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// DefaultWindow is how long repeats of an error are suppressed for before
+// they are summarized.
+const DefaultWindow = time.Hour
+
+// Keys and values that change between otherwise identical log lines.
+var volatileKeys = map[string]bool{
+	"version":       true,
+	"requeue-after": true,
+}
+
+// A Filter tracks how often identical errors occurred.
+type Filter struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      map[uint64]*occurrence
+	lastSweep time.Time
+}
+
+type occurrence struct {
+	emitted    time.Time
+	suppressed int
+}
+
+// NewFilter returns a Filter that lets an error through once per window.
+func NewFilter(window time.Duration) *Filter {
+	return &Filter{window: window, now: time.Now, seen: map[uint64]*occurrence{}}
+}
+
+// Allow reports whether an error identified by the supplied parts should be
+// emitted, and how many times it was suppressed since it was last emitted.
+func (f *Filter) Allow(parts ...string) (int, bool) {
+	h := fnv.New64a()
+	for _, p := range parts {
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+	}
+	key := h.Sum64()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	f.sweep(now)
+
+	o, ok := f.seen[key]
+	if ok && now.Sub(o.emitted) < f.window {
+		o.suppressed++
+		return 0, false
+	}
+
+	suppressed := 0
+	if ok {
+		suppressed = o.suppressed
+	}
+	f.seen[key] = &occurrence{emitted: now}
+	return suppressed, true
+}
+
+// sweep forgets errors that did not occur again for a whole window after they
+// were last emitted, so that memory use is bounded by the error rate.
+func (f *Filter) sweep(now time.Time) {
+	if now.Sub(f.lastSweep) < f.window {
+		return
+	}
+	for k, o := range f.seen {
+		if now.Sub(o.emitted) >= 2*f.window {
+			delete(f.seen, k)
+		}
+	}
+	f.lastSweep = now
+}
+
+// A Logger suppresses log lines with an error that were already logged within
+// the window of its Filter. Other log lines are passed through.
+type Logger struct {
+	log    logging.Logger
+	filter *Filter
+	kv     []any
+}
+
+// NewLogger returns a Logger that deduplicates errors logged to l.
+func NewLogger(l logging.Logger, f *Filter) *Logger {
+	return &Logger{log: l, filter: f}
+}
+
+// Info logs a message unless it is a suppressed repeat.
+func (l *Logger) Info(msg string, keysAndValues ...any) {
+	if kv, ok := l.allow(msg, keysAndValues); ok {
+		l.log.Info(msg, kv...)
+	}
+}
+
+// Debug logs a message unless it is a suppressed repeat.
+func (l *Logger) Debug(msg string, keysAndValues ...any) {
+	if kv, ok := l.allow(msg, keysAndValues); ok {
+		l.log.Debug(msg, kv...)
+	}
+}
+
+// WithValues returns a Logger that includes the supplied keys and values with
+// every log line, and shares the Filter of this one.
+func (l *Logger) WithValues(keysAndValues ...any) logging.Logger {
+	kv := make([]any, 0, len(l.kv)+len(keysAndValues))
+	kv = append(kv, l.kv...)
+	kv = append(kv, keysAndValues...)
+	return &Logger{log: l.log.WithValues(keysAndValues...), filter: l.filter, kv: kv}
+}
+
+func (l *Logger) allow(msg string, keysAndValues []any) ([]any, bool) {
+	parts := []string{msg}
+	hasErr := false
+	for _, kv := range [][]any{l.kv, keysAndValues} {
+		for i := 0; i+1 < len(kv); i += 2 {
+			k := fmt.Sprint(kv[i])
+			if volatileKeys[k] {
+				continue
+			}
+			hasErr = hasErr || k == "error"
+			parts = append(parts, k, fmt.Sprint(kv[i+1]))
+		}
+	}
+	if !hasErr {
+		return keysAndValues, true
+	}
+
+	n, ok := l.filter.Allow(parts...)
+	if n > 0 {
+		keysAndValues = append(keysAndValues, "repeated", n)
+	}
+	return keysAndValues, ok
+}
+
+// A Recorder suppresses warning events that were already recorded for the
+// same object within the window of its Filter. Other events are passed through.
+type Recorder struct {
+	rec    event.Recorder
+	filter *Filter
+}
+
+// NewRecorder returns a Recorder that deduplicates warnings recorded to r.
+func NewRecorder(r event.Recorder, f *Filter) *Recorder {
+	return &Recorder{rec: r, filter: f}
+}
+
+// Event records the supplied event unless it is a suppressed repeat.
+func (r *Recorder) Event(obj runtime.Object, e event.Event) {
+	if e.Type != event.TypeWarning {
+		r.rec.Event(obj, e)
+		return
+	}
+
+	var uid string
+	if o, err := meta.Accessor(obj); err == nil {
+		uid = string(o.GetUID())
+	}
+
+	n, ok := r.filter.Allow("event", uid, string(e.Reason), e.Message)
+	if !ok {
+		return
+	}
+	if n > 0 {
+		e.Message = fmt.Sprintf("%s (repeated %d times in the last %s)", e.Message, n, r.filter.window)
+	}
+	r.rec.Event(obj, e)
+}
+
+// WithAnnotations returns a Recorder that includes the supplied annotations
+// with all recorded events, and shares the Filter of this one.
+func (r *Recorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return &Recorder{rec: r.rec.WithAnnotations(keysAndValues...), filter: r.filter}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newFilter(c *clock) *Filter {
+	f := NewFilter(time.Hour)
+	f.now = c.now
+	return f
+}
+
+type recorder struct{ events []string }
+
+func (r *recorder) Event(_ runtime.Object, e event.Event)      { r.events = append(r.events, e.Message) }
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+type logger struct {
+	lines *[][]any
+	kv    []any
+}
+
+func (l logger) Info(msg string, kv ...any) {
+	*l.lines = append(*l.lines, append(append([]any{msg}, l.kv...), kv...))
+}
+
+func (l logger) Debug(msg string, kv ...any) { l.Info(msg, kv...) }
+
+func (l logger) WithValues(kv ...any) logging.Logger {
+	return logger{lines: l.lines, kv: append(append([]any{}, l.kv...), kv...)}
+}
+
+func TestFilter(t *testing.T) {
+	c := &clock{t: time.Now()}
+	f := newFilter(c)
+
+	type result struct {
+		Suppressed int
+		Allowed    bool
+	}
+	allow := func(parts ...string) result {
+		n, ok := f.Allow(parts...)
+		return result{n, ok}
+	}
+
+	got := []result{allow("a", "boom"), allow("a", "boom"), allow("b", "boom"), allow("a", "boom")}
+	c.advance(time.Hour)
+	got = append(got, allow("a", "boom"), allow("a", "boom"))
+	c.advance(3 * time.Hour)
+	got = append(got, allow("a", "boom"))
+
+	want := []result{
+		{Allowed: true}, {}, {Allowed: true}, {},
+		{Suppressed: 2, Allowed: true}, {},
+		// The error was forgotten after not being emitted for two windows.
+		{Allowed: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nAllow(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	c := &clock{t: time.Now()}
+	var lines [][]any
+	l := NewLogger(logger{lines: &lines}, newFilter(c))
+
+	l.Debug("Reconciling")
+	l.Debug("Reconciling")
+	for i := 0; i < 3; i++ {
+		// The version changes with every status update, so it must not
+		// prevent identical errors from being deduplicated.
+		l.WithValues("request", "a", "version", i).Debug("Cannot observe external resource", "error", "boom")
+	}
+	l.WithValues("request", "b").Debug("Cannot observe external resource", "error", "boom")
+	c.advance(time.Hour)
+	l.WithValues("request", "a").Debug("Cannot observe external resource", "error", "boom")
+
+	want := [][]any{
+		{"Reconciling"},
+		{"Reconciling"},
+		{"Cannot observe external resource", "request", "a", "version", 0, "error", "boom"},
+		{"Cannot observe external resource", "request", "b", "error", "boom"},
+		{"Cannot observe external resource", "request", "a", "error", "boom", "repeated", 2},
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("\nLogger: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	c := &clock{t: time.Now()}
+	r := &recorder{}
+	rec := NewRecorder(r, newFilter(c))
+	a := &v1alpha1.Keyspace{ObjectMeta: v1.ObjectMeta{UID: "a"}}
+	b := &v1alpha1.Keyspace{ObjectMeta: v1.ObjectMeta{UID: "b"}}
+	errBoom := errors.New("boom")
+
+	rec.Event(a, event.Normal("Created", "created"))
+	rec.Event(a, event.Normal("Created", "created"))
+	rec.Event(a, event.Warning("CannotObserve", errBoom))
+	rec.WithAnnotations("k", "v").Event(a, event.Warning("CannotObserve", errBoom))
+	rec.Event(b, event.Warning("CannotObserve", errBoom))
+	c.advance(time.Hour)
+	rec.Event(a, event.Warning("CannotObserve", errBoom))
+
+	want := []string{"created", "created", "boom", "boom", "boom (repeated 1 times in the last 1h0m0s)"}
+	if diff := cmp.Diff(want, r.events); diff != "" {
+		t.Errorf("\nRecorder: -want, +got:\n%s\n", diff)
+	}
+}
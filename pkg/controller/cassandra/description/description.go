@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package description locates the table the descriptions of Cassandra managed
+// resources are kept in.
+package description
+
+import (
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// DefaultTable is the table descriptions are kept in by default.
+const DefaultTable = "crossplane_descriptions"
+
+// Table returns the description table configured by the supplied config, or
+// nil if descriptions are not stored.
+func Table(cfg *v1alpha1.DescriptionsConfig) *cassandra.DescriptionTable {
+	if cfg == nil {
+		return nil
+	}
+	t := &cassandra.DescriptionTable{Keyspace: cfg.Keyspace, Table: DefaultTable}
+	if cfg.Table != nil {
+		t.Table = *cfg.Table
+	}
+	return t
+}
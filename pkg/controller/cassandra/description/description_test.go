@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package description
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestTable(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cfg    *v1alpha1.DescriptionsConfig
+		want   *cassandra.DescriptionTable
+	}{
+		"NotConfigured": {
+			reason: "Descriptions should not be stored if no table is configured",
+		},
+		"DefaultTable": {
+			reason: "The default table should be used if none is named",
+			cfg:    &v1alpha1.DescriptionsConfig{Keyspace: "ops"},
+			want:   &cassandra.DescriptionTable{Keyspace: "ops", Table: DefaultTable},
+		},
+		"Table": {
+			reason: "The named table should be used",
+			cfg:    &v1alpha1.DescriptionsConfig{Keyspace: "ops", Table: ptr.To("notes")},
+			want:   &cassandra.DescriptionTable{Keyspace: "ops", Table: "notes"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Table(tc.cfg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTable(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
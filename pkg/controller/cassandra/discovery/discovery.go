@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discovery resolves the endpoint of a Cassandra cluster from the
+// Kubernetes Service it is exposed through, and the credentials and Service of
+// k8ssandra CassandraDatacenters.
+package discovery
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+// DatacenterGroupVersionKind is the kind of the CassandraDatacenters managed
+// by the k8ssandra cass-operator.
+var DatacenterGroupVersionKind = schema.GroupVersionKind{Group: "cassandra.datastax.com", Version: "v1beta1", Kind: "CassandraDatacenter"}
+
+// The name of the CQL port of the services of CassandraDatacenters.
+const datacenterPortName = "native"
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]`)
+
+const (
+	errNoDatacenterRef = "ProviderConfig does not reference a CassandraDatacenter"
+	errGetDatacenter   = "cannot get CassandraDatacenter"
+	errNoClusterName   = "CassandraDatacenter has no cluster name"
+	errGetService      = "cannot get Service"
+	errListSlices      = "cannot list EndpointSlices of Service"
+	errNoPorts         = "Service has no ports"
+	errNoPort          = "Service has no port named"
+	errNoReadyEndpoint = "Service has no ready endpoints"
+)
+
+// Source returns the credentials Secret and the Service, if any, the supplied
+// ProviderConfig connects with. If its credentials source is a
+// CassandraDatacenter they are derived from the datacenter like cass-operator
+// names them, unless the ProviderConfig references a Service itself.
+func Source(ctx context.Context, kube client.Client, pc *v1alpha1.ProviderConfig) (*xpv1.SecretReference, *v1alpha1.ServiceReference, error) {
+	if pc.Spec.Credentials.Source != v1alpha1.CredentialsSourceCassandraDatacenter {
+		return pc.Spec.Credentials.ConnectionSecretRef, pc.Spec.ServiceRef, nil
+	}
+
+	ref := pc.Spec.Credentials.DatacenterRef
+	if ref == nil {
+		return nil, nil, errors.New(errNoDatacenterRef)
+	}
+
+	dc := &unstructured.Unstructured{}
+	dc.SetGroupVersionKind(DatacenterGroupVersionKind)
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, dc); err != nil {
+		return nil, nil, errors.Wrap(err, errGetDatacenter)
+	}
+
+	cluster, _, _ := unstructured.NestedString(dc.Object, "spec", "clusterName")
+	if cluster == "" {
+		return nil, nil, errors.New(errNoClusterName)
+	}
+	secret, _, _ := unstructured.NestedString(dc.Object, "spec", "superuserSecretName")
+	if secret == "" {
+		secret = kubernetesName(cluster) + "-superuser"
+	}
+
+	svc := pc.Spec.ServiceRef
+	if svc == nil {
+		name, _, _ := unstructured.NestedString(dc.Object, "spec", "datacenterName")
+		if name == "" {
+			name = dc.GetName()
+		}
+		svc = &v1alpha1.ServiceReference{
+			Name:      kubernetesName(cluster) + "-" + kubernetesName(name) + "-service",
+			Namespace: ref.Namespace,
+			PortName:  ptr.To(datacenterPortName),
+		}
+	}
+
+	return &xpv1.SecretReference{Namespace: ref.Namespace, Name: secret}, svc, nil
+}
+
+// kubernetesName returns the supplied cluster or datacenter name cleaned up
+// for use in object names, like cass-operator does.
+func kubernetesName(name string) string {
+	return invalidNameChars.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// Credentials returns the supplied connection credentials with their endpoint
+// and port resolved from the referenced Service. The credentials are returned
+// unchanged if ref is nil.
+func Credentials(ctx context.Context, kube client.Client, ref *v1alpha1.ServiceReference, creds map[string][]byte) (map[string][]byte, error) {
+	if ref == nil {
+		return creds, nil
+	}
+
+	endpoint, port, err := Resolve(ctx, kube, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]byte, len(creds)+2)
+	for k, v := range creds {
+		out[k] = v
+	}
+	out[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(endpoint)
+	out[xpv1.ResourceCredentialsSecretPortKey] = []byte(port)
+	return out, nil
+}
+
+// Resolve returns the endpoint and port of the referenced Service. The endpoint
+// is either the DNS name of the Service or a comma separated list of the IPs
+// of its ready pods.
+func Resolve(ctx context.Context, kube client.Client, ref *v1alpha1.ServiceReference) (string, string, error) {
+	svc := &corev1.Service{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, svc); err != nil {
+		return "", "", errors.Wrap(err, errGetService)
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return "", "", errors.New(errNoPorts)
+	}
+	sp := svc.Spec.Ports[0]
+	if ref.PortName != nil {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			if p.Name == *ref.PortName {
+				sp, found = p, true
+				break
+			}
+		}
+		if !found {
+			return "", "", errors.Errorf("%s %q", errNoPort, *ref.PortName)
+		}
+	}
+
+	if ref.Resolve == nil || *ref.Resolve != v1alpha1.ServiceResolvePodIPs {
+		return svc.GetName() + "." + svc.GetNamespace() + ".svc", strconv.Itoa(int(sp.Port)), nil
+	}
+
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := kube.List(ctx, slices, client.InNamespace(ref.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: ref.Name}); err != nil {
+		return "", "", errors.Wrap(err, errListSlices)
+	}
+
+	var ips []string
+	var port int32
+	for _, s := range slices.Items {
+		p := slicePort(s, sp.Name)
+		if p == 0 {
+			continue
+		}
+		for _, e := range s.Endpoints {
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				continue
+			}
+			ips = append(ips, e.Addresses...)
+			port = p
+		}
+	}
+	if len(ips) == 0 {
+		return "", "", errors.New(errNoReadyEndpoint)
+	}
+
+	sort.Strings(ips)
+	return strings.Join(ips, ","), strconv.Itoa(int(port)), nil
+}
+
+// slicePort returns the port of the supplied EndpointSlice with the supplied
+// name, or 0 if it has none.
+func slicePort(s discoveryv1.EndpointSlice, name string) int32 {
+	for _, p := range s.Ports {
+		if p.Port != nil && (p.Name == nil && name == "" || p.Name != nil && *p.Name == name) {
+			return *p.Port
+		}
+	}
+	return 0
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	service := func(ports ...corev1.ServicePort) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Service)
+			s.SetName("cassandra")
+			s.SetNamespace("db")
+			s.Spec.Ports = ports
+			return nil
+		}
+	}
+	slices := func(s ...discoveryv1.EndpointSlice) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*discoveryv1.EndpointSliceList).Items = s
+			return nil
+		}
+	}
+	cql := corev1.ServicePort{Name: "cql", Port: 9042}
+	jmx := corev1.ServicePort{Name: "jmx", Port: 7199}
+	slice := discoveryv1.EndpointSlice{
+		ObjectMeta: v1.ObjectMeta{Name: "cassandra-abc"},
+		Ports: []discoveryv1.EndpointPort{
+			{Name: ptr.To("jmx"), Port: ptr.To[int32](7199)},
+			{Name: ptr.To("cql"), Port: ptr.To[int32](19042)},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+			{Addresses: []string{"10.0.0.3"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+			{Addresses: []string{"10.0.0.1"}},
+		},
+	}
+	creds := map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte("admin"),
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte("stale.example.org"),
+	}
+	resolved := func(endpoint, port string) map[string][]byte {
+		return map[string][]byte{
+			xpv1.ResourceCredentialsSecretUserKey:     []byte("admin"),
+			xpv1.ResourceCredentialsSecretEndpointKey: []byte(endpoint),
+			xpv1.ResourceCredentialsSecretPortKey:     []byte(port),
+		}
+	}
+
+	type want struct {
+		creds map[string][]byte
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		ref    *v1alpha1.ServiceReference
+		want   want
+	}{
+		"NoServiceRef": {
+			reason: "The credentials should be returned unchanged if no Service is referenced",
+			want:   want{creds: creds},
+		},
+		"ErrGetService": {
+			reason: "An error should be returned if the Service can't be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db"},
+			want:   want{err: errors.Wrap(errBoom, errGetService)},
+		},
+		"NoPorts": {
+			reason: "An error should be returned if the Service has no ports",
+			kube:   &test.MockClient{MockGet: service()},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db"},
+			want:   want{err: errors.New(errNoPorts)},
+		},
+		"NoNamedPort": {
+			reason: "An error should be returned if the Service has no port with the supplied name",
+			kube:   &test.MockClient{MockGet: service(jmx)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db", PortName: ptr.To("cql")},
+			want:   want{err: errors.Errorf("%s %q", errNoPort, "cql")},
+		},
+		"DNS": {
+			reason: "The DNS name of the Service and its first port should be used by default",
+			kube:   &test.MockClient{MockGet: service(cql, jmx)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db"},
+			want:   want{creds: resolved("cassandra.db.svc", "9042")},
+		},
+		"NamedPort": {
+			reason: "The named port of the Service should be used",
+			kube:   &test.MockClient{MockGet: service(jmx, cql)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db", PortName: ptr.To("cql")},
+			want:   want{creds: resolved("cassandra.db.svc", "9042")},
+		},
+		"ErrListSlices": {
+			reason: "An error should be returned if the EndpointSlices of the Service can't be listed",
+			kube:   &test.MockClient{MockGet: service(cql), MockList: test.NewMockListFn(errBoom)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db", Resolve: ptr.To(v1alpha1.ServiceResolvePodIPs)},
+			want:   want{err: errors.Wrap(errBoom, errListSlices)},
+		},
+		"PodIPs": {
+			reason: "The ready pod IPs and their target port should be used",
+			kube:   &test.MockClient{MockGet: service(jmx, cql), MockList: slices(slice)},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db", PortName: ptr.To("cql"), Resolve: ptr.To(v1alpha1.ServiceResolvePodIPs)},
+			want:   want{creds: resolved("10.0.0.1,10.0.0.2", "19042")},
+		},
+		"NoReadyEndpoints": {
+			reason: "An error should be returned if no pod backing the Service is ready",
+			kube:   &test.MockClient{MockGet: service(cql), MockList: slices()},
+			ref:    &v1alpha1.ServiceReference{Name: "cassandra", Namespace: "db", Resolve: ptr.To(v1alpha1.ServiceResolvePodIPs)},
+			want:   want{err: errors.New(errNoReadyEndpoint)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Credentials(context.Background(), tc.kube, tc.ref, creds)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creds, got); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	pc := func(source xpv1.CredentialsSource, svc *v1alpha1.ServiceReference) *v1alpha1.ProviderConfig {
+		pc := &v1alpha1.ProviderConfig{}
+		pc.Spec.Credentials.Source = source
+		pc.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{Namespace: "db", Name: "conn"}
+		pc.Spec.ServiceRef = svc
+		if source == v1alpha1.CredentialsSourceCassandraDatacenter {
+			pc.Spec.Credentials.DatacenterRef = &v1alpha1.DatacenterReference{Namespace: "k8ssandra", Name: "dc1"}
+		}
+		return pc
+	}
+	datacenter := func(spec map[string]any) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			if u.GroupVersionKind() != DatacenterGroupVersionKind || key.Name != "dc1" {
+				return errBoom
+			}
+			u.SetName("dc1")
+			u.Object["spec"] = spec
+			return nil
+		}
+	}
+	own := &v1alpha1.ServiceReference{Namespace: "db", Name: "cassandra"}
+
+	type want struct {
+		secret *xpv1.SecretReference
+		svc    *v1alpha1.ServiceReference
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		pc     *v1alpha1.ProviderConfig
+		want   want
+	}{
+		"ConnectionSecret": {
+			reason: "The connection secret and Service of the ProviderConfig should be used",
+			pc:     pc(v1alpha1.CredentialsSourceCassandraConnectionSecret, own),
+			want:   want{secret: &xpv1.SecretReference{Namespace: "db", Name: "conn"}, svc: own},
+		},
+		"NoDatacenterRef": {
+			reason: "An error should be returned if no CassandraDatacenter is referenced",
+			pc: func() *v1alpha1.ProviderConfig {
+				pc := pc(v1alpha1.CredentialsSourceCassandraDatacenter, nil)
+				pc.Spec.Credentials.DatacenterRef = nil
+				return pc
+			}(),
+			want: want{err: errors.New(errNoDatacenterRef)},
+		},
+		"ErrGetDatacenter": {
+			reason: "Errors getting the CassandraDatacenter should be returned",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			pc:     pc(v1alpha1.CredentialsSourceCassandraDatacenter, nil),
+			want:   want{err: errors.Wrap(errBoom, errGetDatacenter)},
+		},
+		"NoClusterName": {
+			reason: "An error should be returned if the CassandraDatacenter has no cluster name",
+			kube:   &test.MockClient{MockGet: datacenter(map[string]any{})},
+			pc:     pc(v1alpha1.CredentialsSourceCassandraDatacenter, nil),
+			want:   want{err: errors.New(errNoClusterName)},
+		},
+		"Datacenter": {
+			reason: "The superuser secret and service should be named like cass-operator names them",
+			kube:   &test.MockClient{MockGet: datacenter(map[string]any{"clusterName": "Demo_Cluster"})},
+			pc:     pc(v1alpha1.CredentialsSourceCassandraDatacenter, nil),
+			want: want{
+				secret: &xpv1.SecretReference{Namespace: "k8ssandra", Name: "democluster-superuser"},
+				svc:    &v1alpha1.ServiceReference{Namespace: "k8ssandra", Name: "democluster-dc1-service", PortName: ptr.To("native")},
+			},
+		},
+		"DatacenterOverrides": {
+			reason: "Explicit superuser secret and datacenter names, and the Service of the ProviderConfig, should take precedence",
+			kube: &test.MockClient{MockGet: datacenter(map[string]any{
+				"clusterName":         "demo",
+				"datacenterName":      "east",
+				"superuserSecretName": "admin",
+			})},
+			pc: pc(v1alpha1.CredentialsSourceCassandraDatacenter, own),
+			want: want{
+				secret: &xpv1.SecretReference{Namespace: "k8ssandra", Name: "admin"},
+				svc:    own,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			secret, svc, err := Source(context.Background(), tc.kube, tc.pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.secret, secret); diff != "" {
+				t.Errorf("\n%s\nSource(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.svc, svc); diff != "" {
+				t.Errorf("\n%s\nSource(...): -want service, +got service:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
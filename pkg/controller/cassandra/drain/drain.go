@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drain lets the statements that are in flight when the provider
+// shuts down complete before their sessions are closed, so that a restart of
+// the provider does not leave schema changes or grants half applied.
+package drain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// DefaultGracePeriod is how long statements in flight are waited for when the
+// provider shuts down, unless configured otherwise.
+const DefaultGracePeriod = 20 * time.Second
+
+// ErrDraining is returned instead of executing statements once the provider
+// is shutting down.
+var ErrDraining = errors.New("provider is shutting down, not executing statements")
+
+// A session executes statements.
+type session interface {
+	Close()
+}
+
+// A Drainer tracks the statements that write data or schema. Once it drains,
+// it admits no more statements, waits for those in flight for at most its
+// grace period and closes their sessions once they completed.
+type Drainer struct {
+	grace time.Duration
+	log   logging.Logger
+
+	// abort is cancelled when the grace period expires, cancelling the
+	// statements still in flight.
+	abort  context.Context
+	cancel context.CancelFunc
+	idle   sync.WaitGroup
+
+	mu       sync.Mutex
+	draining bool
+	inflight int
+	sessions map[session]int
+}
+
+// NewDrainer returns a Drainer that waits for statements in flight for the
+// supplied grace period.
+func NewDrainer(grace time.Duration, log logging.Logger) *Drainer {
+	abort, cancel := context.WithCancel(context.Background())
+	return &Drainer{grace: grace, log: log, abort: abort, cancel: cancel, sessions: map[session]int{}}
+}
+
+// Begin admits a statement of the supplied session, unless the Drainer is
+// draining.
+func (d *Drainer) Begin(ctx context.Context, db *cassandra.CassandraDB) (context.Context, func(), error) {
+	return d.begin(ctx, db)
+}
+
+func (d *Drainer) begin(ctx context.Context, s session) (context.Context, func(), error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return nil, nil, ErrDraining
+	}
+	d.inflight++
+	d.sessions[s]++
+	d.idle.Add(1)
+
+	// The context of a reconcile is cancelled as soon as the provider shuts
+	// down, which would abort the statement. It still ends with the deadline
+	// of the reconcile, or once the grace period expired.
+	sctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	if dl, ok := ctx.Deadline(); ok {
+		sctx, cancel = context.WithDeadline(context.WithoutCancel(ctx), dl)
+	}
+	stop := context.AfterFunc(d.abort, cancel)
+
+	var once sync.Once
+	return sctx, func() {
+		once.Do(func() {
+			stop()
+			cancel()
+			d.end(s)
+		})
+	}, nil
+}
+
+// end records that a statement of the supplied session completed, closing
+// the session if it was its last one and the Drainer is draining.
+func (d *Drainer) end(s session) {
+	defer d.idle.Done()
+	d.mu.Lock()
+	d.inflight--
+	d.sessions[s]--
+	last := d.sessions[s] == 0
+	if last {
+		delete(d.sessions, s)
+	}
+	closing := last && d.draining
+	d.mu.Unlock()
+
+	if closing {
+		s.Close()
+	}
+}
+
+// Drain stops admitting statements and waits for those in flight to
+// complete. Statements still in flight once the grace period expired are
+// cancelled. Their sessions are closed once they completed.
+func (d *Drainer) Drain() {
+	d.mu.Lock()
+	d.draining = true
+	n := d.inflight
+	d.mu.Unlock()
+
+	if n == 0 {
+		return
+	}
+	d.log.Info("Waiting for statements in flight to complete", "statements", n, "grace-period", d.grace.String())
+
+	idle := make(chan struct{})
+	go func() {
+		d.idle.Wait()
+		close(idle)
+	}()
+	t := time.NewTimer(d.grace)
+	defer t.Stop()
+	select {
+	case <-idle:
+		d.log.Info("Statements in flight completed")
+	case <-t.C:
+		d.mu.Lock()
+		n = d.inflight
+		d.mu.Unlock()
+		d.log.Info("Cancelling statements still in flight after grace period", "statements", n)
+		d.cancel()
+	}
+}
+
+// Start drains once the supplied context is done, i.e. once the controller
+// manager shuts down.
+func (d *Drainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	d.Drain()
+	return nil
+}
+
+// NeedLeaderElection returns false, since followers must not wait for the
+// leader election to drain.
+func (d *Drainer) NeedLeaderElection() bool {
+	return false
+}
+
+// Default is the Drainer of the provider.
+var Default = NewDrainer(DefaultGracePeriod, logging.NewNopLogger())
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+type fakeSession struct {
+	closed atomic.Int32
+}
+
+func (s *fakeSession) Close() {
+	s.closed.Add(1)
+}
+
+func TestDrainWaitsForStatements(t *testing.T) {
+	d := NewDrainer(time.Minute, logging.NewNopLogger())
+	s := &fakeSession{}
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, done, err := d.begin(parent, s)
+	if err != nil {
+		t.Fatalf("begin(...): %v", err)
+	}
+	_, done2, err := d.begin(context.Background(), s)
+	if err != nil {
+		t.Fatalf("begin(...): %v", err)
+	}
+
+	// The provider shutting down cancels the reconcile.
+	cancelParent()
+	if ctx.Err() != nil {
+		t.Errorf("statement context: want not cancelled, got %v", ctx.Err())
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		d.Drain()
+		close(drained)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if _, _, err := d.begin(context.Background(), s); !errors.Is(err, ErrDraining) {
+		t.Errorf("begin(...): want %v, got %v", ErrDraining, err)
+	}
+
+	done()
+	if got := s.closed.Load(); got != 0 {
+		t.Errorf("Close() with a statement in flight: want 0 calls, got %d", got)
+	}
+	done2()
+	<-drained
+	if got := s.closed.Load(); got != 1 {
+		t.Errorf("Close() once drained: want 1 call, got %d", got)
+	}
+}
+
+func TestDrainCancelsAfterGracePeriod(t *testing.T) {
+	d := NewDrainer(10*time.Millisecond, logging.NewNopLogger())
+	s := &fakeSession{}
+
+	ctx, done, err := d.begin(context.Background(), s)
+	if err != nil {
+		t.Fatalf("begin(...): %v", err)
+	}
+
+	d.Drain()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("statement context: want cancelled after grace period")
+	}
+
+	done()
+	if got := s.closed.Load(); got != 1 {
+		t.Errorf("Close(): want 1 call, got %d", got)
+	}
+}
+
+func TestBeginKeepsDeadline(t *testing.T) {
+	d := NewDrainer(time.Minute, logging.NewNopLogger())
+	want := time.Now().Add(time.Hour)
+	parent, cancel := context.WithDeadline(context.Background(), want)
+	defer cancel()
+
+	ctx, done, err := d.begin(parent, &fakeSession{})
+	if err != nil {
+		t.Fatalf("begin(...): %v", err)
+	}
+	defer done()
+	if got, ok := ctx.Deadline(); !ok || !got.Equal(want) {
+		t.Errorf("Deadline(): want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+)
+
+// Keyspace returns the fields of a keyspace whose observed values differ from
+// the desired ones. Fields that are not specified, e.g. because their late
+// initialization is disabled, are not compared.
+func Keyspace(observed, desired *v1alpha1.KeyspaceParameters) []Field {
+	var fields []Field
+	// The replication of keyspaces that are replicated to every datacenter
+	// is compared per datacenter instead, by AutoReplication.
+	auto := desired.AutoDatacenterReplication != nil
+	if !auto && desired.ReplicationClass != nil && (observed.ReplicationClass == nil || *observed.ReplicationClass != *desired.ReplicationClass) {
+		fields = append(fields, Field{Name: "replicationClass", Desired: *desired.ReplicationClass, Observed: ptr.Deref(observed.ReplicationClass, "")})
+	}
+	if !auto && desired.ReplicationFactor != nil && (observed.ReplicationFactor == nil || *observed.ReplicationFactor != *desired.ReplicationFactor) {
+		fields = append(fields, Field{Name: "replicationFactor", Desired: value(desired.ReplicationFactor), Observed: value(observed.ReplicationFactor)})
+	}
+	if !auto && desired.TransientReplicas != nil && (observed.TransientReplicas == nil || *observed.TransientReplicas != *desired.TransientReplicas) {
+		fields = append(fields, Field{Name: "transientReplicas", Desired: value(desired.TransientReplicas), Observed: value(observed.TransientReplicas)})
+	}
+	// Durable writes are not reported by all metadata readers.
+	if desired.DurableWrites != nil && observed.DurableWrites != nil && *observed.DurableWrites != *desired.DurableWrites {
+		fields = append(fields, Field{Name: "durableWrites", Desired: value(desired.DurableWrites), Observed: value(observed.DurableWrites)})
+	}
+	// Descriptions are only observed if they are stored.
+	if desired.Description != nil && observed.Description != nil && *observed.Description != *desired.Description {
+		fields = append(fields, Field{Name: "description", Desired: *desired.Description, Observed: *observed.Description})
+	}
+	return fields
+}
+
+// AutoReplication returns the drifted fields of a keyspace with automatic
+// datacenter replication: its class, unless it is replicated per datacenter,
+// or otherwise its replication factor in any datacenter.
+func AutoReplication(desired *v1alpha1.KeyspaceParameters, observed v1alpha1.KeyspaceObservation) []Field {
+	if observed.ReplicationClass != render.NetworkTopologyStrategy {
+		return []Field{{Name: "replicationClass", Desired: render.NetworkTopologyStrategy, Observed: observed.ReplicationClass}}
+	}
+	want := cassandra.FormatReplicationFactor(desired.AutoDatacenterReplication.Factor, ptr.Deref(desired.TransientReplicas, 0))
+	for _, rf := range observed.Replication {
+		if rf != want {
+			return []Field{{Name: "autoDatacenterReplication.factor", Desired: want, Observed: rf}}
+		}
+	}
+	return nil
+}
+
+// Topology describes how the supplied datacenters of the cluster differ from
+// those a keyspace with automatic datacenter replication is observed to
+// replicate to. It returns an empty string if they do not, or if the keyspace
+// is not replicated per datacenter yet.
+func Topology(observed v1alpha1.KeyspaceObservation, datacenters []string) string {
+	if observed.ReplicationClass != render.NetworkTopologyStrategy {
+		return ""
+	}
+	replicated := make(map[string]bool, len(observed.Replication))
+	for dc := range observed.Replication {
+		replicated[dc] = true
+	}
+	var added, removed []string
+	for _, dc := range datacenters {
+		if !replicated[dc] {
+			added = append(added, dc)
+		}
+		delete(replicated, dc)
+	}
+	for dc := range replicated {
+		removed = append(removed, dc)
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return ""
+	}
+	sort.Strings(removed)
+	return fmt.Sprintf("datacenters (added to cluster: [%s], removed from cluster: [%s])", strings.Join(added, ", "), strings.Join(removed, ", "))
+}
+
+// Role returns the privileges and description of a role whose observed values
+// differ from the desired ones. Privileges that are not specified, e.g.
+// because their late initialization is disabled, are not compared.
+func Role(observed, desired *v1alpha1.RoleParameters) []Field {
+	var fields []Field
+	if desired.Privileges.SuperUser != nil && (observed.Privileges.SuperUser == nil || *observed.Privileges.SuperUser != *desired.Privileges.SuperUser) {
+		fields = append(fields, Field{Name: "privileges.superUser", Desired: strconv.FormatBool(*desired.Privileges.SuperUser), Observed: value(observed.Privileges.SuperUser)})
+	}
+	if desired.Privileges.Login != nil && (observed.Privileges.Login == nil || *observed.Privileges.Login != *desired.Privileges.Login) {
+		fields = append(fields, Field{Name: "privileges.login", Desired: strconv.FormatBool(*desired.Privileges.Login), Observed: value(observed.Privileges.Login)})
+	}
+	// Descriptions are only observed if they are stored.
+	if desired.Description != nil && observed.Description != nil && *observed.Description != *desired.Description {
+		fields = append(fields, Field{Name: "description", Desired: *desired.Description, Observed: *observed.Description})
+	}
+	return fields
+}
+
+// Held returns the permissions a role holds on each of the supplied targets,
+// whether granted on the target itself or inherited from its parents, given
+// the permissions granted to it on a resource as recorded in
+// role_permissions.
+func Held(targets []cassandra.Resource, granted func(resource string) (map[string]bool, error)) ([]map[string]bool, error) {
+	// Permissions granted on ALL KEYSPACES, a keyspace or ALL ROLES are
+	// inherited by the resources they enclose, so they need not be granted
+	// again. Targets commonly share parents, which are only read once.
+	read := make(map[string]map[string]bool)
+	held := make([]map[string]bool, len(targets))
+	for i, t := range targets {
+		held[i] = make(map[string]bool)
+		for _, r := range append([]cassandra.Resource{t}, t.Parents()...) {
+			p, ok := read[r.String()]
+			if !ok {
+				var err error
+				if p, err = granted(r.String()); err != nil {
+					return nil, err
+				}
+				read[r.String()] = p
+			}
+			for permission := range p {
+				held[i][permission] = true
+			}
+		}
+	}
+	return held, nil
+}
+
+// Privileges returns the targets of a grant of the supplied privileges on
+// which the permissions held, as returned by Held, lack any of them, and
+// whether any of them is held on any target. There is nothing to grant on no
+// targets, e.g. on the tables of a keyspace that has none yet, so they count
+// as granted.
+func Privileges(privileges []string, targets []cassandra.Resource, held []map[string]bool) ([]Field, bool) {
+	var fields []Field
+	granted := len(targets) == 0
+	for i, permissions := range held {
+		missing := false
+		for _, p := range privileges {
+			if permissions[p] {
+				granted = true
+			} else {
+				missing = true
+			}
+		}
+		if missing {
+			fields = append(fields, Field{Name: "privileges on " + targets[i].CQL(), Desired: strings.Join(privileges, ", "), Observed: formatPermissions(permissions)})
+		}
+	}
+	return fields, granted
+}
+
+// Removed returns the privileges field of a grant if it was last observed to
+// hold privileges that are no longer desired.
+func Removed(privileges, observed []string) []Field {
+	desired := make(map[string]bool, len(privileges))
+	for _, p := range privileges {
+		desired[p] = true
+	}
+	for _, p := range observed {
+		if !desired[p] {
+			return []Field{{Name: "privileges", Desired: strings.Join(privileges, ", "), Observed: strings.Join(observed, ", ")}}
+		}
+	}
+	return nil
+}
+
+// Public returns the fields of a grant that revokes the permissions of the
+// public role, given the targets the public role holds permissions on.
+func Public(targets []cassandra.Resource) []Field {
+	fields := make([]Field, 0, len(targets))
+	for _, t := range targets {
+		fields = append(fields, Field{Name: "permissions of the public role on " + t.CQL()})
+	}
+	return fields
+}
+
+// value formats the supplied optional value for a DriftReport.
+func value[T any](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(*v)
+}
+
+// formatPermissions formats the supplied permissions for a DriftReport.
+func formatPermissions(held map[string]bool) string {
+	p := make([]string, 0, len(held))
+	for permission := range held {
+		p = append(p, permission)
+	}
+	sort.Strings(p)
+	return strings.Join(p, ", ")
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift records the drift the Cassandra controllers observe in the
+// DriftReport of the ProviderConfig of each drifted resource, so that drift
+// can be consumed from a single object rather than the conditions of every
+// managed resource. It also compares the desired and observed state of managed
+// resources, so that the controllers and the bulk drift audit agree on what
+// drifted.
+package drift
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+const (
+	errGetReport    = "cannot get DriftReport"
+	errCreateReport = "cannot create DriftReport"
+	errOwnReport    = "cannot set owner of DriftReport"
+	errUpdateReport = "cannot update DriftReport status"
+	errGetPC        = "cannot get ProviderConfig"
+)
+
+const (
+	// refreshAfter is how long the observation time of persisting drift
+	// is kept before it is refreshed, so that the report is not written
+	// at every poll of every drifted resource.
+	refreshAfter = 5 * time.Minute
+
+	// staleAfter is how long drift that is no longer observed, e.g.
+	// because its resource was deleted, is kept in the report.
+	staleAfter = time.Hour
+)
+
+// A Field of a managed resource that drifted. Its values are empty if they
+// cannot be represented by a single value, e.g. for missing objects.
+type Field struct {
+	Name     string
+	Desired  string
+	Observed string
+}
+
+// Names returns the names of the supplied fields.
+func Names(fields []Field) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// A Recorder records drift in DriftReports.
+type Recorder struct {
+	kube client.Client
+	log  logging.Logger
+	now  func() time.Time
+}
+
+// NewRecorder returns a Recorder that writes DriftReports with the supplied
+// client. Errors are logged rather than returned, since failing to report
+// drift must not stop resources from being reconciled.
+func NewRecorder(kube client.Client, l logging.Logger) *Recorder {
+	return &Recorder{kube: kube, log: l, now: time.Now}
+}
+
+// Record replaces the drift recorded for the supplied managed resource of the
+// supplied kind with the supplied fields. Resources without drifted fields
+// are removed from the report. The report is only written if it changes.
+func (r *Recorder) Record(ctx context.Context, mg resource.Managed, kind string, fields []Field) {
+	ref := mg.GetProviderConfigReference()
+	if r == nil || ref == nil {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return r.record(ctx, ref.Name, kind, mg.GetName(), fields)
+	})
+	if err != nil {
+		r.log.Info("Cannot record drift", "error", err, "kind", kind, "name", mg.GetName(), "providerConfig", ref.Name)
+	}
+}
+
+func (r *Recorder) record(ctx context.Context, pc, kind, name string, fields []Field) error {
+	report := &v1alpha1.DriftReport{}
+	err := r.kube.Get(ctx, types.NamespacedName{Name: pc}, report)
+	if resource.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, errGetReport)
+	}
+	exists := err == nil
+
+	drift, changed := Merge(report.Status.Drift, kind, name, fields, r.now())
+	if !changed {
+		return nil
+	}
+	owned := metav1.GetControllerOf(report) != nil
+	if !owned {
+		// Reports are controlled by their ProviderConfig, so that they are
+		// garbage collected with it.
+		p := &v1alpha1.ProviderConfig{}
+		if err := r.kube.Get(ctx, types.NamespacedName{Name: pc}, p); err != nil {
+			return errors.Wrap(err, errGetPC)
+		}
+		if err := meta.AddControllerReference(report, meta.AsController(meta.TypedReferenceTo(p, v1alpha1.ProviderConfigGroupVersionKind))); err != nil {
+			return errors.Wrap(err, errOwnReport)
+		}
+	}
+	switch {
+	case !exists:
+		report.SetName(pc)
+		if err := r.kube.Create(ctx, report); err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, errCreateReport)
+		}
+		// Another controller may have created it concurrently, in which
+		// case updating its status conflicts and is retried.
+	case !owned:
+		// Reports created before they were owned.
+		if err := r.kube.Update(ctx, report); err != nil {
+			return errors.Wrap(err, errOwnReport)
+		}
+	}
+	report.Status.Drift = drift
+	return errors.Wrap(r.kube.Status().Update(ctx, report), errUpdateReport)
+}
+
+// Merge replaces the entries of the named resource of the supplied kind with
+// the supplied fields, keeping when each drift was first observed. Entries
+// that were not observed for a while are removed. It returns the entries
+// sorted by kind, name and field, and whether they changed in a way worth
+// writing.
+func Merge(entries []v1alpha1.DriftEntry, kind, name string, fields []Field, now time.Time) ([]v1alpha1.DriftEntry, bool) {
+	t := metav1.NewTime(now)
+	previous := map[string]v1alpha1.DriftEntry{}
+	merged := make([]v1alpha1.DriftEntry, 0, len(entries)+len(fields))
+	changed := false
+	for _, e := range entries {
+		switch {
+		case e.Kind == kind && e.Name == name:
+			previous[e.Field] = e
+		case now.Sub(e.LastObservedTime.Time) >= staleAfter:
+			changed = true
+		default:
+			merged = append(merged, e)
+		}
+	}
+
+	for _, f := range fields {
+		e := v1alpha1.DriftEntry{Kind: kind, Name: name, Field: f.Name, Desired: f.Desired, Observed: f.Observed, FirstObservedTime: t, LastObservedTime: t}
+		p, ok := previous[f.Name]
+		delete(previous, f.Name)
+		switch {
+		case !ok || p.Desired != f.Desired || p.Observed != f.Observed:
+			changed = true
+		case now.Sub(p.LastObservedTime.Time) >= refreshAfter:
+			e.FirstObservedTime = p.FirstObservedTime
+			changed = true
+		default:
+			e = p
+		}
+		merged = append(merged, e)
+	}
+	// Fields that are no longer drifted.
+	if len(previous) > 0 {
+		changed = true
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Field < b.Field
+	})
+	return merged, changed
+}
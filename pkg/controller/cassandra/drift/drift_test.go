@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	at := func(ago time.Duration) metav1.Time { return metav1.NewTime(now.Add(-ago)) }
+	entry := func(kind, name, field string, first, last time.Duration) v1alpha1.DriftEntry {
+		return v1alpha1.DriftEntry{Kind: kind, Name: name, Field: field, Desired: "3", Observed: "1", FirstObservedTime: at(first), LastObservedTime: at(last)}
+	}
+
+	type want struct {
+		entries []v1alpha1.DriftEntry
+		changed bool
+	}
+	cases := map[string]struct {
+		reason  string
+		entries []v1alpha1.DriftEntry
+		fields  []Field
+		want    want
+	}{
+		"InSync": {
+			reason: "Resources that are in sync and were not drifted should not change the report",
+			want:   want{entries: []v1alpha1.DriftEntry{}},
+		},
+		"Drifted": {
+			reason:  "New drift should be added in order",
+			entries: []v1alpha1.DriftEntry{entry("Role", "app", "login", time.Minute, time.Minute)},
+			fields:  []Field{{Name: "replicationFactor", Desired: "3", Observed: "1"}},
+			want: want{
+				entries: []v1alpha1.DriftEntry{
+					entry("Keyspace", "shop", "replicationFactor", 0, 0),
+					entry("Role", "app", "login", time.Minute, time.Minute),
+				},
+				changed: true,
+			},
+		},
+		"Persisting": {
+			reason:  "Drift that was observed recently should not change the report",
+			entries: []v1alpha1.DriftEntry{entry("Keyspace", "shop", "replicationFactor", time.Hour, time.Minute)},
+			fields:  []Field{{Name: "replicationFactor", Desired: "3", Observed: "1"}},
+			want: want{
+				entries: []v1alpha1.DriftEntry{entry("Keyspace", "shop", "replicationFactor", time.Hour, time.Minute)},
+			},
+		},
+		"Refreshed": {
+			reason:  "Drift that persists should be refreshed every few minutes, keeping when it was first observed",
+			entries: []v1alpha1.DriftEntry{entry("Keyspace", "shop", "replicationFactor", time.Hour, 10*time.Minute)},
+			fields:  []Field{{Name: "replicationFactor", Desired: "3", Observed: "1"}},
+			want: want{
+				entries: []v1alpha1.DriftEntry{entry("Keyspace", "shop", "replicationFactor", time.Hour, 0)},
+				changed: true,
+			},
+		},
+		"Resolved": {
+			reason:  "Fields that are in sync again should be removed",
+			entries: []v1alpha1.DriftEntry{entry("Keyspace", "shop", "replicationFactor", time.Hour, time.Minute)},
+			want:    want{entries: []v1alpha1.DriftEntry{}, changed: true},
+		},
+		"Stale": {
+			reason:  "Drift of other resources that was not observed for an hour should be removed",
+			entries: []v1alpha1.DriftEntry{entry("Role", "gone", "login", 2*time.Hour, time.Hour)},
+			want:    want{entries: []v1alpha1.DriftEntry{}, changed: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			entries, changed := Merge(tc.entries, "Keyspace", "shop", tc.fields, now)
+			got := want{entries: entries, changed: changed}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nMerge(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	owner := []metav1.OwnerReference{{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               v1alpha1.ProviderConfigKind,
+		Name:               "cassandra",
+		UID:                "pc-uid",
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(true),
+	}}
+	get := func(existing *v1alpha1.DriftReport) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ProviderConfig:
+				o.SetName(key.Name)
+				o.SetUID("pc-uid")
+			case *v1alpha1.DriftReport:
+				if existing == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				existing.DeepCopyInto(o)
+			}
+			return nil
+		}
+	}
+	existing := func(owners []metav1.OwnerReference) *v1alpha1.DriftReport {
+		r := &v1alpha1.DriftReport{}
+		r.SetName("cassandra")
+		r.SetOwnerReferences(owners)
+		return r
+	}
+
+	cases := map[string]struct {
+		reason   string
+		existing *v1alpha1.DriftReport
+	}{
+		"Created": {
+			reason: "A new DriftReport should be controlled by its ProviderConfig.",
+		},
+		"NotOwned": {
+			reason:   "An existing DriftReport without an owner should be updated to be controlled by its ProviderConfig.",
+			existing: existing(nil),
+		},
+		"Owned": {
+			reason:   "An existing DriftReport controlled by its ProviderConfig should keep its owner.",
+			existing: existing(owner),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []metav1.OwnerReference
+			written := func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				got = obj.GetOwnerReferences()
+				return nil
+			}
+			kube := &test.MockClient{
+				MockGet:    get(tc.existing),
+				MockCreate: written,
+				MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					return written(ctx, obj)
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					got = obj.GetOwnerReferences()
+					return nil
+				},
+			}
+			r := NewRecorder(kube, logging.NewNopLogger())
+			mg := &v1alpha1.Keyspace{}
+			mg.SetName("shop")
+			mg.SetProviderConfigReference(&xpv1.Reference{Name: "cassandra"})
+			r.Record(context.Background(), mg, v1alpha1.KeyspaceKind, []Field{{Name: "replicationFactor", Desired: "3", Observed: "1"}})
+			if diff := cmp.Diff(owner, got); diff != "" {
+				t.Errorf("\n%s\nRecord(...): -want owners, +got owners:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package driftreport periodically audits all Cassandra managed resources
+// against the state of their clusters in bulk, independently of the reconcile
+// cadence of the individual resources.
+package driftreport
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drift"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+)
+
+const (
+	errListPCs      = "cannot list ProviderConfigs"
+	errListManaged  = "cannot list managed resources"
+	errConnect      = "cannot connect to cluster"
+	errSnapshot     = "cannot read cluster state"
+	errUpdateStatus = "cannot update ProviderConfig status"
+	maxOutOfSync    = 10
+	stateInSync     = "in_sync"
+	stateMissing    = "missing"
+	stateDrifted    = "drifted"
+	locatorPrefix   = "org.apache.cassandra.locator."
+
+	// listPageSize is how many roles or permissions are read per page.
+	listPageSize = 1000
+)
+
+// audited is the number of managed resources per ProviderConfig, kind and
+// state observed by the last audit.
+var audited = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cassandra_audited_resources",
+	Help: "Number of Cassandra managed resources per ProviderConfig, kind and audited state (in_sync, missing or drifted).",
+}, []string{"provider_config", "kind", "state"})
+
+func init() {
+	metrics.Registry.MustRegister(audited)
+}
+
+// A Reporter audits the managed resources of every ProviderConfig at a fixed
+// interval and publishes a summary to the ProviderConfig status and metrics.
+// ProviderConfigs are audited one at a time, and each audit reads the cluster
+// state with a handful of bulk queries, to limit the load on the clusters.
+type Reporter struct {
+	kube      client.Client
+	log       logging.Logger
+	interval  time.Duration
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+}
+
+// NewReporter returns a Reporter that audits every interval.
+func NewReporter(kube client.Client, l logging.Logger, interval time.Duration) *Reporter {
+	return &Reporter{kube: kube, log: l, interval: interval, newClient: cassandra.New}
+}
+
+// NeedLeaderElection returns true, so that only the leader replica audits and
+// queries the clusters.
+func (r *Reporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start audits every interval until the supplied context is done.
+func (r *Reporter) Start(ctx context.Context) error {
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := r.AuditAll(ctx); err != nil {
+				r.log.Info("Cannot audit Cassandra managed resources", "error", err)
+			}
+		}
+	}
+}
+
+// AuditAll audits the managed resources of every ProviderConfig.
+func (r *Reporter) AuditAll(ctx context.Context) error {
+	pcs := &v1alpha1.ProviderConfigList{}
+	if err := r.kube.List(ctx, pcs); err != nil {
+		return errors.Wrap(err, errListPCs)
+	}
+
+	mrs, err := r.managed(ctx)
+	if err != nil {
+		return err
+	}
+
+	audited.Reset()
+	for i := range pcs.Items {
+		pc := &pcs.Items[i]
+		report := &v1alpha1.DriftAudit{LastAuditTime: metav1.Now()}
+
+		s, err := r.snapshot(ctx, pc)
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Resources = Compare(s, mrs.forProviderConfig(pc.GetName()))
+		}
+
+		for _, sum := range report.Resources {
+			audited.WithLabelValues(pc.GetName(), sum.Kind, stateInSync).Set(float64(sum.Total - sum.Missing - sum.Drifted))
+			audited.WithLabelValues(pc.GetName(), sum.Kind, stateMissing).Set(float64(sum.Missing))
+			audited.WithLabelValues(pc.GetName(), sum.Kind, stateDrifted).Set(float64(sum.Drifted))
+		}
+
+		pc.Status.DriftReport = report
+		if err := r.kube.Status().Update(ctx, pc); err != nil {
+			r.log.Info(errUpdateStatus, "name", pc.GetName(), "error", err)
+		}
+	}
+	return nil
+}
+
+// Managed are the managed resources that are audited.
+type Managed struct {
+	Keyspaces []v1alpha1.Keyspace
+	Roles     []v1alpha1.Role
+	Grants    []v1alpha1.Grant
+}
+
+func (r *Reporter) managed(ctx context.Context) (*Managed, error) {
+	ks := &v1alpha1.KeyspaceList{}
+	roles := &v1alpha1.RoleList{}
+	grants := &v1alpha1.GrantList{}
+	for _, l := range []client.ObjectList{ks, roles, grants} {
+		if err := r.kube.List(ctx, l); err != nil {
+			return nil, errors.Wrap(err, errListManaged)
+		}
+	}
+	return &Managed{Keyspaces: ks.Items, Roles: roles.Items, Grants: grants.Items}, nil
+}
+
+func (m *Managed) forProviderConfig(name string) *Managed {
+	uses := func(mg resource.Managed) bool {
+		ref := mg.GetProviderConfigReference()
+		return ref != nil && ref.Name == name
+	}
+
+	out := &Managed{}
+	for i := range m.Keyspaces {
+		if uses(&m.Keyspaces[i]) {
+			out.Keyspaces = append(out.Keyspaces, m.Keyspaces[i])
+		}
+	}
+	for i := range m.Roles {
+		if uses(&m.Roles[i]) {
+			out.Roles = append(out.Roles, m.Roles[i])
+		}
+	}
+	for i := range m.Grants {
+		if uses(&m.Grants[i]) {
+			out.Grants = append(out.Grants, m.Grants[i])
+		}
+	}
+	return out
+}
+
+// A Snapshot is the state of a cluster relevant to its managed resources.
+type Snapshot struct {
+	// Keyspaces by name.
+	Keyspaces map[string]v1alpha1.KeyspaceObservation
+	// Tables by keyspace name.
+	Tables map[string][]string
+	// Roles by name.
+	Roles map[string]v1alpha1.RoleObservation
+	// Permissions by role name and resource.
+	Permissions map[string]map[string]map[string]bool
+	// Datacenters of the cluster.
+	Datacenters []string
+}
+
+func (r *Reporter) snapshot(ctx context.Context, pc *v1alpha1.ProviderConfig) (*Snapshot, error) {
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, r.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := r.newClient(d.Credentials, "", cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithLogger(r.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	snap, err := read(ctx, db)
+	return snap, errors.Wrap(err, errSnapshot)
+}
+
+func read(ctx context.Context, db *cassandra.CassandraDB) (*Snapshot, error) { //nolint:gocyclo // Five similar bulk queries.
+	s := &Snapshot{
+		Keyspaces:   map[string]v1alpha1.KeyspaceObservation{},
+		Tables:      map[string][]string{},
+		Roles:       map[string]v1alpha1.RoleObservation{},
+		Permissions: map[string]map[string]map[string]bool{},
+	}
+
+	iter, err := db.Query(ctx, "SELECT keyspace_name, replication, durable_writes FROM system_schema.keyspaces")
+	if err != nil {
+		return nil, err
+	}
+	var name string
+	var replication map[string]string
+	var durable bool
+	for iter.Scan(&name, &replication, &durable) {
+		rf, transient, _ := cassandra.ParseReplicationFactor(replication["replication_factor"])
+		o := v1alpha1.KeyspaceObservation{
+			ReplicationClass:  strings.TrimPrefix(replication["class"], locatorPrefix),
+			ReplicationFactor: rf,
+			TransientReplicas: transient,
+			Replication:       map[string]string{},
+			DurableWrites:     ptr.To(durable),
+		}
+		for k, v := range replication {
+			if k != "class" {
+				o.Replication[k] = v
+			}
+		}
+		s.Keyspaces[name] = o
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	if s.Datacenters, err = db.Datacenters(ctx); err != nil {
+		return nil, err
+	}
+
+	iter, err = db.Query(ctx, "SELECT keyspace_name, table_name FROM system_schema.tables")
+	if err != nil {
+		return nil, err
+	}
+	var table string
+	for iter.Scan(&name, &table) {
+		s.Tables[name] = append(s.Tables[name], table)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	var page []byte
+	for {
+		roles, next, err := db.ListRoles(ctx, listPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roles {
+			s.Roles[r.Name] = v1alpha1.RoleObservation{SuperUser: ptr.To(r.SuperUser), Login: ptr.To(r.Login)}
+		}
+		if page = next; len(page) == 0 {
+			break
+		}
+	}
+
+	for {
+		permissions, next, err := db.ListPermissions(ctx, listPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range permissions {
+			if s.Permissions[p.Role] == nil {
+				s.Permissions[p.Role] = map[string]map[string]bool{}
+			}
+			s.Permissions[p.Role][p.Resource] = map[string]bool{}
+			for _, perm := range p.Permissions {
+				s.Permissions[p.Role][p.Resource][perm] = true
+			}
+		}
+		if page = next; len(page) == 0 {
+			break
+		}
+	}
+	return s, nil
+}
+
+// Compare summarizes how the supplied managed resources differ from the
+// supplied snapshot of their cluster, comparing them like their controllers
+// do. Settings that are not specified, e.g. because they were not late
+// initialized yet, are not compared.
+func Compare(s *Snapshot, m *Managed) []v1alpha1.DriftSummary {
+	ks := v1alpha1.DriftSummary{Kind: v1alpha1.KeyspaceKind}
+	for i := range m.Keyspaces {
+		cr := &m.Keyspaces[i]
+		o, ok := s.Keyspaces[meta.GetExternalName(cr)]
+		add(&ks, cr.GetName(), ok, len(keyspaceDrift(s, o, &cr.Spec.ForProvider)) > 0)
+	}
+
+	roles := v1alpha1.DriftSummary{Kind: v1alpha1.RoleKind}
+	for i := range m.Roles {
+		cr := &m.Roles[i]
+		o, ok := s.Roles[meta.GetExternalName(cr)]
+		observed := &v1alpha1.RoleParameters{Privileges: v1alpha1.RolePrivilege{SuperUser: o.SuperUser, Login: o.Login}}
+		add(&roles, cr.GetName(), ok, len(drift.Role(observed, &cr.Spec.ForProvider)) > 0)
+	}
+
+	grants := v1alpha1.DriftSummary{Kind: v1alpha1.GrantKind}
+	for i := range m.Grants {
+		cr := &m.Grants[i]
+		fields, granted, ok := grantDrift(s, cr)
+		if !ok {
+			// References are not resolved yet.
+			add(&grants, cr.GetName(), false, false)
+			continue
+		}
+		add(&grants, cr.GetName(), granted, len(fields) > 0)
+	}
+
+	return []v1alpha1.DriftSummary{ks, roles, grants}
+}
+
+// keyspaceDrift returns the drifted fields of the supplied keyspace, given its
+// observation in the supplied snapshot.
+func keyspaceDrift(s *Snapshot, o v1alpha1.KeyspaceObservation, p *v1alpha1.KeyspaceParameters) []drift.Field {
+	observed := &v1alpha1.KeyspaceParameters{
+		ReplicationClass:  ptr.To(o.ReplicationClass),
+		ReplicationFactor: ptr.To(o.ReplicationFactor),
+		TransientReplicas: ptr.To(o.TransientReplicas),
+		DurableWrites:     o.DurableWrites,
+	}
+	fields := drift.Keyspace(observed, p)
+	if auto := p.AutoDatacenterReplication; auto != nil {
+		fields = append(fields, drift.AutoReplication(p, o)...)
+		if t := drift.Topology(o, s.Datacenters); t != "" && auto.Policy() != v1alpha1.TopologyIgnore {
+			fields = append(fields, drift.Field{Name: t})
+		}
+	}
+	return fields
+}
+
+// grantDrift returns the drifted fields of the supplied grant in the supplied
+// snapshot and whether any of its privileges is granted, or false if its
+// references are not resolved yet. Privileges are granted if they are
+// inherited from the parents of its targets, e.g. its keyspace.
+func grantDrift(s *Snapshot, cr *v1alpha1.Grant) ([]drift.Field, bool, bool) {
+	p := cr.Spec.ForProvider
+	if p.Role == nil {
+		return nil, false, false
+	}
+	targets, err := render.GrantTargets(cr)
+	if err != nil {
+		return nil, false, false
+	}
+	if targets == nil {
+		for _, t := range s.Tables[*p.Keyspace] {
+			targets = append(targets, cassandra.Resource{Kind: cassandra.ResourceData, Keyspace: *p.Keyspace, Table: t})
+		}
+	}
+
+	privileges := p.Privileges.ToCQL()
+	fields, granted := drift.Privileges(privileges, targets, held(s, *p.Role, targets))
+	fields = append(fields, drift.Removed(privileges, cr.Status.AtProvider.Privileges)...)
+	if ptr.Deref(p.RevokePublic, false) && p.PublicRole != nil && *p.PublicRole != *p.Role {
+		var public []cassandra.Resource
+		for i, permissions := range held(s, *p.PublicRole, targets) {
+			if len(permissions) > 0 {
+				public = append(public, targets[i])
+			}
+		}
+		fields = append(fields, drift.Public(public)...)
+	}
+	return fields, granted, true
+}
+
+// held returns the permissions the supplied role holds on each of the supplied
+// targets in the supplied snapshot.
+func held(s *Snapshot, role string, targets []cassandra.Resource) []map[string]bool {
+	// Reading the snapshot never fails.
+	h, _ := drift.Held(targets, func(resource string) (map[string]bool, error) {
+		return s.Permissions[role][resource], nil
+	})
+	return h
+}
+
+// add counts a managed resource towards the supplied summary.
+func add(s *v1alpha1.DriftSummary, name string, exists, drifted bool) {
+	s.Total++
+	switch {
+	case !exists:
+		s.Missing++
+	case drifted:
+		s.Drifted++
+	default:
+		return
+	}
+	if len(s.OutOfSync) < maxOutOfSync {
+		s.OutOfSync = append(s.OutOfSync, name)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driftreport
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestCompare(t *testing.T) {
+	snap := &Snapshot{
+		Keyspaces: map[string]v1alpha1.KeyspaceObservation{
+			"shop":  {ReplicationClass: "SimpleStrategy", ReplicationFactor: 3, DurableWrites: ptr.To(true)},
+			"blogs": {ReplicationClass: "SimpleStrategy", ReplicationFactor: 1, DurableWrites: ptr.To(true)},
+			// Replicated to dc1, but the cluster also has dc2.
+			"events":  {ReplicationClass: "NetworkTopologyStrategy", Replication: map[string]string{"dc1": "3"}},
+			"metrics": {ReplicationClass: "NetworkTopologyStrategy", Replication: map[string]string{"dc1": "3"}},
+		},
+		Tables: map[string][]string{
+			"shop": {"orders", "items"},
+		},
+		Roles: map[string]v1alpha1.RoleObservation{
+			"app":   {SuperUser: ptr.To(false), Login: ptr.To(true)},
+			"admin": {SuperUser: ptr.To(false), Login: ptr.To(true)},
+		},
+		Permissions: map[string]map[string]map[string]bool{
+			"app": {
+				"data/shop":        {"SELECT": true},
+				"data/shop/orders": {"SELECT": true, "MODIFY": true},
+				"roles/reader":     {"AUTHORIZE": true},
+			},
+		},
+		Datacenters: []string{"dc1", "dc2"},
+	}
+
+	keyspace := func(name string, rf int) v1alpha1.Keyspace {
+		return v1alpha1.Keyspace{
+			ObjectMeta: v1.ObjectMeta{Name: name, Annotations: map[string]string{meta.AnnotationKeyExternalName: name}},
+			Spec:       v1alpha1.KeyspaceSpec{ForProvider: v1alpha1.KeyspaceParameters{ReplicationFactor: ptr.To(rf)}},
+		}
+	}
+	auto := func(name, policy string) v1alpha1.Keyspace {
+		k := keyspace(name, 0)
+		k.Spec.ForProvider.ReplicationFactor = nil
+		k.Spec.ForProvider.AutoDatacenterReplication = &v1alpha1.AutoDatacenterReplication{Factor: 3, OnTopologyChange: ptr.To(policy)}
+		return k
+	}
+	role := func(name string, superUser bool) v1alpha1.Role {
+		return v1alpha1.Role{
+			ObjectMeta: v1.ObjectMeta{Name: name, Annotations: map[string]string{meta.AnnotationKeyExternalName: name}},
+			Spec:       v1alpha1.RoleSpec{ForProvider: v1alpha1.RoleParameters{Privileges: v1alpha1.RolePrivilege{SuperUser: ptr.To(superUser)}}},
+		}
+	}
+	grant := func(name string, tables bool, privileges ...v1alpha1.GrantPrivilege) v1alpha1.Grant {
+		return v1alpha1.Grant{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{
+				Role:                              ptr.To("app"),
+				Keyspace:                          ptr.To("shop"),
+				Privileges:                        privileges,
+				ApplyToExistingTablesIndividually: ptr.To(tables),
+			}},
+		}
+	}
+
+	m := &Managed{
+		Keyspaces: []v1alpha1.Keyspace{
+			keyspace("shop", 3), keyspace("blogs", 3), keyspace("gone", 1),
+			auto("events", v1alpha1.TopologyReport), auto("metrics", v1alpha1.TopologyIgnore),
+		},
+		Roles: []v1alpha1.Role{role("app", false), role("admin", true), role("gone", false)},
+		Grants: []v1alpha1.Grant{
+			grant("read", false, "SELECT"),
+			grant("write", false, "SELECT", "MODIFY"),
+			// SELECT is inherited from the keyspace, MODIFY is only
+			// granted on one of its tables.
+			grant("tables", true, "SELECT"),
+			grant("modify", true, "MODIFY"),
+			grant("none", false, "DROP"),
+			{ObjectMeta: v1.ObjectMeta{Name: "unresolved"}},
+			{
+				ObjectMeta: v1.ObjectMeta{Name: "authorize"},
+				Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{
+					Role: ptr.To("app"), OnRole: ptr.To("reader"), Privileges: v1alpha1.GrantPrivileges{"AUTHORIZE"},
+				}},
+			},
+		},
+	}
+
+	want := []v1alpha1.DriftSummary{
+		{Kind: v1alpha1.KeyspaceKind, Total: 5, Missing: 1, Drifted: 2, OutOfSync: []string{"blogs", "gone", "events"}},
+		{Kind: v1alpha1.RoleKind, Total: 3, Missing: 1, Drifted: 1, OutOfSync: []string{"admin", "gone"}},
+		{Kind: v1alpha1.GrantKind, Total: 7, Missing: 2, Drifted: 2, OutOfSync: []string{"write", "modify", "none", "unresolved"}},
+	}
+	if diff := cmp.Diff(want, Compare(snap, m)); diff != "" {
+		t.Errorf("\nCompare(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effectiveaccess
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/credentials"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/dedup"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/events"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/profile"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/recovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errConnect            = "cannot connect to cluster"
+	errNotEffectiveAccess = "managed resource is not an EffectiveAccess custom resource"
+	errSelectIdentity     = "cannot select cluster identity"
+	errNoRole             = "role is not resolved"
+	errSelectAccess       = "cannot select effective access"
+	errRoleNotFound       = "role not found"
+	maxConcurrency        = 5
+)
+
+// Setup adds a controller that reconciles EffectiveAccess managed resources.
+func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.EffectiveAccessGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	l := o.Logger.WithValues("controller", name)
+	rec := events.NewRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), mgr.GetClient())
+	f := dedup.NewFilter(dedup.DefaultWindow)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.EffectiveAccessGroupVersionKind),
+		managed.WithExternalConnecter(recovery.NewConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New, log: dedup.NewLogger(l, f)}, v1alpha1.EffectiveAccessKind)),
+		managed.WithLogger(dedup.NewLogger(l, f)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(dedup.NewRecorder(rec, f)))
+
+	// Resources are reconciled as soon as the credentials of their
+	// ProviderConfig change rather than at the next poll.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.EffectiveAccess{}).
+		Watches(&corev1.Secret{}, credentials.EnqueueUsers(mgr.GetClient(), func() resource.ManagedList { return &v1alpha1.EffectiveAccessList{} }, l), builder.WithPredicates(secrets.Changed())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+	log       logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.EffectiveAccess)
+	if !ok {
+		return nil, errors.New(errNotEffectiveAccess)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, c.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := c.newClient(d.Credentials, "", cassandra.WithSerialConsistency(pc.Spec.DefaultConsistencySerial), cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithMaxPreparedStatements(pc.Spec.MaxPreparedStatements), cassandra.WithLogger(c.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
+	}
+	db.SetExecutionProfiles(profile.Convert(pc.Spec.ExecutionProfiles))
+	db.SetLatencyObserver(latency.Default.Observer(pc.GetName(), v1alpha1.EffectiveAccessKind, cr.GetName()))
+	db.SetDrainer(drain.Default)
+	return &external{db: db}, nil
+}
+
+// external never changes the cluster: the access of a role is only
+// observed, and there is nothing to create, update or delete.
+type external struct {
+	db *cassandra.CassandraDB
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.EffectiveAccess)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEffectiveAccess)
+	}
+
+	// Deleting an EffectiveAccess leaves its role untouched, so it is gone
+	// as soon as it is deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Role == nil {
+		return managed.ExternalObservation{}, errors.New(errNoRole)
+	}
+
+	a, exists, err := c.db.EffectiveAccess(ctx, *cr.Spec.ForProvider.Role)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectAccess)
+	}
+	if !exists {
+		// The role cannot be created here, so report it rather than
+		// pretend that the access is missing.
+		msg := fmt.Sprintf("%s: %q", errRoleNotFound, *cr.Spec.ForProvider.Role)
+		cr.SetConditions(v1alpha1.RoleNotFound(msg), xpv1.Unavailable())
+		return managed.ExternalObservation{}, errors.New(msg)
+	}
+
+	cr.Status.AtProvider.Roles = a.Roles
+	cr.Status.AtProvider.SuperUser = ptr.To(a.SuperUser)
+	cr.Status.AtProvider.Permissions = make([]v1alpha1.EffectivePermission, len(a.Permissions))
+	for i, p := range a.Permissions {
+		cr.Status.AtProvider.Permissions[i] = v1alpha1.EffectivePermission{Resource: p.Resource, Permissions: p.Permissions, GrantedTo: p.GrantedTo}
+	}
+
+	cluster, dc, err := c.db.Identity(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectIdentity)
+	}
+	cr.Status.AtProvider.ClusterIdentity = v1alpha1.ClusterIdentity{ClusterName: cluster, Datacenter: dc}
+
+	if cr.GetCondition(v1alpha1.TypeDependencies).Status != corev1.ConditionUnknown {
+		cr.SetConditions(v1alpha1.DependenciesFound())
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effectiveaccess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	cr := &v1alpha1.EffectiveAccess{Spec: v1alpha1.EffectiveAccessSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}}}}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		usage  resource.Tracker
+		mg     resource.Managed
+		want   error
+	}{
+		"NotEffectiveAccess": {
+			reason: "Connecting for a managed resource that is not an EffectiveAccess should return an error.",
+			mg:     &v1alpha1.Role{},
+			want:   errors.New(errNotEffectiveAccess),
+		},
+		"ErrTrackUsage": {
+			reason: "Errors tracking the usage of the ProviderConfig should be returned.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "A missing ProviderConfig should return an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errGetPC),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			usage := tc.usage
+			if usage == nil {
+				usage = resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil })
+			}
+			c := &connector{kube: tc.kube, usage: usage}
+			_, err := c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
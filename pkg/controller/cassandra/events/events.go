@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events annotates the events of Cassandra managed resources with the
+// event annotations of their ProviderConfig, so that they can be routed to
+// the team that owns the resources.
+package events
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+// A Recorder adds the event annotations of the ProviderConfig of a managed
+// resource to the events recorded for it.
+type Recorder struct {
+	rec  event.Recorder
+	kube client.Reader
+}
+
+// NewRecorder returns a Recorder that records to r, reading ProviderConfigs
+// using the supplied client.
+func NewRecorder(r event.Recorder, kube client.Reader) *Recorder {
+	return &Recorder{rec: r, kube: kube}
+}
+
+// Event records the supplied event. Events are recorded without the
+// annotations of the ProviderConfig if it can't be read, e.g. because the
+// event reports that it is missing.
+func (r *Recorder) Event(obj runtime.Object, e event.Event) {
+	kv := r.annotations(obj)
+	if len(kv) == 0 {
+		r.rec.Event(obj, e)
+		return
+	}
+	r.rec.WithAnnotations(kv...).Event(obj, e)
+}
+
+// WithAnnotations returns a Recorder that includes the supplied annotations
+// with all recorded events, in addition to those of ProviderConfigs.
+func (r *Recorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return &Recorder{rec: r.rec.WithAnnotations(keysAndValues...), kube: r.kube}
+}
+
+// annotations returns the event annotations of the ProviderConfig of the
+// supplied object as keys and values, sorted by key.
+func (r *Recorder) annotations(obj runtime.Object) []string {
+	mg, ok := obj.(resource.Managed)
+	if !ok || mg.GetProviderConfigReference() == nil {
+		return nil
+	}
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(context.Background(), types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(pc.Spec.EventAnnotations))
+	for k := range pc.Spec.EventAnnotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kv := make([]string, 0, 2*len(keys))
+	for _, k := range keys {
+		kv = append(kv, k, pc.Spec.EventAnnotations[k])
+	}
+	return kv
+}
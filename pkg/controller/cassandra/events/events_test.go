@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+// recorder records the annotations events were recorded with.
+type recorder struct {
+	annotations map[string]string
+	recorded    *[]map[string]string
+}
+
+func (r *recorder) Event(_ runtime.Object, _ event.Event) {
+	*r.recorded = append(*r.recorded, r.annotations)
+}
+
+func (r *recorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	a := map[string]string{}
+	for k, v := range r.annotations {
+		a[k] = v
+	}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		a[keysAndValues[i]] = keysAndValues[i+1]
+	}
+	return &recorder{annotations: a, recorded: r.recorded}
+}
+
+func TestEvent(t *testing.T) {
+	pc := func(annotations map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*v1alpha1.ProviderConfig).Spec.EventAnnotations = annotations
+			return nil
+		}
+	}
+	keyspace := &v1alpha1.Keyspace{Spec: v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "team-a"}}}}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		obj    runtime.Object
+		want   []map[string]string
+	}{
+		"Annotated": {
+			reason: "Events should carry the event annotations of the ProviderConfig.",
+			kube:   &test.MockClient{MockGet: pc(map[string]string{"team": "a"})},
+			obj:    keyspace,
+			want:   []map[string]string{{"team": "a"}},
+		},
+		"NoAnnotations": {
+			reason: "Events of ProviderConfigs without event annotations should be recorded as they are.",
+			kube:   &test.MockClient{MockGet: pc(nil)},
+			obj:    keyspace,
+			want:   []map[string]string{nil},
+		},
+		"NoProviderConfig": {
+			reason: "Events should be recorded without annotations if the ProviderConfig can't be read.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			obj:    keyspace,
+			want:   []map[string]string{nil},
+		},
+		"NotManaged": {
+			reason: "Events of objects that are not managed resources should be recorded as they are.",
+			obj:    &v1alpha1.ProviderConfig{},
+			want:   []map[string]string{nil},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []map[string]string
+			NewRecorder(&recorder{recorded: &got}, tc.kube).Event(tc.obj, event.Normal("Test", "test"))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEvent(...): -want annotations, +got annotations:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export exports the schema of the keyspaces of Cassandra clusters,
+// whether they are managed by the provider or not, e.g. to keep snapshots of
+// the schema for disaster recovery.
+package export
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+)
+
+const (
+	errGetPC         = "cannot get ProviderConfig"
+	errConnect       = "cannot connect to cluster"
+	errListKeyspaces = "cannot list keyspaces"
+	errDescribe      = "cannot describe keyspace"
+	errNoKeyspace    = "keyspace does not exist"
+	locatorPrefix    = "org.apache.cassandra.locator."
+)
+
+// SchemaKey is the key of ConfigMaps the schema of a keyspace is kept under.
+const SchemaKey = "schema.cql"
+
+// LabelKeyspace labels ConfigMaps with the keyspace whose schema they keep.
+const LabelKeyspace = "cassandra.cql.crossplane.io/keyspace"
+
+// A Schema is the schema of a keyspace.
+type Schema struct {
+	// Keyspace the schema is of.
+	Keyspace string
+
+	// Statements that recreate the keyspace and its schema.
+	Statements []string
+
+	// Described is true if the statements were described by the server.
+	// Otherwise they were built from system_schema and lack table options,
+	// functions, aggregates and materialized views.
+	Described bool
+}
+
+// CQL returns the statements of the schema as a CQL script.
+func (s Schema) CQL() string {
+	b := &strings.Builder{}
+	for _, stmt := range s.Statements {
+		b.WriteString(stmt)
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
+
+// ConfigMap returns a ConfigMap in the supplied namespace that keeps the
+// schema. It is named after the keyspace.
+func (s Schema) ConfigMap(namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "cassandra-schema-" + strings.ReplaceAll(strings.ToLower(s.Keyspace), "_", "-"),
+			Labels:    map[string]string{LabelKeyspace: s.Keyspace},
+		},
+		Data: map[string]string{SchemaKey: s.CQL()},
+	}
+}
+
+// An Exporter exports the schema of the keyspaces of the clusters of
+// ProviderConfigs.
+type Exporter struct {
+	kube      client.Client
+	log       logging.Logger
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+}
+
+// NewExporter returns an Exporter that reads ProviderConfigs and their
+// Secrets with the supplied client.
+func NewExporter(kube client.Client, l logging.Logger) *Exporter {
+	return &Exporter{kube: kube, log: l, newClient: cassandra.New}
+}
+
+// Export returns the schema of the named keyspaces of the cluster of the named
+// ProviderConfig, or of all of its keyspaces other than the system keyspaces
+// if none are named. The schema is described by the server if it supports
+// DESCRIBE statements, i.e. Cassandra 4.0 and later.
+func (e *Exporter) Export(ctx context.Context, providerConfig string, keyspaces []string) ([]Schema, error) {
+	pc := &v1alpha1.ProviderConfig{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: providerConfig}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	db, err := e.connect(ctx, pc)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	if len(keyspaces) == 0 {
+		if keyspaces, err = db.Keyspaces(ctx); err != nil {
+			return nil, errors.Wrap(err, errListKeyspaces)
+		}
+	}
+
+	out := make([]Schema, 0, len(keyspaces))
+	for _, ks := range keyspaces {
+		s, err := describe(ctx, db, ks)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %q", errDescribe, ks)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// describe returns the schema of the supplied keyspace, as described by the
+// server if possible.
+func describe(ctx context.Context, db *cassandra.CassandraDB, keyspace string) (Schema, error) {
+	stmts, ok, err := db.DescribeKeyspace(ctx, keyspace)
+	if err != nil {
+		return Schema{}, err
+	}
+	if ok {
+		return Schema{Keyspace: keyspace, Statements: stmts, Described: true}, nil
+	}
+
+	md, exists, err := db.Keyspace(ctx, keyspace)
+	if err != nil {
+		return Schema{}, err
+	}
+	if !exists {
+		return Schema{}, errors.New(errNoKeyspace)
+	}
+	tmpl, err := db.KeyspaceSchema(ctx, keyspace)
+	if err != nil {
+		return Schema{}, err
+	}
+	return Schema{Keyspace: keyspace, Statements: append([]string{createKeyspace(keyspace, md)}, tmpl.Statements(keyspace)...)}, nil
+}
+
+// createKeyspace returns the statement that creates the supplied keyspace
+// with the supplied metadata.
+func createKeyspace(keyspace string, md cassandra.KeyspaceMetadata) string {
+	opts := make(map[string]string, len(md.Replication))
+	for k, v := range md.Replication {
+		if k != "class" {
+			opts[k] = v
+		}
+	}
+	return cql.CreateKeyspace(keyspace).
+		IfNotExists(true).
+		Replication(strings.TrimPrefix(md.Replication["class"], locatorPrefix), opts).
+		DurableWrites(ptr.Deref(md.DurableWrites, true)).
+		String()
+}
+
+// connect connects to the cluster of the supplied ProviderConfig with the
+// first of its identities that connects.
+func (e *Exporter) connect(ctx context.Context, pc *v1alpha1.ProviderConfig) (*cassandra.CassandraDB, error) {
+	b := breaker.Default.For(pc)
+	return connection.Connect(ctx, e.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := e.newClient(d.Credentials, "", cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithLogger(e.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+}
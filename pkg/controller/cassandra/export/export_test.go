@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestConfigMap(t *testing.T) {
+	s := Schema{
+		Keyspace: "Shop_Orders",
+		Statements: []string{
+			`CREATE KEYSPACE "Shop_Orders" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true`,
+			`CREATE TABLE "Shop_Orders"."orders" ("id" uuid PRIMARY KEY)`,
+		},
+	}
+
+	want := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "backup",
+			Name:      "cassandra-schema-shop-orders",
+			Labels:    map[string]string{LabelKeyspace: "Shop_Orders"},
+		},
+		Data: map[string]string{SchemaKey: `CREATE KEYSPACE "Shop_Orders" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = true;
+CREATE TABLE "Shop_Orders"."orders" ("id" uuid PRIMARY KEY);
+`},
+	}
+	if diff := cmp.Diff(want, s.ConfigMap("backup")); diff != "" {
+		t.Errorf("\nConfigMap(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreateKeyspace(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		md     cassandra.KeyspaceMetadata
+		want   string
+	}{
+		"NetworkTopologyStrategy": {
+			reason: "The keyspace should be created with its observed replication, without the class prefix",
+			md: cassandra.KeyspaceMetadata{
+				Replication:   map[string]string{"class": locatorPrefix + "NetworkTopologyStrategy", "dc1": "3"},
+				DurableWrites: ptr.To(false),
+			},
+			want: `CREATE KEYSPACE IF NOT EXISTS "shop" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3'} AND durable_writes = false`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, createKeyspace("shop", tc.md)); diff != "" {
+				t.Errorf("\n%s\ncreateKeyspace(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
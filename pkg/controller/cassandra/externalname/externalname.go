@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalname contains the initializer that derives the external
+// names of Cassandra managed resources from their ProviderConfig.
+package externalname
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+const (
+	errGetPC          = "cannot get ProviderConfig"
+	errUpdateManaged  = "cannot update managed resource"
+	errNoProviderConf = "managed resource does not reference a ProviderConfig"
+)
+
+// An Initializer writes the external name annotation of a managed resource
+// that does not have one yet. The name of the managed resource is used,
+// formatted according to the ExternalName settings of its ProviderConfig.
+type Initializer struct {
+	kube client.Client
+}
+
+// NewInitializer returns a new Initializer.
+func NewInitializer(c client.Client) *Initializer {
+	return &Initializer{kube: c}
+}
+
+// Initialize the external name of the supplied managed resource.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	if meta.GetExternalName(mg) != "" {
+		return nil
+	}
+
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return errors.New(errNoProviderConf)
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := i.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return errors.Wrap(err, errGetPC)
+	}
+
+	meta.SetExternalName(mg, Format(pc.Spec.ExternalName, mg.GetName()))
+	return errors.Wrap(i.kube.Update(ctx, mg), errUpdateManaged)
+}
+
+// Format returns the external name for the supplied object name.
+func Format(f *v1alpha1.ExternalNameFormat, name string) string {
+	if f == nil {
+		return name
+	}
+	return f.Prefix + name + f.Suffix
+}
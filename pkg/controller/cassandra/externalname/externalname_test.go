@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	keyspace := func(externalName string) *v1alpha1.Keyspace {
+		ks := &v1alpha1.Keyspace{
+			ObjectMeta: v1.ObjectMeta{Name: "orders"},
+			Spec: v1alpha1.KeyspaceSpec{
+				ResourceSpec: xpv1.ResourceSpec{
+					ProviderConfigReference: &xpv1.Reference{Name: "default"},
+				},
+			},
+		}
+		if externalName != "" {
+			meta.SetExternalName(ks, externalName)
+		}
+		return ks
+	}
+
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     *v1alpha1.Keyspace
+		want   want
+	}{
+		"ExternalNameAlreadySet": {
+			reason: "An existing external name should never be changed",
+			mg:     keyspace("legacy"),
+			want:   want{externalName: "legacy"},
+		},
+		"ErrGetProviderConfig": {
+			reason: "An error should be returned if we can't get the ProviderConfig",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			mg:   keyspace(""),
+			want: want{err: errors.Wrap(errBoom, errGetPC)},
+		},
+		"NoFormat": {
+			reason: "The object name should be used verbatim if no format is configured",
+			kube: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg:   keyspace(""),
+			want: want{externalName: "orders"},
+		},
+		"PrefixAndSuffix": {
+			reason: "The configured prefix and suffix should be applied to the object name",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					pc := obj.(*v1alpha1.ProviderConfig)
+					pc.Spec.ExternalName = &v1alpha1.ExternalNameFormat{Prefix: "prod_", Suffix: "_v1"}
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg:   keyspace(""),
+			want: want{externalName: "prod_orders_v1"},
+		},
+		"ErrUpdate": {
+			reason: "An error should be returned if we can't persist the external name",
+			kube: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			mg:   keyspace(""),
+			want: want{externalName: "orders", err: errors.Wrap(errBoom, errUpdateManaged)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewInitializer(tc.kube).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ni.Initialize(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ni.Initialize(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"context"
+	"sort"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+	"github.com/pkg/errors"
+)
+
+// apply grants the privileges of the supplied grant that the role does not
+// hold yet on each target, and revokes the former privileges that are no
+// longer desired. Nothing is executed if the role is up to date.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.Grant, targets []cassandra.Resource, former []string) error {
+	role := *cr.Spec.ForProvider.Role
+	observed, err := c.observe(ctx, role, targets)
+	if err != nil {
+		return errors.Wrap(err, errGrantObserve)
+	}
+
+	grants, revokes, err := applyGrants(role, targets, cr.Spec.ForProvider.Privileges.ToCQL(), former, observed)
+	if err != nil {
+		return errors.Wrap(err, errGrantCreate)
+	}
+	for _, query := range grants {
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errGrantCreate)
+		}
+	}
+	for _, query := range revokes {
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errGrantDelete)
+		}
+	}
+	return c.revokePublic(ctx, cr, targets)
+}
+
+// applyGrants returns the statements that bring the permissions observed on
+// each target to the desired privileges: desired privileges that are not
+// observed are granted, and former privileges that are no longer desired but
+// still observed are revoked. CQL grants one permission per statement, so
+// privileges are sorted and deduplicated to keep the statements stable.
+func applyGrants(role string, targets []cassandra.Resource, desired, former []string, observed []map[string]bool) ([]string, []string, error) {
+	desired = uniqueSorted(desired)
+	keep := make(map[string]bool, len(desired))
+	for _, p := range desired {
+		keep[p] = true
+	}
+
+	var grants, revokes []string
+	for i, t := range targets {
+		if err := cassandra.ValidatePermissions(t, desired); err != nil {
+			return nil, nil, err
+		}
+		for _, p := range desired {
+			if !observed[i][p] {
+				grants = append(grants, cql.Grant(p, t.CQL(), role))
+			}
+		}
+		for _, p := range uniqueSorted(former) {
+			if !keep[p] && observed[i][p] {
+				revokes = append(revokes, cql.Revoke(p, t.CQL(), role))
+			}
+		}
+	}
+	return grants, revokes, nil
+}
+
+// uniqueSorted returns a sorted copy of the supplied privileges without
+// duplicates.
+func uniqueSorted(privileges []string) []string {
+	out := append([]string(nil), privileges...)
+	sort.Strings(out)
+	n := 0
+	for i, p := range out {
+		if i == 0 || p != out[n-1] {
+			out[n] = p
+			n++
+		}
+	}
+	return out[:n]
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestApplyGrants(t *testing.T) {
+	shop := cassandra.Resource{Kind: cassandra.ResourceData, Keyspace: "shop"}
+	roles := cassandra.Resource{Kind: cassandra.ResourceRoles, Role: "admin"}
+
+	type want struct {
+		grants  []string
+		revokes []string
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		targets  []cassandra.Resource
+		desired  []string
+		former   []string
+		observed []map[string]bool
+		want     want
+	}{
+		"UpToDate": {
+			reason:   "Nothing should be executed if the role holds exactly the desired privileges.",
+			targets:  []cassandra.Resource{shop},
+			desired:  []string{"SELECT", "MODIFY"},
+			former:   []string{"MODIFY", "SELECT"},
+			observed: []map[string]bool{{"SELECT": true, "MODIFY": true}},
+		},
+		"Create": {
+			reason:   "Desired privileges should be granted sorted and once, skipping those already held.",
+			targets:  []cassandra.Resource{shop},
+			desired:  []string{"SELECT", "MODIFY", "SELECT", "ALTER"},
+			observed: []map[string]bool{{"ALTER": true}},
+			want: want{grants: []string{
+				`GRANT MODIFY ON KEYSPACE "shop" TO "app"`,
+				`GRANT SELECT ON KEYSPACE "shop" TO "app"`,
+			}},
+		},
+		"Update": {
+			reason:   "Former privileges that are no longer desired should be revoked if they are still held.",
+			targets:  []cassandra.Resource{shop},
+			desired:  []string{"SELECT"},
+			former:   []string{"SELECT", "MODIFY", "DROP"},
+			observed: []map[string]bool{{"SELECT": true, "MODIFY": true}},
+			want: want{revokes: []string{
+				`REVOKE MODIFY ON KEYSPACE "shop" FROM "app"`,
+			}},
+		},
+		"InvalidPrivilege": {
+			reason:   "Privileges that can't be granted on a target should return an error.",
+			targets:  []cassandra.Resource{roles},
+			desired:  []string{"SELECT"},
+			observed: []map[string]bool{{}},
+			want:     want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			grants, revokes, err := applyGrants("app", tc.targets, tc.desired, tc.former, tc.observed)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\napplyGrants(...): want error %t, got %v\n", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.grants, grants); diff != "" {
+				t.Errorf("\n%s\napplyGrants(...): -want grants, +got grants:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.revokes, revokes); diff != "" {
+				t.Errorf("\n%s\napplyGrants(...): -want revokes, +got revokes:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -23,29 +23,60 @@ import (
 
 	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/audit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/credentials"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/dedup"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drift"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/events"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/notify"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/parallel"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/profile"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/recovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 const (
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret"
-	errNotGrant     = "managed resource is not a Grant custom resource"
-	errGrantCreate  = "cannot create grant"
-	errGrantDelete  = "cannot delete grant"
-	errGrantObserve = "cannot observe grant"
-	maxConcurrency  = 5
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errConnect           = "cannot connect to cluster"
+	errNotifications     = "cannot configure notifications"
+	errNotGrant          = "managed resource is not a Grant custom resource"
+	errSelectIdentity    = "cannot select cluster identity"
+	errGrantCreate       = "cannot create grant"
+	errGrantDelete       = "cannot delete grant"
+	errGrantObserve      = "cannot observe grant"
+	errListTables        = "cannot list keyspace tables"
+	errTarget            = "exactly one of keyspace, onRole and onAllRoles must be set"
+	errPublicRole        = "publicRole must be set to a role other than role if revokePublic is true"
+	errRevokePublic      = "cannot revoke permissions of public role"
+	errCheckDeps         = "cannot check that the referenced keyspace and roles exist"
+	errKeyspaceNotFnd    = "referenced keyspace not found"
+	errRoleNotFound      = "referenced role not found"
+	errSelectVersion     = "cannot select release version"
+	errInvalidPrivileges = "privileges cannot be granted"
+	errUnresolved        = "references not yet resolved"
+	maxParallelRevokes   = 8
+	maxConcurrency       = 5
 )
 
 // Setup adds a controller that reconciles Grant managed resources.
@@ -53,16 +84,25 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.GrantGroupKind)
 
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	l := o.Logger.WithValues("controller", name)
+	rec := events.NewRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), mgr.GetClient())
+	a := audit.New(l, rec, audit.IdentityFromConfig(mgr.GetConfig()))
+	// Audit entries are never deduplicated, only the errors of every poll.
+	f := dedup.NewFilter(dedup.DefaultWindow)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.GrantGroupVersionKind),
-		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New}),
-		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithExternalConnecter(recovery.NewConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New, log: dedup.NewLogger(l, f), audit: a, drift: drift.NewRecorder(mgr.GetClient(), l)}, v1alpha1.GrantKind)),
+		managed.WithLogger(dedup.NewLogger(l, f)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithRecorder(dedup.NewRecorder(rec, f)),
+		managed.WithConnectionPublishers(secrets.NewPublisher(mgr.GetClient(), mgr.GetScheme())))
 
+	// Resources are reconciled as soon as the credentials of their
+	// ProviderConfig change rather than at the next poll.
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Grant{}).
+		Watches(&corev1.Secret{}, credentials.EnqueueUsers(mgr.GetClient(), func() resource.ManagedList { return &v1alpha1.GrantList{} }, l), builder.WithPredicates(secrets.Changed())).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrency,
 		}).
@@ -72,7 +112,10 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) *cassandra.CassandraDB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+	log       logging.Logger
+	audit     *audit.Auditor
+	drift     *drift.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -90,22 +133,36 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, c.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := c.newClient(d.Credentials, "", cassandra.WithSerialConsistency(pc.Spec.DefaultConsistencySerial), cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithMaxPreparedStatements(pc.Spec.MaxPreparedStatements), cassandra.WithLogger(c.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+	if c.audit != nil {
+		db.SetAuditFn(c.audit.For(cr))
 	}
-
-	db := c.newClient(s.Data, "")
-	return &external{db: db}, nil
+	db.SetExecutionProfiles(profile.Convert(pc.Spec.ExecutionProfiles))
+	db.SetLatencyObserver(latency.Default.Observer(pc.GetName(), v1alpha1.GrantKind, cr.GetName()))
+	db.SetDrainer(drain.Default)
+	ext := &external{db: db, drift: c.drift}
+	if pc.Spec.Notifications == nil {
+		return ext, nil
+	}
+	sink, err := notify.NewSink(ctx, c.kube, pc.Spec.Notifications, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errNotifications)
+	}
+	return notify.Wrap(ext, db, sink, v1alpha1.GrantKind, c.log), nil
 }
 
 type external struct {
-	db *cassandra.CassandraDB
+	db    *cassandra.CassandraDB
+	drift *drift.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -114,55 +171,69 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotGrant)
 	}
 
+	if msg := unresolved(cr.Spec.ForProvider); msg != "" {
+		if meta.WasDeleted(cr) {
+			// Nothing was granted before the references were resolved.
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.SetConditions(v1alpha1.ReferencesUnresolved(msg))
+		return managed.ExternalObservation{}, errors.New(msg)
+	}
+
 	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
 
-	query := fmt.Sprintf("SELECT permissions FROM system_auth.role_permissions WHERE role = ? AND resource = 'data/%s'", keyspace)
-	var permissions []string
-	iter, err := c.db.Query(ctx, query, role)
+	missing, err := c.checkDependencies(ctx, cr)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGrantObserve)
+		return managed.ExternalObservation{}, err
 	}
-	defer iter.Close()
-
-	observedPermissions := make(map[string]bool)
-	resourceExists := false
-	for iter.Scan(&permissions) {
-		for _, p := range permissions {
-			observedPermissions[p] = true
+	if missing != "" {
+		if meta.WasDeleted(cr) {
+			// There is nothing left to revoke.
+			return managed.ExternalObservation{ResourceExists: false}, nil
 		}
+		return managed.ExternalObservation{}, errors.New(missing)
 	}
 
-	desiredPermissions := make(map[string]bool)
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
-	for _, p := range privileges {
-		desiredPermissions[p] = true
+	targets, err := c.targets(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGrantObserve)
 	}
 
-	upToDate := true
-	for p := range desiredPermissions {
-		if !observedPermissions[p] {
-			upToDate = false
-			break
-		} else {
-			resourceExists = true
+	// Privileges the cluster would reject are reported before any of them is
+	// granted, rather than as a syntax error of the first GRANT.
+	privileges := cr.Spec.ForProvider.Privileges.ToCQL()
+	if !meta.WasDeleted(cr) {
+		if err := c.validate(ctx, privileges, targets); err != nil {
+			return managed.ExternalObservation{}, err
 		}
 	}
 
-	atProviderPrivileges := cr.Status.AtProvider.Privileges
+	observed, err := c.observe(ctx, role, targets)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGrantObserve)
+	}
 
-	for _, p := range atProviderPrivileges {
-		if !desiredPermissions[p] {
-			// a case where we removed some permissions from CR spec
-			upToDate = false
-		}
+	drifted, resourceExists := drift.Privileges(privileges, targets, observed)
+	drifted = append(drifted, drift.Removed(privileges, cr.Status.AtProvider.Privileges)...)
+
+	public, err := c.publicTargets(ctx, cr, targets)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGrantObserve)
 	}
+	drifted = append(drifted, drift.Public(public)...)
+	upToDate := len(drifted) == 0
+	c.drift.Record(ctx, cr, v1alpha1.GrantKind, drifted)
 
 	if upToDate {
 		cr.Status.AtProvider.Privileges = privileges
 	}
 
 	if resourceExists {
+		cluster, dc, err := c.db.Identity(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectIdentity)
+		}
+		cr.Status.AtProvider.ClusterIdentity = v1alpha1.ClusterIdentity{ClusterName: cluster, Datacenter: dc}
 		cr.SetConditions(xpv1.Available())
 	}
 
@@ -179,16 +250,17 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
+	if msg := unresolved(cr.Spec.ForProvider); msg != "" {
+		return managed.ExternalCreation{}, errors.New(msg)
+	}
 
-	for _, privilege := range privileges {
-		// we make multiple grants to support yugabyteDB dialect that doesn't allow multiple grants like GRANT SELECT, MODIFY ...
-		query := fmt.Sprintf("GRANT %s ON KEYSPACE %s TO %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-		if err := c.db.Exec(ctx, query); err != nil {
-			return managed.ExternalCreation{}, errors.Wrap(err, errGrantCreate)
-		}
+	targets, err := c.targets(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGrantCreate)
+	}
+
+	if err := c.apply(ctx, cr, targets, nil); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
 	return managed.ExternalCreation{}, nil
@@ -200,31 +272,20 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
-	keyspace := *cr.Spec.ForProvider.Keyspace
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
-	desiredPermissions := make(map[string]bool)
-
+	if msg := unresolved(cr.Spec.ForProvider); msg != "" {
+		return managed.ExternalUpdate{}, errors.New(msg)
+	}
 
-	for _, privilege := range privileges {
-		query := fmt.Sprintf("GRANT %s ON KEYSPACE %s TO %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-		if err := c.db.Exec(ctx, query); err != nil {
-			return managed.ExternalUpdate{}, errors.Wrap(err, errGrantCreate)
-		}
-		desiredPermissions[privilege] = true
+	targets, err := c.targets(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGrantCreate)
 	}
 
-	atProviderPrivileges := cr.Status.AtProvider.Privileges
-	for _, p := range atProviderPrivileges {
-		if !desiredPermissions[p] {
-			query := fmt.Sprintf("REVOKE %s ON KEYSPACE %s FROM %s", p, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-			if err := c.db.Exec(ctx, query); err != nil {
-				return managed.ExternalUpdate{}, errors.Wrap(err, errGrantDelete)
-			}
-		}
+	if err := c.apply(ctx, cr, targets, cr.Status.AtProvider.Privileges); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	cr.Status.AtProvider.Privileges = privileges
+	cr.Status.AtProvider.Privileges = cr.Spec.ForProvider.Privileges.ToCQL()
 
 	return managed.ExternalUpdate{}, nil
 }
@@ -235,24 +296,169 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotGrant)
 	}
 
-	role := *cr.Spec.ForProvider.Role
+	targets, err := c.targets(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, errGrantDelete)
+	}
+
+	// Grants on every table of a keyspace are revoked concurrently, so that
+	// tearing down a tenant does not take a round trip per privilege.
+	queries := render.Revoke(cr, targets)
+	revokes := make([]func(ctx context.Context) error, len(queries))
+	for i, query := range queries {
+		query := query
+		revokes[i] = func(ctx context.Context) error {
+			return c.db.Exec(ctx, query)
+		}
+	}
+	return errors.Wrap(parallel.Run(ctx, maxParallelRevokes, revokes...), errGrantDelete)
+}
+
+// unresolved describes the role or keyspace of the supplied grant that is not
+// set yet, e.g. because the resource it references does not exist, or returns
+// an empty string if both are.
+func unresolved(p v1alpha1.GrantParameters) string {
+	var missing []string
+	if p.Role == nil {
+		missing = append(missing, "role")
+	}
+	if p.Keyspace == nil && (p.KeyspaceRef != nil || p.KeyspaceSelector != nil) {
+		missing = append(missing, "keyspace")
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", errUnresolved, strings.Join(missing, ", "))
+}
+
+// checkDependencies returns which keyspace or role the supplied grant refers
+// to does not exist, e.g. because the resource creating it is not ready yet,
+// and sets the Dependencies condition accordingly. Cassandra's own errors for
+// such grants are hard to relate to their cause.
+func (c *external) checkDependencies(ctx context.Context, cr *v1alpha1.Grant) (string, error) {
+	p := cr.Spec.ForProvider
+
+	if p.Keyspace != nil {
+		exists, err := c.db.KeyspaceExists(ctx, *p.Keyspace)
+		if err != nil {
+			return "", errors.Wrap(err, errCheckDeps)
+		}
+		if !exists {
+			msg := fmt.Sprintf("%s: %q", errKeyspaceNotFnd, *p.Keyspace)
+			cr.SetConditions(v1alpha1.KeyspaceNotFound(msg))
+			return msg, nil
+		}
+	}
+
+	roles := []string{*p.Role}
+	if p.OnRole != nil {
+		roles = append(roles, *p.OnRole)
+	}
+	for _, r := range roles {
+		exists, err := c.db.RoleExists(ctx, r)
+		if err != nil {
+			return "", errors.Wrap(err, errCheckDeps)
+		}
+		if !exists {
+			msg := fmt.Sprintf("%s: %q", errRoleNotFound, r)
+			cr.SetConditions(v1alpha1.RoleNotFound(msg))
+			return msg, nil
+		}
+	}
+
+	if cr.GetCondition(v1alpha1.TypeDependencies).Status != corev1.ConditionUnknown {
+		cr.SetConditions(v1alpha1.DependenciesFound())
+	}
+	return "", nil
+}
+
+// targets returns the resources the grant applies to: a role, all roles, its
+// keyspace, or each of the tables that currently exist in its keyspace.
+func (c *external) targets(ctx context.Context, cr *v1alpha1.Grant) ([]cassandra.Resource, error) {
+	targets, err := render.GrantTargets(cr)
+	if err != nil || targets != nil {
+		return targets, err
+	}
+
 	keyspace := *cr.Spec.ForProvider.Keyspace
-	privileges := replaceUnderscoreWithSpace(cr.Spec.ForProvider.Privileges)
+	iter, err := c.db.Query(ctx, "SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?", keyspace)
+	if err != nil {
+		return nil, errors.Wrap(err, errListTables)
+	}
 
-	for _, privilege := range privileges {
-		query := fmt.Sprintf("REVOKE %s ON KEYSPACE %s FROM %s", privilege, cassandra.QuoteIdentifier(keyspace), cassandra.QuoteIdentifier(role))
-		if err := c.db.Exec(ctx, query); err != nil {
-			return errors.Wrap(err, errGrantDelete)
+	var table string
+	for iter.Scan(&table) {
+		targets = append(targets, cassandra.Resource{Kind: cassandra.ResourceData, Keyspace: keyspace, Table: table})
+	}
+
+	return targets, errors.Wrap(iter.Close(), errListTables)
+}
+
+// publicTargets returns the targets on which the public role of the supplied
+// grant holds permissions, if the grant revokes them.
+func (c *external) publicTargets(ctx context.Context, cr *v1alpha1.Grant, targets []cassandra.Resource) ([]cassandra.Resource, error) {
+	p := cr.Spec.ForProvider
+	if p.RevokePublic == nil || !*p.RevokePublic {
+		return nil, nil
+	}
+	if p.PublicRole == nil || *p.PublicRole == "" || *p.PublicRole == *p.Role {
+		return nil, errors.New(errPublicRole)
+	}
+
+	observed, err := c.observe(ctx, *p.PublicRole, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	var public []cassandra.Resource
+	for i, t := range targets {
+		if len(observed[i]) > 0 {
+			public = append(public, t)
 		}
 	}
+	return public, nil
+}
 
+// revokePublic revokes all permissions the public role of the supplied grant
+// holds on the supplied targets, if the grant revokes them.
+func (c *external) revokePublic(ctx context.Context, cr *v1alpha1.Grant, targets []cassandra.Resource) error {
+	public, err := c.publicTargets(ctx, cr, targets)
+	if err != nil {
+		return errors.Wrap(err, errRevokePublic)
+	}
+
+	for _, t := range public {
+		query := cql.Revoke("ALL PERMISSIONS", t.CQL(), *cr.Spec.ForProvider.PublicRole)
+		if err := c.db.Exec(ctx, query); err != nil {
+			return errors.Wrap(err, errRevokePublic)
+		}
+	}
 	return nil
 }
 
-func replaceUnderscoreWithSpace(privileges []v1alpha1.GrantPrivilege) []string {
-	replaced := make([]string, len(privileges))
-	for i, privilege := range privileges {
-		replaced[i] = strings.ReplaceAll(string(privilege), "_", " ")
+// validate returns an error if any of the supplied privileges cannot be
+// granted on any of the supplied targets by the release of Cassandra the
+// cluster runs. The release is only selected if a target requires one.
+func (c *external) validate(ctx context.Context, privileges []string, targets []cassandra.Resource) error {
+	var v cassandra.Version
+	for _, t := range targets {
+		if v.IsZero() && !cassandra.RequiredVersion(t).IsZero() {
+			var err error
+			if v, err = c.db.ReleaseVersion(ctx); err != nil {
+				return errors.Wrap(err, errSelectVersion)
+			}
+		}
+		if err := cassandra.ValidatePermissionsFor(v, t, privileges); err != nil {
+			return errors.Wrap(err, errInvalidPrivileges)
+		}
 	}
-	return replaced
+	return nil
+}
+
+// observe returns the permissions role holds on each of the supplied targets,
+// whether granted on the target itself or inherited from its parents.
+func (c *external) observe(ctx context.Context, role string, targets []cassandra.Resource) ([]map[string]bool, error) {
+	return drift.Held(targets, func(resource string) (map[string]bool, error) {
+		return c.db.RolePermissions(ctx, role, resource)
+	})
 }
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	cr := &v1alpha1.Grant{Spec: v1alpha1.GrantSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}}}}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		usage  resource.Tracker
+		mg     resource.Managed
+		want   error
+	}{
+		"NotGrant": {
+			reason: "Connecting for a managed resource that is not a Grant should return an error.",
+			mg:     &v1alpha1.Role{},
+			want:   errors.New(errNotGrant),
+		},
+		"ErrTrackUsage": {
+			reason: "Errors tracking the usage of the ProviderConfig should be returned.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "A missing ProviderConfig should return an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errGetPC),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			usage := tc.usage
+			if usage == nil {
+				usage = resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil })
+			}
+			c := &connector{kube: tc.kube, usage: usage}
+			_, err := c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUnresolved(t *testing.T) {
+	type want struct {
+		o      managed.ExternalObservation
+		err    error
+		reason xpv1.ConditionReason
+	}
+
+	now := metav1.Now()
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Grant
+		want   want
+	}{
+		"RoleUnresolved": {
+			reason: "A grant whose role is not resolved yet should not be observed.",
+			cr: &v1alpha1.Grant{Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{
+				RoleRef:  &xpv1.Reference{Name: "app"},
+				Keyspace: ptr.To("shop"),
+			}}},
+			want: want{
+				err:    errors.New(errUnresolved + ": role"),
+				reason: v1alpha1.ReasonReferencesUnresolved,
+			},
+		},
+		"KeyspaceUnresolved": {
+			reason: "A grant whose keyspace is referenced but not resolved yet should not be observed.",
+			cr: &v1alpha1.Grant{Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{
+				Role:             ptr.To("app"),
+				KeyspaceSelector: &xpv1.Selector{MatchLabels: map[string]string{"tenant": "shop"}},
+			}}},
+			want: want{
+				err:    errors.New(errUnresolved + ": keyspace"),
+				reason: v1alpha1.ReasonReferencesUnresolved,
+			},
+		},
+		"Deleted": {
+			reason: "A deleted grant whose references were never resolved should not exist.",
+			cr: &v1alpha1.Grant{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{
+					RoleRef:     &xpv1.Reference{Name: "app"},
+					KeyspaceRef: &xpv1.Reference{Name: "shop"},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, tc.cr.GetCondition(v1alpha1.TypeDependencies).Reason); tc.want.reason != "" && diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want reason, +got reason:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inflight tracks schema changes that timed out but may still
+// complete on the cluster, so that they are not issued again while they are in
+// flight.
+package inflight
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultTimeout is how long a timed out schema change is waited for before
+// it is assumed to have failed.
+const DefaultTimeout = 10 * time.Minute
+
+type operation struct {
+	name    string
+	started time.Time
+}
+
+// A Tracker records the operations that are in flight per managed resource.
+// It is kept in memory, so operations are forgotten when the provider
+// restarts.
+type Tracker struct {
+	timeout time.Duration
+	now     func() time.Time
+
+	mu  sync.Mutex
+	ops map[types.UID]operation
+}
+
+// NewTracker returns a Tracker that waits for operations for the supplied
+// timeout.
+func NewTracker(timeout time.Duration) *Tracker {
+	return &Tracker{timeout: timeout, now: time.Now, ops: map[types.UID]operation{}}
+}
+
+// Start records that the named operation on the supplied resource timed out
+// and may still be in flight.
+func (t *Tracker) Start(uid types.UID, name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ops[uid] = operation{name: name, started: t.now()}
+}
+
+// InFlight returns the operation that may be in flight for the supplied
+// resource. Operations that did not complete within the timeout are
+// forgotten.
+func (t *Tracker) InFlight(uid types.UID) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	op, ok := t.ops[uid]
+	if !ok {
+		return "", false
+	}
+	if t.now().Sub(op.started) >= t.timeout {
+		delete(t.ops, uid)
+		return "", false
+	}
+	return op.name, true
+}
+
+// Done records that the operation on the supplied resource completed.
+func (t *Tracker) Done(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.ops, uid)
+}
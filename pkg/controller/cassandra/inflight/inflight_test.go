@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflight
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTracker(t *testing.T) {
+	now := time.Now()
+	tr := NewTracker(time.Minute)
+	tr.now = func() time.Time { return now }
+
+	type result struct {
+		Op       string
+		InFlight bool
+	}
+	inFlight := func(uid string) result {
+		op, ok := tr.InFlight(types.UID(uid))
+		return result{op, ok}
+	}
+
+	got := []result{inFlight("a")}
+	tr.Start("a", "create")
+	tr.Start("b", "drop")
+	got = append(got, inFlight("a"), inFlight("b"))
+	tr.Done("a")
+	got = append(got, inFlight("a"))
+	now = now.Add(time.Minute)
+	got = append(got, inFlight("b"))
+
+	want := []result{
+		{},
+		{Op: "create", InFlight: true},
+		{Op: "drop", InFlight: true},
+		// Completed.
+		{},
+		// Timed out.
+		{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nInFlight(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -18,38 +18,91 @@ package keyspace
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
-	"strconv"
+	"time"
 
 	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra/management"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/audit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/credentials"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/dedup"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/description"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drift"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/events"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/externalname"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/inflight"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/lateinit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/notify"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/ownership"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/pacing"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/profile"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/quota"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/recovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+	"github.com/crossplane-contrib/provider-sql/pkg/features"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 const (
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret"
-	errNotKeyspace  = "managed resource is not a Keyspace custom resource"
-	errSelectKeyspace = "cannot select keyspace"
-	errCreateKeyspace = "cannot create keyspace"
-	errUpdateKeyspace = "cannot update keyspace"
-	errDropKeyspace   = "cannot drop keyspace"
-	maxConcurrency  = 5
-	defaultStrategy = "SimpleStrategy"
-	defaultReplicas = 1
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errConnect           = "cannot connect to cluster"
+	errNotifications     = "cannot configure notifications"
+	errNotKeyspace       = "managed resource is not a Keyspace custom resource"
+	errSelectIdentity    = "cannot select cluster identity"
+	errNoManagementAPI   = "ProviderConfig observes keyspaces with the management API but does not configure it"
+	errSelectKeyspace    = "cannot select keyspace"
+	errCreateKeyspace    = "cannot create keyspace"
+	errUpdateKeyspace    = "cannot update keyspace"
+	errDropKeyspace      = "cannot drop keyspace"
+	errKeyspaceExists    = "keyspace already exists and was not created by this resource"
+	errSchemaAgree       = "deferring schema change"
+	errNoTemplate        = "template keyspace does not exist"
+	errSelectSchema      = "cannot select keyspace schema"
+	errBootstrap         = "cannot bootstrap keyspace schema"
+	errCheckOwnership    = "cannot check for conflicting Keyspaces"
+	errConflict          = "keyspace is managed by other Keyspaces"
+	errInFlight          = "waiting for timed out statement to complete"
+	errSelectDCs         = "cannot select datacenters"
+	errSelectDescription = "cannot select keyspace description"
+	errSetDescription    = "cannot set keyspace description"
+	errDropDescription   = "cannot delete keyspace description"
+	errSelectVersions    = "cannot select schema versions"
+	opCreate             = "CREATE KEYSPACE"
+	opDrop               = "DROP KEYSPACE"
+	errCheckQuota        = "cannot check ProviderConfig quota"
+	errQuotaExceeded     = "ProviderConfig keyspace quota exceeded"
+	maxConcurrency       = 5
+	defaultStrategy      = "SimpleStrategy"
+	defaultReplicas      = 1
+
+	// schemaPollInterval is how often keyspaces whose schema is still
+	// propagating to other datacenters are observed.
+	schemaPollInterval = 10 * time.Second
 )
 
 // Setup adds a controller that reconciles Keyspace managed resources.
@@ -57,16 +110,27 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.KeyspaceGroupKind)
 
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	l := o.Logger.WithValues("controller", name)
+	rec := events.NewRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), mgr.GetClient())
+	a := audit.New(l, rec, audit.IdentityFromConfig(mgr.GetConfig()))
+	// Audit entries are never deduplicated, only the errors of every poll.
+	f := dedup.NewFilter(dedup.DefaultWindow)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.KeyspaceGroupVersionKind),
-		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New}),
-		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithExternalConnecter(recovery.NewConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New, log: dedup.NewLogger(l, f), audit: a, drift: drift.NewRecorder(mgr.GetClient(), l), inflight: inflight.NewTracker(inflight.DefaultTimeout), noLateInit: o.Features.Enabled(features.DisableKeyspaceLateInit)}, v1alpha1.KeyspaceKind)),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient())),
+		managed.WithLogger(dedup.NewLogger(l, f)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithPollIntervalHook(pollInterval),
+		managed.WithRecorder(dedup.NewRecorder(rec, f)),
+		managed.WithConnectionPublishers(secrets.NewPublisher(mgr.GetClient(), mgr.GetScheme())))
 
+	// Resources are reconciled as soon as the credentials of their
+	// ProviderConfig change rather than at the next poll.
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Keyspace{}).
+		Watches(&corev1.Secret{}, credentials.EnqueueUsers(mgr.GetClient(), func() resource.ManagedList { return &v1alpha1.KeyspaceList{} }, l), builder.WithPredicates(secrets.Changed())).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrency,
 		}).
@@ -76,7 +140,14 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) *cassandra.CassandraDB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+	log       logging.Logger
+	audit     *audit.Auditor
+	drift     *drift.Recorder
+	inflight  *inflight.Tracker
+
+	// noLateInit stops the spec of Keyspaces from being late initialized.
+	noLateInit bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -94,22 +165,52 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, c.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := c.newClient(d.Credentials, "", cassandra.WithSerialConsistency(pc.Spec.DefaultConsistencySerial), cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithMaxPreparedStatements(pc.Spec.MaxPreparedStatements), cassandra.WithLogger(c.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+	if c.audit != nil {
+		db.SetAuditFn(c.audit.For(cr))
 	}
-
-	db := c.newClient(s.Data, "")
-	return &external{db: db}, nil
+	db.SetExecutionProfiles(profile.Convert(pc.Spec.ExecutionProfiles))
+	db.SetLatencyObserver(latency.Default.Observer(pc.GetName(), v1alpha1.KeyspaceKind, cr.GetName()))
+	db.SetDrainer(drain.Default)
+
+	var md cassandra.MetadataReader = db
+	if ptr.Deref(pc.Spec.ObserveWith, v1alpha1.ObserveWithCQL) == v1alpha1.ObserveWithManagementAPI {
+		if pc.Spec.ManagementAPI == nil {
+			return nil, errors.New(errNoManagementAPI)
+		}
+		md = management.New(pc.Spec.ManagementAPI.URL, nil)
+	}
+	ext := &external{db: db, drift: c.drift, md: md, kube: c.kube, quota: pc.Spec.Quota, descriptions: description.Table(pc.Spec.Descriptions), inflight: c.inflight, pacer: pacing.Default.For(pc), noLateInit: c.noLateInit}
+	if pc.Spec.Notifications == nil {
+		return ext, nil
+	}
+	sink, err := notify.NewSink(ctx, c.kube, pc.Spec.Notifications, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errNotifications)
+	}
+	return notify.Wrap(ext, db, sink, v1alpha1.KeyspaceKind, c.log), nil
 }
 
 type external struct {
-	db *cassandra.CassandraDB
+	db           *cassandra.CassandraDB
+	md           cassandra.MetadataReader
+	kube         client.Client
+	quota        *v1alpha1.ProviderQuota
+	descriptions *cassandra.DescriptionTable
+	inflight     *inflight.Tracker
+	drift        *drift.Recorder
+	pacer        *pacing.Pacer
+
+	noLateInit bool
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -118,41 +219,53 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotKeyspace)
 	}
 
-	// Separate query to check if the resource exists
-	existsQuery := "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?"
-	var keyspaceName string
-	existsIter, err := c.db.Query(ctx, existsQuery, meta.GetExternalName(cr))
+	if err := c.checkOwnership(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Keyspaces the credentials may not describe must not be mistaken for
+	// missing ones, which would be created again.
+	md, exists, err := c.md.Keyspace(ctx, meta.GetExternalName(cr))
+	checkPrivileges(cr, err)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, "failed to check keyspace existence")
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectKeyspace)
+	}
+
+	// Statements on large schemas may time out and still complete later, so
+	// they are not issued again until the schema shows whether they did.
+	created := false
+	if op, ok := c.inflight.InFlight(cr.GetUID()); ok {
+		if (op == opCreate) != exists {
+			return managed.ExternalObservation{}, errors.Errorf("%s: %s", errInFlight, op)
+		}
+		c.inflight.Done(cr.GetUID())
+		if op == opCreate {
+			// The keyspace was created by this resource.
+			meta.SetExternalCreateSucceeded(cr, time.Now())
+			created = true
+		}
 	}
-	defer existsIter.Close()
 
-	if !existsIter.Scan(&keyspaceName) {
+	if !exists {
 		// Keyspace does not exist
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists:   false,
 			ResourceUpToDate: false,
 		}, nil
 	}
 
-	observed := &v1alpha1.KeyspaceParameters{
-		ReplicationClass:   new(string),
-		ReplicationFactor:  new(int),
-		DurableWrites:      new(bool),
-	}
-
-	detailsQuery := "SELECT replication, durable_writes FROM system_schema.keyspaces WHERE keyspace_name = ?"
-	detailsIter, err := c.db.Query(ctx, detailsQuery, meta.GetExternalName(cr))
-	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errSelectKeyspace)
+	if !adopt(cr.Spec.ForProvider.IfNotExists, cr) {
+		return managed.ExternalObservation{}, errors.New(errKeyspaceExists)
 	}
-	defer detailsIter.Close()
 
-	replicationMap := map[string]string{}
-	if !detailsIter.Scan(&replicationMap, &observed.DurableWrites) {
-		return managed.ExternalObservation{}, errors.New("failed to scan keyspace attributes")
+	observed := &v1alpha1.KeyspaceParameters{
+		ReplicationClass:  new(string),
+		ReplicationFactor: new(int),
+		TransientReplicas: new(int),
+		DurableWrites:     md.DurableWrites,
 	}
 
+	replicationMap := md.Replication
 	if rc, ok := replicationMap["class"]; ok {
 		// Remove Cassandra prefix if present.
 		if strings.HasPrefix(rc, "org.apache.cassandra.locator.") {
@@ -161,16 +274,88 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		*observed.ReplicationClass = rc
 	}
 	if rf, ok := replicationMap["replication_factor"]; ok {
-		rfInt, _ := strconv.Atoi(rf)
-		*observed.ReplicationFactor = rfInt
+		*observed.ReplicationFactor, *observed.TransientReplicas, err = cassandra.ParseReplicationFactor(rf)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectKeyspace)
+		}
+	}
+
+	cr.Status.AtProvider = v1alpha1.KeyspaceObservation{
+		ReplicationClass:  *observed.ReplicationClass,
+		ReplicationFactor: *observed.ReplicationFactor,
+		TransientReplicas: *observed.TransientReplicas,
+		Replication:       replicationOptions(replicationMap),
+		DurableWrites:     observed.DurableWrites,
+		LastOperation:     cr.Status.AtProvider.LastOperation,
+	}
+	cluster, dc, err := c.db.Identity(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectIdentity)
+	}
+	cr.Status.AtProvider.ClusterIdentity = v1alpha1.ClusterIdentity{ClusterName: cluster, Datacenter: dc}
+
+	if c.descriptions != nil {
+		d, _, err := c.db.Description(ctx, *c.descriptions, cassandra.KindKeyspace, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectDescription)
+		}
+		observed.Description = &d
+		cr.Status.AtProvider.Description = d
+	}
+
+	ready, err := c.ready(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.SetConditions(ready)
+
+	m, err := c.missingSchema(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	missing := m.Names()
+
+	li := false
+	if !c.noLateInit {
+		li = lateInit(observed, &cr.Spec.ForProvider).Record(v1alpha1.KeyspaceKind)
+	}
+	drifted := drift.Keyspace(observed, &cr.Spec.ForProvider)
+	// Datacenters that changed under the Report policy are not corrected.
+	uncorrected := 0
+	if auto := cr.Spec.ForProvider.AutoDatacenterReplication; auto != nil {
+		fields, topology, err := c.datacenterDrift(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		drifted = append(drifted, fields...)
+		if topology != "" {
+			drifted = append(drifted, drift.Field{Name: topology})
+			if auto.Policy() == v1alpha1.TopologyReport {
+				uncorrected++
+			}
+		}
+	}
+	if len(missing) > 0 {
+		drifted = append(drifted, drift.Field{Name: fmt.Sprintf("schema of template keyspace %q (missing %s)", *cr.Spec.ForProvider.BootstrapFrom, strings.Join(missing, ", "))})
 	}
+	if len(drifted) == 0 {
+		cr.SetConditions(v1alpha1.InSync())
+	} else {
+		cr.SetConditions(v1alpha1.OutOfSync("observed keyspace differs in " + strings.Join(drift.Names(drifted), ", ")))
+	}
+	c.drift.Record(ctx, cr, v1alpha1.KeyspaceKind, drifted)
+	upToDate := len(drifted) == uncorrected
 
-	cr.SetConditions(xpv1.Available())
+	// Report drift without correcting it when auto-correction is disabled.
+	// Missing template objects are created regardless.
+	if !autoCorrect(cr) {
+		upToDate = len(missing) == 0
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
-		ResourceUpToDate:        upToDate(observed, &cr.Spec.ForProvider),
+		ResourceLateInitialized: li || created,
+		ResourceUpToDate:        upToDate,
 	}, nil
 }
 
@@ -180,106 +365,376 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotKeyspace)
 	}
 
-	params := cr.Spec.ForProvider
-	strategy := defaultStrategy
-	if params.ReplicationClass != nil {
-		strategy = *params.ReplicationClass
+	if err := cassandra.ValidateIdentifier(meta.GetExternalName(cr)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateKeyspace)
 	}
 
-	replicationFactor := defaultReplicas
-	if params.ReplicationFactor != nil {
-		replicationFactor = *params.ReplicationFactor
+	// Keyspaces replicated to every datacenter count towards the quota once
+	// per datacenter.
+	var dcs []string
+	rf := ptr.Deref(cr.Spec.ForProvider.ReplicationFactor, defaultReplicas)
+	if auto := cr.Spec.ForProvider.AutoDatacenterReplication; auto != nil {
+		var err error
+		if dcs, err = c.db.Datacenters(ctx); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errSelectDCs)
+		}
+		rf = auto.Factor * len(dcs)
 	}
 
-	durableWrites := true
-	if params.DurableWrites != nil {
-		durableWrites = *params.DurableWrites
+	if err := c.checkQuota(ctx, cr, rf); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	query := render.Keyspace(cr, dcs)
+
+	if err := c.pacer.Check(ctx, c.db); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
-	query := "CREATE KEYSPACE IF NOT EXISTS " + cassandra.QuoteIdentifier(meta.GetExternalName(cr)) +
-		" WITH replication = {'class': '" + strategy + "', 'replication_factor': " + strconv.Itoa(replicationFactor) + "} AND durable_writes = " + strconv.FormatBool(durableWrites)
+	if err := c.db.CheckSchemaAgreement(ctx); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSchemaAgree)
+	}
 
 	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalCreation{}, errors.New(errCreateKeyspace + ": " + err.Error())
+		if cassandra.IsTimeout(err) {
+			c.inflight.Start(cr.GetUID(), opCreate)
+		}
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateKeyspace)
+	}
+
+	if err := c.setDescription(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
 	return managed.ExternalCreation{}, nil
 }
 
+// setDescription stores the description of the supplied keyspace if it has
+// one that differs from the observed one and descriptions are stored.
+func (c *external) setDescription(ctx context.Context, cr *v1alpha1.Keyspace) error {
+	d := cr.Spec.ForProvider.Description
+	if c.descriptions == nil || d == nil || *d == cr.Status.AtProvider.Description {
+		return nil
+	}
+	err := c.db.SetDescription(ctx, *c.descriptions, cassandra.KindKeyspace, meta.GetExternalName(cr), *d, cr.GetName())
+	return errors.Wrap(err, errSetDescription)
+}
+
+// datacenterDrift returns the drifted fields of the supplied observed keyspace
+// with automatic datacenter replication. Unless its policy ignores them,
+// changes of the datacenters of the cluster are described separately.
+func (c *external) datacenterDrift(ctx context.Context, cr *v1alpha1.Keyspace) ([]drift.Field, string, error) {
+	observed := cr.Status.AtProvider
+	fields := drift.AutoReplication(&cr.Spec.ForProvider, observed)
+	if cr.Spec.ForProvider.AutoDatacenterReplication.Policy() == v1alpha1.TopologyIgnore || observed.ReplicationClass != render.NetworkTopologyStrategy {
+		return fields, "", nil
+	}
+	dcs, err := c.db.Datacenters(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, errSelectDCs)
+	}
+	return fields, drift.Topology(observed, dcs), nil
+}
+
+// datacenters returns the datacenters the supplied keyspace with automatic
+// datacenter replication is replicated to when it is altered: those it is
+// observed to replicate to, or those of the cluster if its policy follows them
+// or it is not replicated per datacenter yet.
+func (c *external) datacenters(ctx context.Context, cr *v1alpha1.Keyspace) ([]string, error) {
+	observed := cr.Status.AtProvider
+	if cr.Spec.ForProvider.AutoDatacenterReplication.Policy() == v1alpha1.TopologyFollow || observed.ReplicationClass != render.NetworkTopologyStrategy {
+		dcs, err := c.db.Datacenters(ctx)
+		return dcs, errors.Wrap(err, errSelectDCs)
+	}
+	dcs := make([]string, 0, len(observed.Replication))
+	for dc := range observed.Replication {
+		dcs = append(dcs, dc)
+	}
+	sort.Strings(dcs)
+	return dcs, nil
+}
+
+// replicationOptions returns the supplied replication options without the
+// replication class.
+func replicationOptions(replication map[string]string) map[string]string {
+	opts := make(map[string]string, len(replication))
+	for k, v := range replication {
+		if k != "class" {
+			opts[k] = v
+		}
+	}
+	return opts
+}
+
+// adopt returns whether the supplied existing keyspace may be adopted by the
+// supplied resource, i.e. whether it either allows adoption or created the
+// keyspace itself.
+func adopt(ifNotExists *bool, cr resource.Managed) bool {
+	return ifNotExists == nil || *ifNotExists || !meta.GetExternalCreateSucceeded(cr).IsZero()
+}
+
+// checkQuota returns an error and sets the Quota condition if creating the
+// keyspace would exceed the quota of its ProviderConfig.
+func (c *external) checkQuota(ctx context.Context, cr *v1alpha1.Keyspace, rf int) error {
+	if c.quota == nil || (c.quota.MaxKeyspaces == nil && c.quota.MaxTotalReplicationFactor == nil) {
+		return nil
+	}
+
+	ok, err := quota.Within(ctx, c.kube, cr, v1alpha1.KeyspaceKind, c.quota.MaxKeyspaces)
+	if err != nil {
+		return errors.Wrap(err, errCheckQuota)
+	}
+	if !ok {
+		cr.SetConditions(v1alpha1.QuotaExceeded(fmt.Sprintf("ProviderConfig %q allows at most %d keyspaces", cr.GetProviderConfigReference().Name, *c.quota.MaxKeyspaces)))
+		return errors.New(errQuotaExceeded)
+	}
+
+	ok, err = quota.WithinReplication(ctx, c.kube, cr, rf, c.quota.MaxTotalReplicationFactor)
+	if err != nil {
+		return errors.Wrap(err, errCheckQuota)
+	}
+	if !ok {
+		cr.SetConditions(v1alpha1.QuotaExceeded(fmt.Sprintf("ProviderConfig %q allows a total replication factor of at most %d across keyspaces", cr.GetProviderConfigReference().Name, *c.quota.MaxTotalReplicationFactor)))
+		return errors.New(errQuotaExceeded)
+	}
+
+	cr.SetConditions(v1alpha1.WithinQuota())
+	return nil
+}
+
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mg.(*v1alpha1.Keyspace)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotKeyspace)
 	}
 
+	// Settings that are not specified keep their observed values.
 	params := cr.Spec.ForProvider
+	observed := cr.Status.AtProvider
 	strategy := defaultStrategy
+	if observed.ReplicationClass != "" {
+		strategy = observed.ReplicationClass
+	}
 	if params.ReplicationClass != nil {
 		strategy = *params.ReplicationClass
 	}
 
 	replicationFactor := defaultReplicas
+	if observed.ReplicationFactor != 0 {
+		replicationFactor = observed.ReplicationFactor
+	}
 	if params.ReplicationFactor != nil {
 		replicationFactor = *params.ReplicationFactor
 	}
 
-	durableWrites := true
+	transientReplicas := observed.TransientReplicas
+	if params.TransientReplicas != nil {
+		transientReplicas = *params.TransientReplicas
+	}
+
+	alter := cql.AlterKeyspace(meta.GetExternalName(cr))
+	if params.AutoDatacenterReplication != nil {
+		dcs, err := c.datacenters(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		alter.Replication(render.Replication(cr, dcs))
+	} else {
+		alter.Replication(strategy, map[string]string{"replication_factor": cassandra.FormatReplicationFactor(replicationFactor, transientReplicas)})
+	}
+
+	// Durable writes are left as they are if they are neither specified nor
+	// observed.
+	durableWrites := observed.DurableWrites
 	if params.DurableWrites != nil {
-		durableWrites = *params.DurableWrites
+		durableWrites = params.DurableWrites
+	}
+	if durableWrites != nil {
+		alter.DurableWrites(*durableWrites)
 	}
+	query := alter.String()
 
-	query := "ALTER KEYSPACE " + cassandra.QuoteIdentifier(meta.GetExternalName(cr)) +
-		" WITH replication = {'class': '" + strategy + "', 'replication_factor': " + strconv.Itoa(replicationFactor) + "} AND durable_writes = " + strconv.FormatBool(durableWrites)
+	if err := c.pacer.Check(ctx, c.db); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalUpdate{}, errors.New(errUpdateKeyspace + ": " + err.Error())
+	if err := c.db.CheckSchemaAgreement(ctx); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSchemaAgree)
+	}
+
+	if autoCorrect(cr) {
+		if err := c.db.Exec(ctx, query); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateKeyspace)
+		}
+		cr.Status.AtProvider.LastOperation = &v1alpha1.KeyspaceOperation{Statement: query, Time: metav1.Now()}
+	}
+
+	if err := c.setDescription(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Only the template objects that are missing are created, rather than
+	// replaying the whole template at every update.
+	missing, err := c.missingSchema(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	for _, stmt := range missing.Statements(meta.GetExternalName(cr)) {
+		if err := c.db.Exec(ctx, stmt); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errBootstrap)
+		}
 	}
 
 	return managed.ExternalUpdate{}, nil
 }
 
+// checkOwnership returns an error and sets the Ownership condition if other
+// Keyspaces manage the same keyspace, so that they do not fight over its
+// settings. Deleted Keyspaces are not checked so that they can be deleted.
+func (c *external) checkOwnership(ctx context.Context, cr *v1alpha1.Keyspace) error {
+	if meta.WasDeleted(cr) {
+		return nil
+	}
+
+	others, err := ownership.Conflicts(ctx, c.kube, cr, &v1alpha1.KeyspaceList{})
+	if err != nil {
+		return errors.Wrap(err, errCheckOwnership)
+	}
+	if len(others) > 0 {
+		cr.SetConditions(v1alpha1.ConflictingOwnership(fmt.Sprintf("keyspace %q of ProviderConfig %q is also managed by Keyspaces %s",
+			meta.GetExternalName(cr), cr.GetProviderConfigReference().Name, strings.Join(others, ", "))))
+		return errors.New(errConflict)
+	}
+
+	if cr.GetCondition(v1alpha1.TypeOwnership).Status != corev1.ConditionUnknown {
+		cr.SetConditions(v1alpha1.ExclusiveOwnership())
+	}
+	return nil
+}
+
+// checkPrivileges sets the Authorized condition when err was returned because
+// the ProviderConfig credentials may not read the schema of the supplied
+// keyspace, and clears it once they may.
+func checkPrivileges(cr *v1alpha1.Keyspace, err error) {
+	if cassandra.IsUnauthorized(err) {
+		cr.SetConditions(v1alpha1.InsufficientPrivileges(fmt.Sprintf(
+			"the credentials of ProviderConfig %q may not read the schema of keyspace %q: grant them SELECT on KEYSPACE system_schema or DESCRIBE on the keyspace",
+			cr.GetProviderConfigReference().Name, meta.GetExternalName(cr))))
+		return
+	}
+	if err == nil && cr.GetCondition(v1alpha1.TypeAuthorized).Reason == v1alpha1.ReasonInsufficientPrivileges {
+		cr.SetConditions(v1alpha1.Authorized())
+	}
+}
+
+// autoCorrect returns whether drift of the supplied keyspace is corrected.
+func autoCorrect(cr *v1alpha1.Keyspace) bool {
+	p := cr.Spec.ForProvider.AutoCorrectDrift
+	return p == nil || *p
+}
+
+// missingSchema returns the template objects that are missing from the
+// supplied keyspace, which are none unless it is bootstrapped from a template.
+func (c *external) missingSchema(ctx context.Context, cr *v1alpha1.Keyspace) (*cassandra.Schema, error) {
+	from := cr.Spec.ForProvider.BootstrapFrom
+	if from == nil {
+		return &cassandra.Schema{}, nil
+	}
+
+	tmpl, err := c.templateSchema(ctx, *from)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := c.db.KeyspaceSchema(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectSchema)
+	}
+
+	return tmpl.Without(s), nil
+}
+
+// templateSchema returns the schema of the named template keyspace.
+func (c *external) templateSchema(ctx context.Context, name string) (*cassandra.Schema, error) {
+	exists, err := c.db.KeyspaceExists(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, errSelectKeyspace)
+	}
+	if !exists {
+		return nil, errors.Errorf("%s: %q", errNoTemplate, name)
+	}
+
+	s, err := c.db.KeyspaceSchema(ctx, name)
+	return s, errors.Wrap(err, errSelectSchema)
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.Keyspace)
 	if !ok {
 		return errors.New(errNotKeyspace)
 	}
 
-	query := "DROP KEYSPACE IF EXISTS " + cassandra.QuoteIdentifier(meta.GetExternalName(cr))
+	query := cql.DropKeyspace(meta.GetExternalName(cr))
 	if err := c.db.Exec(ctx, query); err != nil {
-		return errors.New(errDropKeyspace + ": " + err.Error())
+		if cassandra.IsTimeout(err) {
+			c.inflight.Start(cr.GetUID(), opDrop)
+		}
+		return errors.Wrap(err, errDropKeyspace)
+	}
+
+	if c.descriptions != nil {
+		err := c.db.DeleteDescription(ctx, *c.descriptions, cassandra.KindKeyspace, meta.GetExternalName(cr))
+		return errors.Wrap(err, errDropDescription)
 	}
 
 	return nil
 }
 
-func upToDate(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) bool {
-	if observed.ReplicationClass == nil || desired.ReplicationClass == nil || *observed.ReplicationClass != *desired.ReplicationClass {
-		return false
+// ready returns the Ready condition of an existing keyspace. Keyspaces that
+// await their schema in all datacenters are not ready until a node of every
+// datacenter reports the schema version of the connected node.
+func (c *external) ready(ctx context.Context, cr *v1alpha1.Keyspace) (xpv1.Condition, error) {
+	if !ptr.Deref(cr.Spec.ForProvider.AwaitSchemaInAllDatacenters, false) {
+		return xpv1.Available(), nil
 	}
-	if observed.ReplicationFactor == nil || desired.ReplicationFactor == nil || *observed.ReplicationFactor != *desired.ReplicationFactor {
-		return false
+	nodes, err := c.db.SchemaVersions(ctx)
+	if err != nil {
+		return xpv1.Condition{}, errors.Wrap(err, errSelectVersions)
 	}
-	if observed.DurableWrites == nil || desired.DurableWrites == nil || *observed.DurableWrites != *desired.DurableWrites {
-		return false
+	if len(nodes) == 0 {
+		return xpv1.Available(), nil
 	}
-	return true
+	if dcs := cassandra.DatacentersWithout(nodes, nodes[0].Version); len(dcs) > 0 {
+		return v1alpha1.SchemaPropagating("schema is not yet visible in datacenters " + strings.Join(dcs, ", ")), nil
+	}
+	return xpv1.Available(), nil
 }
 
-func lateInit(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) bool {
-	li := false
-
-	if desired.ReplicationClass == nil {
-		desired.ReplicationClass = observed.ReplicationClass
-		li = true
+// pollInterval observes keyspaces whose schema is propagating more often, so
+// that they become ready soon after it reached every datacenter.
+func pollInterval(mg resource.Managed, interval time.Duration) time.Duration {
+	if mg.GetCondition(xpv1.TypeReady).Reason == v1alpha1.ReasonSchemaPropagating && interval > schemaPollInterval {
+		return schemaPollInterval
 	}
-	if desired.ReplicationFactor == nil {
-		desired.ReplicationFactor = observed.ReplicationFactor
-		li = true
+	return interval
+}
+
+func lateInit(observed *v1alpha1.KeyspaceParameters, desired *v1alpha1.KeyspaceParameters) *lateinit.Fields {
+	f := lateinit.New(desired.LateInitializePolicy)
+
+	// The replication of keyspaces that are replicated to every datacenter
+	// follows from their datacenters.
+	auto := desired.AutoDatacenterReplication != nil
+	if !auto {
+		lateinit.Pointer(f, "replicationClass", &desired.ReplicationClass, observed.ReplicationClass)
+		lateinit.Pointer(f, "replicationFactor", &desired.ReplicationFactor, observed.ReplicationFactor)
 	}
-	if desired.DurableWrites == nil {
-		desired.DurableWrites = observed.DurableWrites
-		li = true
+	// Keyspaces without transient replicas are the norm, so their spec is not
+	// cluttered with a zero.
+	if !auto && observed.TransientReplicas != nil && *observed.TransientReplicas > 0 {
+		lateinit.Pointer(f, "transientReplicas", &desired.TransientReplicas, observed.TransientReplicas)
 	}
+	lateinit.Pointer(f, "durableWrites", &desired.DurableWrites, observed.DurableWrites)
 
-	return li
+	return f
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	cr := &v1alpha1.Keyspace{Spec: v1alpha1.KeyspaceSpec{ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}}}}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		usage  resource.Tracker
+		mg     resource.Managed
+		want   error
+	}{
+		"NotKeyspace": {
+			reason: "Connecting for a managed resource that is not a Keyspace should return an error.",
+			mg:     &v1alpha1.Role{},
+			want:   errors.New(errNotKeyspace),
+		},
+		"ErrTrackUsage": {
+			reason: "Errors tracking the usage of the ProviderConfig should be returned.",
+			usage:  resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ErrGetProviderConfig": {
+			reason: "A missing ProviderConfig should return an error.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     cr,
+			want:   errors.Wrap(errBoom, errGetPC),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			usage := tc.usage
+			if usage == nil {
+				usage = resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil })
+			}
+			c := &connector{kube: tc.kube, usage: usage}
+			_, err := c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		condition xpv1.Condition
+		interval  time.Duration
+		want      time.Duration
+	}{
+		"Available": {
+			reason:    "Available keyspaces should be observed at the poll interval.",
+			condition: xpv1.Available(),
+			interval:  10 * time.Minute,
+			want:      10 * time.Minute,
+		},
+		"SchemaPropagating": {
+			reason:    "Keyspaces whose schema is propagating should be observed more often.",
+			condition: v1alpha1.SchemaPropagating("schema is not yet visible in datacenters dc2"),
+			interval:  10 * time.Minute,
+			want:      schemaPollInterval,
+		},
+		"ShortInterval": {
+			reason:    "Poll intervals shorter than the schema poll interval should be kept.",
+			condition: v1alpha1.SchemaPropagating("schema is not yet visible in datacenters dc2"),
+			interval:  time.Second,
+			want:      time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Keyspace{}
+			cr.SetConditions(tc.condition)
+			if diff := cmp.Diff(tc.want, pollInterval(cr, tc.interval)); diff != "" {
+				t.Errorf("\n%s\npollInterval(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
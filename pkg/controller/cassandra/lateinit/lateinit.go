@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lateinit late initializes the parameters of Cassandra managed
+// resources, and counts the spec updates it causes so that churn after a
+// restart of the provider can be monitored.
+package lateinit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+var writes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cassandra_late_init_writes_total",
+	Help: "Spec updates of Cassandra managed resources caused by late initialization.",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(writes)
+}
+
+// Fields late initializes the parameters of a resource under its policy,
+// and records which it changed.
+type Fields struct {
+	policy *v1alpha1.LateInitializePolicy
+	names  []string
+}
+
+// New returns Fields that late initialize parameters the supplied policy
+// allows.
+func New(policy *v1alpha1.LateInitializePolicy) *Fields {
+	return &Fields{policy: policy}
+}
+
+// Pointer sets the named parameter to the observed value if it is unset,
+// the value was observed and the policy allows it. Parameters are only
+// reported as changed if they were set, so that resources whose server
+// reports no value are not written at every observation.
+func Pointer[T any](f *Fields, name string, desired **T, observed *T) {
+	if *desired != nil || observed == nil || !f.policy.Allows(name) {
+		return
+	}
+	*desired = observed
+	f.names = append(f.names, name)
+}
+
+// Names returns the names of the parameters that were late initialized.
+func (f *Fields) Names() []string {
+	return f.names
+}
+
+// Record counts a spec update of a resource of the supplied kind if any
+// parameter was late initialized, and returns whether one was.
+func (f *Fields) Record(kind string) bool {
+	if len(f.names) == 0 {
+		return false
+	}
+	writes.WithLabelValues(kind).Inc()
+	return true
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lateinit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestPointer(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		policy   *v1alpha1.LateInitializePolicy
+		desired  *int
+		observed *int
+		want     *int
+		names    []string
+	}{
+		"Unset": {
+			reason:   "Unset parameters should be late initialized with the observed value.",
+			observed: ptr.To(3),
+			want:     ptr.To(3),
+			names:    []string{"replicationFactor"},
+		},
+		"Set": {
+			reason:   "Set parameters should be kept.",
+			desired:  ptr.To(1),
+			observed: ptr.To(3),
+			want:     ptr.To(1),
+		},
+		"NotObserved": {
+			reason: "Parameters whose value was not observed should not be reported as changed.",
+		},
+		"NotAllowed": {
+			reason:   "Parameters the policy does not allow should be left unset.",
+			policy:   &v1alpha1.LateInitializePolicy{Mode: ptr.To(v1alpha1.LateInitializeNone)},
+			observed: ptr.To(3),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := New(tc.policy)
+			Pointer(f, "replicationFactor", &tc.desired, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.desired); diff != "" {
+				t.Errorf("\n%s\nPointer(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.names, f.Names()); diff != "" {
+				t.Errorf("\n%s\nNames(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecord(t *testing.T) {
+	f := New(nil)
+	if f.Record("Test") {
+		t.Errorf("Record(...): want false without late initialized parameters")
+	}
+	Pointer(f, "login", new(*bool), ptr.To(true))
+	if !f.Record("Test") {
+		t.Errorf("Record(...): want true with late initialized parameters")
+	}
+	if got := testutil.ToFloat64(writes.WithLabelValues("Test")); got != 1 {
+		t.Errorf("Record(...): want 1 write, got %v", got)
+	}
+}
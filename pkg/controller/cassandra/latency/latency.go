@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package latency exposes the duration of the statements Cassandra managed
+// resources execute as metrics. Resources may be identified by a label of
+// bounded cardinality, and by exemplars that carry their kind and name.
+package latency
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// Path of the endpoint of the metrics server that serves metrics in the
+// OpenMetrics format, which is required to expose exemplars.
+const Path = "/metrics/openmetrics"
+
+var duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "cassandra_statement_duration_seconds",
+	Help:    "Duration of the CQL statements executed by Cassandra managed resources.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"provider_config", "kind", "operation", "resource"})
+
+func init() {
+	metrics.Registry.MustRegister(duration)
+}
+
+// A Recorder records the duration of the statements of managed resources.
+type Recorder struct {
+	allow     []*regexp.Regexp
+	buckets   uint32
+	exemplars bool
+}
+
+// An Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithAllowList labels the statements of resources whose names match any of
+// the supplied expressions with their name.
+func WithAllowList(allow []*regexp.Regexp) Option {
+	return func(r *Recorder) {
+		r.allow = allow
+	}
+}
+
+// WithHashBuckets labels the statements of resources whose names are not
+// allowed with one of the supplied number of buckets their kind and name hash
+// to, which bounds the number of series while keeping noisy resources apart.
+func WithHashBuckets(n int) Option {
+	return func(r *Recorder) {
+		if n > 0 {
+			r.buckets = uint32(n)
+		}
+	}
+}
+
+// WithExemplars attaches the kind and name of the resource that executed a
+// statement to its observation as an exemplar.
+func WithExemplars() Option {
+	return func(r *Recorder) {
+		r.exemplars = true
+	}
+}
+
+// NewRecorder returns a Recorder. Statements are not labelled with their
+// resource unless it is configured to.
+func NewRecorder(o ...Option) *Recorder {
+	r := &Recorder{}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
+}
+
+// Default is the Recorder of the provider.
+var Default = NewRecorder()
+
+// Observer returns a latency observer that records the statements executed
+// for the supplied kind and name of resource, using the named
+// ProviderConfig.
+func (r *Recorder) Observer(providerConfig, kind, name string) cassandra.LatencyObserver {
+	resource := r.resource(kind, name)
+	exemplar := Exemplar(kind, name)
+	return func(operation string, d time.Duration) {
+		o := duration.WithLabelValues(providerConfig, kind, operation, resource)
+		if e, ok := o.(prometheus.ExemplarObserver); ok && r.exemplars {
+			e.ObserveWithExemplar(d.Seconds(), exemplar)
+			return
+		}
+		o.Observe(d.Seconds())
+	}
+}
+
+// resource returns the value of the resource label of the supplied kind and
+// name of resource: its name if it is allowed, the bucket it hashes to if
+// names are hashed, and nothing otherwise.
+func (r *Recorder) resource(kind, name string) string {
+	for _, re := range r.allow {
+		if re.MatchString(name) {
+			return name
+		}
+	}
+	if r.buckets == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(kind + "/" + name))
+	return fmt.Sprintf("bucket-%d", h.Sum32()%r.buckets)
+}
+
+// Exemplar returns the labels of the exemplars of the supplied kind and name
+// of resource. Names are truncated to keep the labels within the 128
+// characters exemplars may carry.
+func Exemplar(kind, name string) prometheus.Labels {
+	limit := prometheus.ExemplarMaxRunes - len("kind") - utf8.RuneCountInString(kind) - len("name")
+	if utf8.RuneCountInString(name) > limit {
+		name = string([]rune(name)[:max(limit, 0)])
+	}
+	return prometheus.Labels{"kind": kind, "name": name}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestResource(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      []Option
+		name   string
+		want   string
+	}{
+		"NotLabelled": {
+			reason: "Resources should not be labelled by default.",
+			name:   "app",
+			want:   "",
+		},
+		"Allowed": {
+			reason: "Resources whose names are allowed should be labelled with their name.",
+			o:      []Option{WithAllowList([]*regexp.Regexp{regexp.MustCompile("^tenant-")}), WithHashBuckets(16)},
+			name:   "tenant-a",
+			want:   "tenant-a",
+		},
+		"Hashed": {
+			reason: "Resources whose names are not allowed should be labelled with the bucket they hash to.",
+			o:      []Option{WithAllowList([]*regexp.Regexp{regexp.MustCompile("^tenant-")}), WithHashBuckets(16)},
+			name:   "app",
+			want:   "bucket-1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRecorder(tc.o...).resource("Role", tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nresource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExemplar(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   prometheus.Labels
+	}{
+		"Short": {
+			reason: "Short names should be kept.",
+			name:   "app",
+			want:   prometheus.Labels{"kind": "Role", "name": "app"},
+		},
+		"Long": {
+			reason: "Long names should be truncated to fit the exemplar.",
+			name:   strings.Repeat("a", 253),
+			want:   prometheus.Labels{"kind": "Role", "name": strings.Repeat("a", prometheus.ExemplarMaxRunes-12)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Exemplar("Role", tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExemplar(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			runes := 0
+			for k, v := range got {
+				runes += utf8.RuneCountInString(k) + utf8.RuneCountInString(v)
+			}
+			if runes > prometheus.ExemplarMaxRunes {
+				t.Errorf("\n%s\nExemplar(...): %d runes exceed the limit of %d", tc.reason, runes, prometheus.ExemplarMaxRunes)
+			}
+		})
+	}
+}
+
+func TestObserver(t *testing.T) {
+	NewRecorder(WithExemplars()).Observer("default", "Keyspace", "shop")(cassandra.OperationRead, 20*time.Millisecond)
+
+	m := &dto.Metric{}
+	if err := duration.WithLabelValues("default", "Keyspace", cassandra.OperationRead, "").(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("Write(...): %v", err)
+	}
+	if got := m.GetHistogram().GetSampleCount(); got != 1 {
+		t.Errorf("Observer(...): want 1 observation, got %d", got)
+	}
+	var exemplar []*dto.LabelPair
+	for _, b := range m.GetHistogram().GetBucket() {
+		if e := b.GetExemplar(); e != nil {
+			exemplar = e.GetLabel()
+		}
+	}
+	got := map[string]string{}
+	for _, l := range exemplar {
+		got[l.GetName()] = l.GetValue()
+	}
+	if diff := cmp.Diff(map[string]string{"kind": "Keyspace", "name": "shop"}, got); diff != "" {
+		t.Errorf("Observer(...): -want exemplar, +got exemplar:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify notifies a webhook of the changes Cassandra managed resources
+// make to the cluster.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+// DefaultTimeout of notifications.
+const DefaultTimeout = 5 * time.Second
+
+const (
+	errGetToken   = "cannot get notification token Secret"
+	errNoTokenKey = "notification token Secret does not contain key"
+)
+
+// Operations that are notified.
+const (
+	OperationCreate = "Create"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// An Event describes a change a managed resource made to the cluster.
+type Event struct {
+	Operation      string    `json:"operation"`
+	Kind           string    `json:"kind"`
+	Name           string    `json:"name"`
+	ExternalName   string    `json:"externalName"`
+	ProviderConfig string    `json:"providerConfig,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// A Sink POSTs events to a webhook.
+type Sink struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// NewSink returns a Sink of the webhook configured by cfg. A nil HTTP client
+// uses one with the DefaultTimeout.
+func NewSink(ctx context.Context, kube client.Client, cfg *v1alpha1.NotificationConfig, hc *http.Client) (*Sink, error) {
+	if hc == nil {
+		hc = &http.Client{Timeout: DefaultTimeout}
+	}
+	s := &Sink{url: cfg.URL, http: hc}
+
+	if ref := cfg.TokenSecretRef; ref != nil {
+		sec := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, sec); err != nil {
+			return nil, errors.Wrap(err, errGetToken)
+		}
+		token, ok := sec.Data[ref.Key]
+		if !ok {
+			return nil, errors.Errorf("%s %q", errNoTokenKey, ref.Key)
+		}
+		s.token = strings.TrimSpace(string(token))
+	}
+	return s, nil
+}
+
+// Send POSTs the supplied event to the webhook.
+func (s *Sink) Send(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("cannot encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cannot build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("notification request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification webhook responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// A Writer counts the statements that changed the cluster.
+type Writer interface {
+	Writes() uint64
+}
+
+// An External notifies a Sink of the changes its wrapped external client
+// makes. Calls that succeed without writing to the cluster, e.g. updates of
+// resources whose drift is not corrected or deletions of roles owned by
+// another Role, are not notified. Notifications are sent in the background,
+// so that a slow webhook does not hold up reconciles, and failed ones are
+// logged rather than failing the change, which has been made already.
+type External struct {
+	managed.ExternalClient
+
+	writer Writer
+	sink   *Sink
+	kind   string
+	log    logging.Logger
+	now    func() time.Time
+	sent   sync.WaitGroup
+}
+
+// Wrap returns an external client that notifies the supplied sink of the
+// changes the supplied client makes to resources of the supplied kind, as
+// counted by the supplied writer.
+func Wrap(e managed.ExternalClient, w Writer, s *Sink, kind string, log logging.Logger) *External {
+	return &External{ExternalClient: e, writer: w, sink: s, kind: kind, log: log, now: time.Now}
+}
+
+// Create the external resource and notify the sink if it was created.
+func (e *External) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	before := e.writer.Writes()
+	c, err := e.ExternalClient.Create(ctx, mg)
+	if err == nil && e.writer.Writes() > before {
+		e.notify(ctx, mg, OperationCreate)
+	}
+	return c, err
+}
+
+// Update the external resource and notify the sink if it was updated.
+func (e *External) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	before := e.writer.Writes()
+	u, err := e.ExternalClient.Update(ctx, mg)
+	if err == nil && e.writer.Writes() > before {
+		e.notify(ctx, mg, OperationUpdate)
+	}
+	return u, err
+}
+
+// Delete the external resource and notify the sink if it was deleted.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) error {
+	before := e.writer.Writes()
+	err := e.ExternalClient.Delete(ctx, mg)
+	if err == nil && e.writer.Writes() > before {
+		e.notify(ctx, mg, OperationDelete)
+	}
+	return err
+}
+
+// notify sends the event of the supplied operation in the background. It is
+// bounded by the timeout of the HTTP client of the sink rather than by the
+// supplied context, which ends with the reconcile.
+func (e *External) notify(ctx context.Context, mg resource.Managed, op string) {
+	ev := Event{
+		Operation:    op,
+		Kind:         e.kind,
+		Name:         mg.GetName(),
+		ExternalName: meta.GetExternalName(mg),
+		Time:         e.now().UTC(),
+	}
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		ev.ProviderConfig = ref.Name
+	}
+	ctx = context.WithoutCancel(ctx)
+	e.sent.Add(1)
+	go func() {
+		defer e.sent.Done()
+		if err := e.sink.Send(ctx, ev); err != nil {
+			e.log.Info("Cannot notify webhook of change", "kind", e.kind, "name", ev.Name, "operation", op, "error", err)
+		}
+	}()
+}
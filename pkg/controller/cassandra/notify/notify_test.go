@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestNewSink(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "ops", Name: "webhook"}, Key: "token"}
+
+	type want struct {
+		token string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		cfg    *v1alpha1.NotificationConfig
+		want   want
+	}{
+		"NoToken": {
+			reason: "No token should be sent if none is referenced",
+			cfg:    &v1alpha1.NotificationConfig{URL: "http://cmdb"},
+		},
+		"ErrGetToken": {
+			reason: "Errors getting the token Secret should be returned",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cfg:    &v1alpha1.NotificationConfig{URL: "http://cmdb", TokenSecretRef: ref},
+			want:   want{err: errors.Wrap(errBoom, errGetToken)},
+		},
+		"NoTokenKey": {
+			reason: "An error should be returned if the Secret lacks the token key",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			cfg:    &v1alpha1.NotificationConfig{URL: "http://cmdb", TokenSecretRef: ref},
+			want:   want{err: errors.Errorf("%s %q", errNoTokenKey, "token")},
+		},
+		"Token": {
+			reason: "The token should be read from the Secret",
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("s3cr3t\n")}
+				return nil
+			}},
+			cfg:  &v1alpha1.NotificationConfig{URL: "http://cmdb", TokenSecretRef: ref},
+			want: want{token: "s3cr3t"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSink(context.Background(), tc.kube, tc.cfg, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewSink(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if s != nil && s.token != tc.want.token {
+				t.Errorf("\n%s\nNewSink(...): want token %q, got %q\n", tc.reason, tc.want.token, s.token)
+			}
+		})
+	}
+}
+
+// A writer counts writes to a fake cluster.
+type writer uint64
+
+func (w *writer) Writes() uint64 { return uint64(*w) }
+
+func TestExternal(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var w writer
+
+	ks := &v1alpha1.Keyspace{
+		ObjectMeta: v1.ObjectMeta{Name: "shop"},
+		Spec: v1alpha1.KeyspaceSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+		},
+	}
+	meta.SetExternalName(ks, "shop_prod")
+
+	cases := map[string]struct {
+		reason string
+		client managed.ExternalClient
+		call   func(e *External) error
+		want   []Event
+	}{
+		"Create": {
+			reason: "Creations should be notified",
+			client: &managed.ExternalClientFns{CreateFn: func(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+				w++
+				return managed.ExternalCreation{}, nil
+			}},
+			call: func(e *External) error {
+				_, err := e.Create(context.Background(), ks)
+				return err
+			},
+			want: []Event{{Operation: OperationCreate, Kind: v1alpha1.KeyspaceKind, Name: "shop", ExternalName: "shop_prod", ProviderConfig: "default", Time: now}},
+		},
+		"UpdateFailed": {
+			reason: "Failed updates should not be notified",
+			client: &managed.ExternalClientFns{UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, errBoom
+			}},
+			call: func(e *External) error {
+				_, err := e.Update(context.Background(), ks)
+				if !errors.Is(err, errBoom) {
+					return errors.New("the error of the update should be returned")
+				}
+				return nil
+			},
+		},
+		"UpdateNoWrites": {
+			reason: "Updates that did not write to the cluster should not be notified",
+			client: &managed.ExternalClientFns{UpdateFn: func(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, nil
+			}},
+			call: func(e *External) error {
+				_, err := e.Update(context.Background(), ks)
+				return err
+			},
+		},
+		"Delete": {
+			reason: "Deletions should be notified",
+			client: &managed.ExternalClientFns{DeleteFn: func(context.Context, resource.Managed) error {
+				w++
+				return nil
+			}},
+			call: func(e *External) error {
+				return e.Delete(context.Background(), ks)
+			},
+			want: []Event{{Operation: OperationDelete, Kind: v1alpha1.KeyspaceKind, Name: "shop", ExternalName: "shop_prod", ProviderConfig: "default", Time: now}},
+		},
+		"DeleteNoWrites": {
+			reason: "Deletions that left the external resource alone, e.g. because another resource owns it, should not be notified",
+			client: &managed.ExternalClientFns{DeleteFn: func(context.Context, resource.Managed) error {
+				return nil
+			}},
+			call: func(e *External) error {
+				return e.Delete(context.Background(), ks)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []Event
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer s3cr3t" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				e := Event{}
+				if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				got = append(got, e)
+			}))
+			defer srv.Close()
+
+			w = 0
+			e := Wrap(tc.client, &w, &Sink{url: srv.URL, token: "s3cr3t", http: srv.Client()}, v1alpha1.KeyspaceKind, logging.NewNopLogger())
+			e.now = func() time.Time { return now }
+			if err := tc.call(e); err != nil {
+				t.Errorf("\n%s\n%v\n", tc.reason, err)
+			}
+			e.sent.Wait()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnotifications: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ownership detects Cassandra managed resources that manage the same
+// external resource, and locates the table their owners are recorded in.
+package ownership
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+const errList = "cannot list managed resources"
+
+// DefaultTable is the table owners are recorded in by default.
+const DefaultTable = "crossplane_owners"
+
+// Table returns the owner table configured by the supplied config, or nil if
+// owners are not recorded.
+func Table(cfg *v1alpha1.OwnersConfig) *cassandra.OwnerTable {
+	if cfg == nil {
+		return nil
+	}
+	t := &cassandra.OwnerTable{Keyspace: cfg.Keyspace, Table: DefaultTable}
+	if cfg.Table != nil {
+		t.Table = *cfg.Table
+	}
+	return t
+}
+
+// Conflicts returns the names of the other managed resources of the supplied
+// list's kind that use the same ProviderConfig and external name as the
+// supplied one, and thus manage the same external resource.
+func Conflicts(ctx context.Context, kube client.Client, mg resource.Managed, l resource.ManagedList) ([]string, error) {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return nil, nil
+	}
+
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errList)
+	}
+
+	var names []string
+	for _, o := range l.GetItems() {
+		if o.GetUID() == mg.GetUID() {
+			continue
+		}
+		oref := o.GetProviderConfigReference()
+		if oref == nil || oref.Name != ref.Name || meta.GetExternalName(o) != meta.GetExternalName(mg) {
+			continue
+		}
+		names = append(names, o.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestTable(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cfg    *v1alpha1.OwnersConfig
+		want   *cassandra.OwnerTable
+	}{
+		"NotConfigured": {
+			reason: "Owners should not be recorded if no table is configured.",
+		},
+		"DefaultTable": {
+			reason: "The default table should be used if none is named.",
+			cfg:    &v1alpha1.OwnersConfig{Keyspace: "ops"},
+			want:   &cassandra.OwnerTable{Keyspace: "ops", Table: DefaultTable},
+		},
+		"Table": {
+			reason: "The named table should be used.",
+			cfg:    &v1alpha1.OwnersConfig{Keyspace: "ops", Table: ptr.To("owners")},
+			want:   &cassandra.OwnerTable{Keyspace: "ops", Table: "owners"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Table(tc.cfg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTable(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	keyspace := func(name, uid, pc, external string) v1alpha1.Keyspace {
+		ks := v1alpha1.Keyspace{
+			ObjectMeta: v1.ObjectMeta{Name: name, UID: types.UID(uid)},
+			Spec: v1alpha1.KeyspaceSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: pc}},
+			},
+		}
+		meta.SetExternalName(&ks, external)
+		return ks
+	}
+	me := keyspace("me", "1", "default", "shop")
+
+	type want struct {
+		names []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     v1alpha1.Keyspace
+		want   want
+	}{
+		"NoProviderConfig": {
+			reason: "A resource without a ProviderConfig cannot conflict.",
+			mg:     v1alpha1.Keyspace{},
+		},
+		"ErrList": {
+			reason: "An error should be returned if the resources cannot be listed.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			mg:     me,
+			want:   want{err: errors.Wrap(errBoom, errList)},
+		},
+		"Conflicts": {
+			reason: "Other resources with the same ProviderConfig and external name should be returned.",
+			kube: &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+				obj.(*v1alpha1.KeyspaceList).Items = []v1alpha1.Keyspace{
+					me,
+					keyspace("twin-b", "2", "default", "shop"),
+					keyspace("other-pc", "3", "other", "shop"),
+					keyspace("other-name", "4", "default", "blog"),
+					keyspace("twin-a", "5", "default", "shop"),
+				}
+				return nil
+			}},
+			mg:   me,
+			want: want{names: []string{"twin-a", "twin-b"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			names, err := Conflicts(context.Background(), tc.kube, &tc.mg, &v1alpha1.KeyspaceList{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConflicts(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.names, names); diff != "" {
+				t.Errorf("\n%s\nConflicts(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pacing defers the schema changes of the resources of a
+// ProviderConfig while its cluster is under stress, so that a shared cluster
+// that is struggling is not burdened further by the provider.
+package pacing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// DefaultCheckInterval applies to ProviderConfigs that do not configure how
+// often the load of their cluster is read.
+const DefaultCheckInterval = 30 * time.Second
+
+var deferred = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cassandra_schema_changes_deferred_total",
+	Help: "Schema changes that were deferred because the cluster of a ProviderConfig was under stress.",
+}, []string{"provider_config"})
+
+func init() {
+	metrics.Registry.MustRegister(deferred)
+}
+
+// A StressedError is returned instead of changing the schema of a cluster
+// that is under stress.
+type StressedError struct {
+	ProviderConfig string
+	Reason         string
+	RetryAfter     time.Duration
+}
+
+func (e *StressedError) Error() string {
+	return fmt.Sprintf("cluster of ProviderConfig %q is under stress, deferring schema changes for %s: %s", e.ProviderConfig, e.RetryAfter.Round(time.Second), e.Reason)
+}
+
+// IsStressed reports whether err was returned because a cluster was under
+// stress.
+func IsStressed(err error) bool {
+	var se *StressedError
+	return errors.As(err, &se)
+}
+
+// A LoadReader reads the load of a cluster.
+type LoadReader interface {
+	Load(ctx context.Context) (cassandra.Load, error)
+}
+
+// Stressed describes the limits of the supplied config that the supplied load
+// exceeds, or returns an empty string if it exceeds none.
+func Stressed(l cassandra.Load, cfg *v1alpha1.PacingConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	var reasons []string
+	if cfg.MaxPendingCompactions != nil && l.PendingCompactions > *cfg.MaxPendingCompactions {
+		reasons = append(reasons, fmt.Sprintf("%d pending compactions exceed %d", l.PendingCompactions, *cfg.MaxPendingCompactions))
+	}
+	if cfg.MaxPendingMutations != nil && l.PendingMutations > *cfg.MaxPendingMutations {
+		reasons = append(reasons, fmt.Sprintf("%d pending mutations exceed %d", l.PendingMutations, *cfg.MaxPendingMutations))
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// A Pacer tracks the load of the cluster of a ProviderConfig.
+type Pacer struct {
+	name string
+	now  func() time.Time
+
+	mu       sync.Mutex
+	cfg      *v1alpha1.PacingConfig
+	interval time.Duration
+	load     cassandra.Load
+	readAt   time.Time
+}
+
+// Check returns a StressedError if the cluster is under stress, reading its
+// load from the supplied reader unless it was read within the check
+// interval. Clusters whose load cannot be read are not considered under
+// stress. A nil Pacer never defers schema changes.
+func (p *Pacer) Check(ctx context.Context, r LoadReader) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	cfg, interval, load, readAt := p.cfg, p.interval, p.load, p.readAt
+	p.mu.Unlock()
+	if cfg == nil {
+		return nil
+	}
+
+	if now := p.now(); now.Sub(readAt) >= interval {
+		l, err := r.Load(ctx)
+		if err != nil {
+			// Pacing protects the cluster; it never blocks schema changes
+			// of clusters that do not report their load.
+			return nil //nolint:nilerr // See above.
+		}
+		load, readAt = l, now
+		p.mu.Lock()
+		p.load, p.readAt = l, now
+		p.mu.Unlock()
+	}
+
+	reason := Stressed(load, cfg)
+	if reason == "" {
+		return nil
+	}
+	deferred.WithLabelValues(p.name).Inc()
+	return &StressedError{ProviderConfig: p.name, Reason: reason, RetryAfter: interval - p.now().Sub(readAt)}
+}
+
+// A Registry holds the pacers of ProviderConfigs. It is kept in memory and
+// shared by the controllers of all kinds, so the load of a cluster is read
+// once per check interval rather than by every resource.
+type Registry struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	pacers map[string]*Pacer
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{now: time.Now, pacers: map[string]*Pacer{}}
+}
+
+// Default is the Registry of the provider.
+var Default = NewRegistry()
+
+// For returns the pacer of the supplied ProviderConfig, configured as it
+// configures it.
+func (r *Registry) For(pc *v1alpha1.ProviderConfig) *Pacer {
+	interval := DefaultCheckInterval
+	if cfg := pc.Spec.Pacing; cfg != nil && cfg.CheckInterval != nil {
+		interval = cfg.CheckInterval.Duration
+	}
+
+	r.mu.Lock()
+	p, ok := r.pacers[pc.GetName()]
+	if !ok {
+		p = &Pacer{name: pc.GetName(), now: r.now}
+		r.pacers[pc.GetName()] = p
+	}
+	r.mu.Unlock()
+
+	p.mu.Lock()
+	p.cfg, p.interval = pc.Spec.Pacing, interval
+	p.mu.Unlock()
+	return p
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pacing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+type reader struct {
+	load  cassandra.Load
+	err   error
+	reads int
+}
+
+func (r *reader) Load(_ context.Context) (cassandra.Load, error) {
+	r.reads++
+	return r.load, r.err
+}
+
+func TestStressed(t *testing.T) {
+	cfg := &v1alpha1.PacingConfig{MaxPendingCompactions: ptr.To(100), MaxPendingMutations: ptr.To(10)}
+
+	cases := map[string]struct {
+		reason string
+		load   cassandra.Load
+		cfg    *v1alpha1.PacingConfig
+		want   string
+	}{
+		"NotConfigured": {
+			reason: "A cluster is never under stress if pacing is not configured.",
+			load:   cassandra.Load{PendingCompactions: 1000},
+			want:   "",
+		},
+		"WithinLimits": {
+			reason: "A cluster whose load is at its limits is not under stress.",
+			load:   cassandra.Load{PendingCompactions: 100, PendingMutations: 10},
+			cfg:    cfg,
+			want:   "",
+		},
+		"Exceeded": {
+			reason: "Every exceeded limit should be reported.",
+			load:   cassandra.Load{PendingCompactions: 120, PendingMutations: 11},
+			cfg:    cfg,
+			want:   "120 pending compactions exceed 100, 11 pending mutations exceed 10",
+		},
+		"Unlimited": {
+			reason: "Limits that are not set should not be enforced.",
+			load:   cassandra.Load{PendingMutations: 1000},
+			cfg:    &v1alpha1.PacingConfig{MaxPendingCompactions: ptr.To(100)},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Stressed(tc.load, tc.cfg)); diff != "" {
+				t.Errorf("\n%s\nStressed(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPacer(t *testing.T) {
+	now := time.Now()
+	r := NewRegistry()
+	r.now = func() time.Time { return now }
+	pc := &v1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: v1alpha1.ProviderConfigSpec{
+			Pacing: &v1alpha1.PacingConfig{MaxPendingCompactions: ptr.To(100), CheckInterval: &metav1.Duration{Duration: time.Minute}},
+		},
+	}
+	p := r.For(pc)
+	lr := &reader{load: cassandra.Load{PendingCompactions: 500}}
+
+	type result struct {
+		Stressed bool
+		Reads    int
+	}
+	check := func() result {
+		err := p.Check(context.Background(), lr)
+		return result{Stressed: IsStressed(err), Reads: lr.reads}
+	}
+
+	got := []result{check()}
+	now = now.Add(30 * time.Second)
+	lr.load = cassandra.Load{}
+	got = append(got, check())
+	now = now.Add(30 * time.Second)
+	got = append(got, check())
+	lr.err = errors.New("unconfigured table thread_pools")
+	now = now.Add(time.Minute)
+	got = append(got, check())
+
+	want := []result{
+		{Stressed: true, Reads: 1},
+		// The load is reused within the check interval.
+		{Stressed: true, Reads: 1},
+		// Schema changes resume once the cluster recovered.
+		{Stressed: false, Reads: 2},
+		// Clusters that do not report their load are not paced.
+		{Stressed: false, Reads: 3},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("p.Check(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestPacerNil(t *testing.T) {
+	var p *Pacer
+	if err := p.Check(context.Background(), &reader{}); err != nil {
+		t.Errorf("(*Pacer)(nil).Check(...): want nil error, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package parallel runs the independent statements of an observation
+// concurrently, so that observing a resource takes about one round trip to the
+// cluster rather than one per statement.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// Run calls the supplied functions concurrently, at most limit at a time, and
+// waits for all of them to return. It returns the error of the first function
+// that failed, which cancels the context passed to the others.
+func Run(ctx context.Context, limit int, fns ...func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for _, fn := range fns {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(fn func(ctx context.Context) error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx); err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+			}
+		}(fn)
+	}
+	wg.Wait()
+	return first
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallel
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestRun(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err         error
+		calls       int32
+		concurrency int32
+	}
+
+	cases := map[string]struct {
+		reason string
+		limit  int
+		fail   bool
+		n      int
+		want   want
+	}{
+		"Succeeded": {
+			reason: "Every function should be called",
+			limit:  4,
+			n:      3,
+			want:   want{calls: 3, concurrency: 3},
+		},
+		"Bounded": {
+			reason: "No more functions than the limit should run at a time",
+			limit:  2,
+			n:      5,
+			want:   want{calls: 5, concurrency: 2},
+		},
+		"Failed": {
+			reason: "The error of a failed function should be returned once every function returned",
+			limit:  1,
+			fail:   true,
+			n:      2,
+			want:   want{err: errBoom, calls: 2, concurrency: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls, running, concurrency atomic.Int32
+			// Functions block until all of those that may run at a time
+			// started, so that the observed concurrency is deterministic.
+			started := make(chan struct{})
+			var once atomic.Bool
+			fns := make([]func(ctx context.Context) error, tc.n)
+			for i := range fns {
+				fns[i] = func(_ context.Context) error {
+					n := running.Add(1)
+					defer running.Add(-1)
+					calls.Add(1)
+					for {
+						c := concurrency.Load()
+						if n <= c || concurrency.CompareAndSwap(c, n) {
+							break
+						}
+					}
+					if n == int32(min(tc.limit, tc.n)) && once.CompareAndSwap(false, true) {
+						close(started)
+					}
+					<-started
+					if tc.fail {
+						return errBoom
+					}
+					return nil
+				}
+			}
+
+			err := Run(context.Background(), tc.limit, fns...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got := want{err: tc.want.err, calls: calls.Load(), concurrency: concurrency.Load()}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRun(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package passwords generates the passwords of Cassandra roles.
+package passwords
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/backoff"
+)
+
+const errGenerate = "cannot generate password"
+
+// Generation is attempted a few times before it fails, since reading from
+// the entropy source may fail transiently, e.g. while the kernel's pool is
+// initialized.
+var retry = backoff.Backoff{Initial: 100 * time.Millisecond, Factor: 2, Steps: 3}
+
+// A Generator generates passwords.
+type Generator struct {
+	settings password.Settings
+	entropy  io.Reader
+	retry    *backoff.Retrier
+}
+
+// NewGenerator returns a Generator of passwords as configured by the supplied
+// config, read from the supplied entropy source. The runtime's default
+// settings apply if the config is nil and crypto/rand is read if the source
+// is nil.
+func NewGenerator(cfg *v1alpha1.PasswordConfig, entropy io.Reader) *Generator {
+	s := password.Default
+	if cfg != nil && cfg.Length != nil {
+		s.Length = *cfg.Length
+	}
+	if cfg != nil && cfg.CharacterSet != nil {
+		s.CharacterSet = *cfg.CharacterSet
+	}
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+	return &Generator{settings: s, entropy: entropy, retry: backoff.NewRetrier(retry)}
+}
+
+// Generate a password. Reading the entropy source is retried with a backoff
+// until it succeeds, it failed a few times or the supplied context is done.
+func (g *Generator) Generate(ctx context.Context) (string, error) {
+	var pw string
+	err := g.retry.Do(ctx, func() error {
+		var err error
+		pw, err = g.generate()
+		return err
+	})
+	return pw, errors.Wrap(err, errGenerate)
+}
+
+func (g *Generator) generate() (string, error) {
+	if len(g.settings.CharacterSet) == 0 {
+		return "", errors.New("character set is empty")
+	}
+	size := big.NewInt(int64(len(g.settings.CharacterSet)))
+	pw := make([]byte, g.settings.Length)
+	for i := range pw {
+		n, err := rand.Int(g.entropy, size)
+		if err != nil {
+			return "", err
+		}
+		pw[i] = g.settings.CharacterSet[n.Int64()]
+	}
+	return string(pw), nil
+}
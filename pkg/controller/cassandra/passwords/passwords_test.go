@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package passwords
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/backoff"
+)
+
+// flaky fails the first reads before it reads from r.
+type flaky struct {
+	fails int
+	r     io.Reader
+}
+
+func (f *flaky) Read(p []byte) (int, error) {
+	if f.fails > 0 {
+		f.fails--
+		return 0, errBoom
+	}
+	return f.r.Read(p)
+}
+
+var errBoom = errors.New("boom")
+
+func TestGenerate(t *testing.T) {
+	type want struct {
+		length int
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		ctx     context.Context
+		cfg     *v1alpha1.PasswordConfig
+		entropy io.Reader
+		want    want
+	}{
+		"Default": {
+			reason:  "Passwords should have the default length if none is configured",
+			ctx:     context.Background(),
+			entropy: bytes.NewReader(bytes.Repeat([]byte{1}, 1024)),
+			want:    want{length: 27},
+		},
+		"Length": {
+			reason:  "Passwords should have the configured length",
+			ctx:     context.Background(),
+			cfg:     &v1alpha1.PasswordConfig{Length: ptr.To(40), CharacterSet: ptr.To("abcdefghij")},
+			entropy: bytes.NewReader(bytes.Repeat([]byte{1}, 1024)),
+			want:    want{length: 40},
+		},
+		"TransientFailure": {
+			reason:  "Generation should be retried if reading the entropy source fails",
+			ctx:     context.Background(),
+			entropy: &flaky{fails: 2, r: bytes.NewReader(bytes.Repeat([]byte{1}, 1024))},
+			want:    want{length: 27},
+		},
+		"PersistentFailure": {
+			reason:  "Generation should fail with a wrapped error if reading the entropy source keeps failing",
+			ctx:     context.Background(),
+			entropy: &flaky{fails: retry.Steps},
+			want:    want{err: errors.Wrap(errBoom, errGenerate)},
+		},
+		"ContextDone": {
+			reason: "Generation should not be retried once the context is done",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			}(),
+			entropy: &flaky{fails: 1, r: bytes.NewReader(bytes.Repeat([]byte{1}, 1024))},
+			want:    want{err: errors.Wrap(errBoom, errGenerate)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewGenerator(tc.cfg, tc.entropy)
+			g.retry = backoff.NewRetrier(retry, backoff.WithClock(backoff.NewFakeClock(time.Time{})))
+			pw, err := g.Generate(tc.ctx)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.length, len(pw)); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want length, +got length:\n%s\n", tc.reason, diff)
+			}
+			if cs := ptr.Deref(ptr.Deref(tc.cfg, v1alpha1.PasswordConfig{}).CharacterSet, ""); cs != "" && strings.Trim(pw, cs) != "" {
+				t.Errorf("\n%s\nGenerate(...): password %q has characters outside of %q", tc.reason, pw, cs)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight validates Cassandra ProviderConfigs when the provider
+// starts, so that misconfigurations surface before the first managed resource
+// using them is reconciled.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+)
+
+const (
+	errListPCs     = "cannot list ProviderConfigs"
+	errEmptyHost   = "endpoint lists an empty host"
+	errInvalidPort = "credentials Secret has an invalid port"
+	errConnect     = "cannot connect to cluster"
+	errNoKey       = "credentials Secret has no %s, which password authentication requires unless the cluster does not authenticate clients"
+)
+
+// valid is whether each ProviderConfig passed the last validation.
+var valid = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cassandra_provider_config_valid",
+	Help: "Whether a Cassandra ProviderConfig passed validation when the provider started (1) or not (0).",
+}, []string{"provider_config"})
+
+func init() {
+	metrics.Registry.MustRegister(valid)
+}
+
+// A Result of validating a ProviderConfig.
+type Result struct {
+	// Name of the ProviderConfig.
+	Name string
+
+	// Err is why the ProviderConfig is invalid, or nil if it is valid.
+	Err error
+}
+
+// A Validator validates ProviderConfigs.
+type Validator struct {
+	kube      client.Client
+	connect   bool
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+}
+
+// NewValidator returns a Validator that reads ProviderConfigs and their
+// Secrets using the supplied client. If connect is true it also connects to
+// each cluster.
+func NewValidator(kube client.Client, connect bool) *Validator {
+	return &Validator{kube: kube, connect: connect, newClient: cassandra.New}
+}
+
+// ValidateAll validates every ProviderConfig and publishes the results as
+// metrics.
+func (v *Validator) ValidateAll(ctx context.Context) ([]Result, error) {
+	pcs := &v1alpha1.ProviderConfigList{}
+	if err := v.kube.List(ctx, pcs); err != nil {
+		return nil, errors.Wrap(err, errListPCs)
+	}
+
+	valid.Reset()
+	results := make([]Result, len(pcs.Items))
+	for i := range pcs.Items {
+		pc := &pcs.Items[i]
+		results[i] = Result{Name: pc.GetName(), Err: v.Validate(ctx, pc)}
+		ok := 0.0
+		if results[i].Err == nil {
+			ok = 1
+		}
+		valid.WithLabelValues(pc.GetName()).Set(ok)
+	}
+	return results, nil
+}
+
+// Validate returns an error if none of the identities of the supplied
+// ProviderConfig can be used to connect to its cluster.
+func (v *Validator) Validate(ctx context.Context, pc *v1alpha1.ProviderConfig) error {
+	if !v.connect {
+		_, err := v.details(ctx, pc)
+		return err
+	}
+
+	db, err := connection.Connect(ctx, v.kube, pc, breaker.Default.For(pc), func(d connection.Details) (*cassandra.CassandraDB, error) {
+		if err := checkEndpoint(d.Credentials); err != nil {
+			return nil, err
+		}
+		db := v.newClient(d.Credentials, "", cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator))
+		if _, _, err := db.Identity(ctx); err != nil {
+			db.Close()
+			return nil, errors.Wrap(err, errConnect)
+		}
+		return db, nil
+	})
+	if err != nil {
+		return err
+	}
+	db.Close()
+	return nil
+}
+
+// Warnings returns why the credentials of the supplied ProviderConfig may not
+// be usable to connect to its cluster: none of its identities can be loaded
+// or have an endpoint that can be parsed, or the first that can lacks the
+// username or password that password authentication requires. Unlike
+// Validate it never connects, and it does not treat missing keys as errors
+// because clusters that don't authenticate clients don't need them.
+func (v *Validator) Warnings(ctx context.Context, pc *v1alpha1.ProviderConfig) []string {
+	d, err := v.details(ctx, pc)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if m := pc.Spec.AuthMechanism; m != nil && *m != cassandra.AuthPassword {
+		return nil
+	}
+
+	var warnings []string
+	for _, k := range []string{xpv1.ResourceCredentialsSecretUserKey, xpv1.ResourceCredentialsSecretPasswordKey} {
+		if len(d.Credentials[k]) == 0 {
+			warnings = append(warnings, fmt.Sprintf(errNoKey, k))
+		}
+	}
+	return warnings
+}
+
+// details returns the connection details of the first identity of the
+// supplied ProviderConfig whose details can be loaded and whose endpoint can
+// be parsed.
+func (v *Validator) details(ctx context.Context, pc *v1alpha1.ProviderConfig) (connection.Details, error) {
+	return connection.LoadFirst(ctx, v.kube, pc, func(d connection.Details) error {
+		return checkEndpoint(d.Credentials)
+	})
+}
+
+// checkEndpoint returns an error if the endpoint and port of the supplied
+// credentials can't be parsed the way the Cassandra client does.
+func checkEndpoint(creds map[string][]byte) error {
+	endpoint := string(creds[xpv1.ResourceCredentialsSecretEndpointKey])
+	for _, h := range strings.Split(endpoint, ",") {
+		if strings.TrimSpace(h) == "" {
+			return errors.New(errEmptyHost)
+		}
+	}
+
+	port, ok := creds[xpv1.ResourceCredentialsSecretPortKey]
+	if !ok {
+		return nil
+	}
+	if p, err := strconv.Atoi(string(port)); err != nil || p < 1 || p > 65535 {
+		return errors.Errorf("%s %q", errInvalidPort, string(port))
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestValidate(t *testing.T) {
+	errBoom := errors.New("boom")
+	secret := func(data map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		}
+	}
+	pc := func(ref *xpv1.SecretReference) *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{Credentials: v1alpha1.ProviderCredentials{ConnectionSecretRef: ref}}}
+	}
+	ref := &xpv1.SecretReference{Name: "cassandra", Namespace: "crossplane-system"}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		pc     *v1alpha1.ProviderConfig
+		want   error
+	}{
+		"NoSecretRef": {
+			reason: "A ProviderConfig without a credentials Secret should be invalid.",
+			pc:     pc(nil),
+			want:   errors.New("ProviderConfig does not reference a credentials Secret"),
+		},
+		"ErrGetSecret": {
+			reason: "A ProviderConfig whose credentials Secret can't be read should be invalid.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			pc:     pc(ref),
+			want:   errors.Wrap(errBoom, "cannot get credentials Secret"),
+		},
+		"NoEndpoint": {
+			reason: "A ProviderConfig whose credentials Secret lacks an endpoint should be invalid.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"username": "admin"})},
+			pc:     pc(ref),
+			want:   errors.New("credentials Secret has no endpoint"),
+		},
+		"EmptyHost": {
+			reason: "A ProviderConfig whose endpoint lists an empty host should be invalid.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "10.0.0.1,,10.0.0.2"})},
+			pc:     pc(ref),
+			want:   errors.New(errEmptyHost),
+		},
+		"InvalidPort": {
+			reason: "A ProviderConfig whose port is not a port number should be invalid.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra", "port": "cql"})},
+			pc:     pc(ref),
+			want:   errors.Errorf("%s %q", errInvalidPort, "cql"),
+		},
+		"Failover": {
+			reason: "A ProviderConfig whose primary endpoint can't be parsed should be valid if a failover identity's can.",
+			kube: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				endpoint := "10.0.0.1,,10.0.0.2"
+				if key.Name == "dr" {
+					endpoint = "10.1.0.1"
+				}
+				obj.(*corev1.Secret).Data = map[string][]byte{"endpoint": []byte(endpoint)}
+				return nil
+			}},
+			pc: func() *v1alpha1.ProviderConfig {
+				pc := pc(ref)
+				pc.Spec.FailoverIdentities = []v1alpha1.FailoverIdentity{{Name: "dr", ConnectionSecretRef: xpv1.SecretReference{Name: "dr", Namespace: "crossplane-system"}}}
+				return pc
+			}(),
+		},
+		"Valid": {
+			reason: "A ProviderConfig with a parseable endpoint should be valid when not connecting.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "10.0.0.1, 10.0.0.2", "port": "9042"})},
+			pc:     pc(ref),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewValidator(tc.kube, false).Validate(context.Background(), tc.pc)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	cassandra.RegisterAuthenticator("GSSAPI", func(_ map[string][]byte) (gocql.Authenticator, error) {
+		return gocql.PasswordAuthenticator{}, nil
+	})
+	secret := func(data map[string]string) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.Data = map[string][]byte{}
+			for k, v := range data {
+				s.Data[k] = []byte(v)
+			}
+			return nil
+		}
+	}
+	ref := &xpv1.SecretReference{Name: "cassandra", Namespace: "crossplane-system"}
+	pc := func(mechanism *string) *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+			Credentials:   v1alpha1.ProviderCredentials{ConnectionSecretRef: ref},
+			AuthMechanism: mechanism,
+		}}
+	}
+	gssapi := "GSSAPI"
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		pc     *v1alpha1.ProviderConfig
+		want   []string
+	}{
+		"NoSecret": {
+			reason: "A credentials Secret that can't be read should be reported.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			pc:     pc(nil),
+			want:   []string{"cannot get credentials Secret: boom"},
+		},
+		"NoCredentials": {
+			reason: "A missing username and password should be reported when authenticating with a password.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra"})},
+			pc:     pc(nil),
+			want: []string{
+				fmt.Sprintf(errNoKey, xpv1.ResourceCredentialsSecretUserKey),
+				fmt.Sprintf(errNoKey, xpv1.ResourceCredentialsSecretPasswordKey),
+			},
+		},
+		"OtherMechanism": {
+			reason: "A missing username and password should not be reported for other authentication mechanisms.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra"})},
+			pc:     pc(&gssapi),
+		},
+		"Complete": {
+			reason: "Complete credentials should not be reported.",
+			kube:   &test.MockClient{MockGet: secret(map[string]string{"endpoint": "cassandra", "username": "admin", "password": "secret"})},
+			pc:     pc(nil),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewValidator(tc.kube, false).Warnings(context.Background(), tc.pc)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nWarnings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
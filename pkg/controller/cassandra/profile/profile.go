@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profile converts the execution profiles of a Cassandra
+// ProviderConfig into those of the Cassandra client.
+package profile
+
+import (
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+// Convert returns the read and write profiles configured by the supplied
+// execution profiles. Profiles that are not configured use the session
+// defaults.
+func Convert(p *v1alpha1.ExecutionProfiles) (read, write cassandra.ExecutionProfile) {
+	if p == nil {
+		return read, write
+	}
+	return convert(p.Read), convert(p.Write)
+}
+
+func convert(p *v1alpha1.ExecutionProfile) cassandra.ExecutionProfile {
+	out := cassandra.ExecutionProfile{}
+	if p == nil {
+		return out
+	}
+	if p.Consistency != nil {
+		out.Consistency = *p.Consistency
+	}
+	if p.Timeout != nil {
+		out.Timeout = p.Timeout.Duration
+	}
+	out.Retries = p.Retries
+	return out
+}
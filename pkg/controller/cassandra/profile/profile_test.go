@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestConvert(t *testing.T) {
+	type want struct {
+		read  cassandra.ExecutionProfile
+		write cassandra.ExecutionProfile
+	}
+
+	cases := map[string]struct {
+		reason   string
+		profiles *v1alpha1.ExecutionProfiles
+		want     want
+	}{
+		"NotConfigured": {
+			reason: "Session defaults should be used if no profiles are configured",
+		},
+		"ReadOnly": {
+			reason: "Writes should use the session defaults if only reads are configured",
+			profiles: &v1alpha1.ExecutionProfiles{
+				Read: &v1alpha1.ExecutionProfile{Consistency: ptr.To("LOCAL_ONE")},
+			},
+			want: want{read: cassandra.ExecutionProfile{Consistency: "LOCAL_ONE"}},
+		},
+		"Both": {
+			reason: "All fields of both profiles should be converted",
+			profiles: &v1alpha1.ExecutionProfiles{
+				Read: &v1alpha1.ExecutionProfile{
+					Consistency: ptr.To("LOCAL_QUORUM"),
+					Timeout:     &v1.Duration{Duration: 5 * time.Second},
+					Retries:     ptr.To(3),
+				},
+				Write: &v1alpha1.ExecutionProfile{
+					Consistency: ptr.To("EACH_QUORUM"),
+					Timeout:     &v1.Duration{Duration: 30 * time.Second},
+					Retries:     ptr.To(0),
+				},
+			},
+			want: want{
+				read:  cassandra.ExecutionProfile{Consistency: "LOCAL_QUORUM", Timeout: 5 * time.Second, Retries: ptr.To(3)},
+				write: cassandra.ExecutionProfile{Consistency: "EACH_QUORUM", Timeout: 30 * time.Second, Retries: ptr.To(0)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			read, write := Convert(tc.profiles)
+			if diff := cmp.Diff(tc.want.read, read); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want read, +got read:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.write, write); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want write, +got write:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
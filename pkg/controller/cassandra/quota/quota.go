@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota enforces the per-ProviderConfig quotas of Cassandra managed
+// resources using the ProviderConfigUsages that track them.
+package quota
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+const (
+	errListUsages    = "cannot list ProviderConfigUsages"
+	errListKeyspaces = "cannot list Keyspaces"
+)
+
+// Within reports whether the supplied managed resource of the supplied kind
+// fits within limit. Usages of the ProviderConfig are ranked by creation time
+// so that, when several resources are created at once, the oldest ones are
+// admitted rather than all of them being rejected. A nil limit is unlimited.
+func Within(ctx context.Context, kube client.Client, mg resource.Managed, kind string, limit *int) (bool, error) {
+	if limit == nil {
+		return true, nil
+	}
+
+	o, err := older(ctx, kube, mg, kind)
+	if err != nil {
+		return false, err
+	}
+	return len(o) < *limit, nil
+}
+
+// WithinReplication reports whether the supplied keyspace, with the supplied
+// replication factor, fits within limit together with the keyspaces of its
+// ProviderConfig that are ranked before it, as by Within. The replication
+// factor of a keyspace is its desired one, or the observed one if it does not
+// specify any; keyspaces replicated to every datacenter count their factor
+// once per observed datacenter. A nil limit is unlimited.
+func WithinReplication(ctx context.Context, kube client.Client, cr *v1alpha1.Keyspace, rf int, limit *int) (bool, error) {
+	if limit == nil {
+		return true, nil
+	}
+
+	o, err := older(ctx, kube, cr, v1alpha1.KeyspaceKind)
+	if err != nil {
+		return false, err
+	}
+
+	l := &v1alpha1.KeyspaceList{}
+	if err := kube.List(ctx, l); err != nil {
+		return false, errors.Wrap(err, errListKeyspaces)
+	}
+
+	total := rf
+	for _, ks := range l.Items {
+		if !o[ks.GetUID()] {
+			continue
+		}
+		total += replicationFactor(ks)
+	}
+	return total <= *limit, nil
+}
+
+// replicationFactor returns the replication factor the supplied keyspace
+// counts towards the quota with. Keyspaces replicated to every datacenter
+// count once per datacenter they were observed to replicate to, and at least
+// once before they were observed. Their replication has no single factor to
+// late initialize or observe.
+func replicationFactor(ks v1alpha1.Keyspace) int {
+	if auto := ks.Spec.ForProvider.AutoDatacenterReplication; auto != nil {
+		return auto.Factor * max(len(ks.Status.AtProvider.Replication), 1)
+	}
+	if p := ks.Spec.ForProvider.ReplicationFactor; p != nil {
+		return *p
+	}
+	return ks.Status.AtProvider.ReplicationFactor
+}
+
+// older returns the UIDs of the resources of the supplied kind that use the
+// ProviderConfig of the supplied managed resource and are ranked before it.
+func older(ctx context.Context, kube client.Client, mg resource.Managed, kind string) (map[types.UID]bool, error) {
+	l := &v1alpha1.ProviderConfigUsageList{}
+	if err := kube.List(ctx, l, client.MatchingLabels{xpv1.LabelKeyProviderName: mg.GetProviderConfigReference().Name}); err != nil {
+		return nil, errors.Wrap(err, errListUsages)
+	}
+
+	usages := make([]v1alpha1.ProviderConfigUsage, 0, len(l.Items))
+	for _, u := range l.Items {
+		if u.ResourceReference.Kind == kind {
+			usages = append(usages, u)
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool {
+		if !usages[i].CreationTimestamp.Equal(&usages[j].CreationTimestamp) {
+			return usages[i].CreationTimestamp.Before(&usages[j].CreationTimestamp)
+		}
+		return usages[i].Name < usages[j].Name
+	})
+
+	// If our usage is not in the cache yet we're the newest user.
+	o := make(map[types.UID]bool, len(usages))
+	for _, u := range usages {
+		if u.ResourceReference.UID == mg.GetUID() {
+			break
+		}
+		o[u.ResourceReference.UID] = true
+	}
+	return o, nil
+}
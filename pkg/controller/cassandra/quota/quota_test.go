@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestWithin(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	usage := func(kind, uid string, age time.Duration) v1alpha1.ProviderConfigUsage {
+		return v1alpha1.ProviderConfigUsage{
+			ObjectMeta: v1.ObjectMeta{Name: uid, CreationTimestamp: v1.NewTime(now.Add(-age))},
+			ProviderConfigUsage: xpv1.ProviderConfigUsage{
+				ResourceReference: xpv1.TypedReference{Kind: kind, UID: types.UID(uid)},
+			},
+		}
+	}
+	list := func(u ...v1alpha1.ProviderConfigUsage) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1alpha1.ProviderConfigUsageList).Items = u
+			return nil
+		}
+	}
+	keyspace := &v1alpha1.Keyspace{
+		ObjectMeta: v1.ObjectMeta{UID: "me"},
+		Spec: v1alpha1.KeyspaceSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+		},
+	}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		limit  *int
+		want   want
+	}{
+		"Unlimited": {
+			reason: "A nil limit should always admit the resource",
+			want:   want{ok: true},
+		},
+		"ErrList": {
+			reason: "An error should be returned if we can't list usages",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			limit:  ptr.To(1),
+			want:   want{err: errors.Wrap(errBoom, errListUsages)},
+		},
+		"OtherKindsIgnored": {
+			reason: "Usages by resources of other kinds should not count towards the limit",
+			kube: &test.MockClient{MockList: list(
+				usage(v1alpha1.RoleKind, "a", time.Hour),
+				usage(v1alpha1.RoleKind, "b", time.Hour),
+				usage(v1alpha1.KeyspaceKind, "me", time.Minute),
+			)},
+			limit: ptr.To(1),
+			want:  want{ok: true},
+		},
+		"OlderResourcesAdmittedFirst": {
+			reason: "The resource should be rejected if older resources already use up the limit",
+			kube: &test.MockClient{MockList: list(
+				usage(v1alpha1.KeyspaceKind, "me", time.Minute),
+				usage(v1alpha1.KeyspaceKind, "a", time.Hour),
+				usage(v1alpha1.KeyspaceKind, "b", 2*time.Hour),
+			)},
+			limit: ptr.To(2),
+			want:  want{ok: false},
+		},
+		"NewerResourcesDoNotCount": {
+			reason: "The resource should be admitted if only newer resources would exceed the limit",
+			kube: &test.MockClient{MockList: list(
+				usage(v1alpha1.KeyspaceKind, "me", time.Hour),
+				usage(v1alpha1.KeyspaceKind, "a", time.Minute),
+				usage(v1alpha1.KeyspaceKind, "b", time.Second),
+			)},
+			limit: ptr.To(2),
+			want:  want{ok: true},
+		},
+		"UsageNotCached": {
+			reason: "A resource whose usage is not cached yet should be counted as the newest",
+			kube: &test.MockClient{MockList: list(
+				usage(v1alpha1.KeyspaceKind, "a", time.Minute),
+			)},
+			limit: ptr.To(1),
+			want:  want{ok: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ok, err := Within(context.Background(), tc.kube, keyspace, v1alpha1.KeyspaceKind, tc.limit)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWithin(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nWithin(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithinReplication(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	usage := func(uid string, age time.Duration) v1alpha1.ProviderConfigUsage {
+		return v1alpha1.ProviderConfigUsage{
+			ObjectMeta: v1.ObjectMeta{Name: uid, CreationTimestamp: v1.NewTime(now.Add(-age))},
+			ProviderConfigUsage: xpv1.ProviderConfigUsage{
+				ResourceReference: xpv1.TypedReference{Kind: v1alpha1.KeyspaceKind, UID: types.UID(uid)},
+			},
+		}
+	}
+	ks := func(uid string, desired *int, observed int) v1alpha1.Keyspace {
+		k := v1alpha1.Keyspace{ObjectMeta: v1.ObjectMeta{UID: types.UID(uid)}}
+		k.Spec.ForProvider.ReplicationFactor = desired
+		k.Status.AtProvider.ReplicationFactor = observed
+		return k
+	}
+	auto := func(uid string, factor int, dcs ...string) v1alpha1.Keyspace {
+		k := v1alpha1.Keyspace{ObjectMeta: v1.ObjectMeta{UID: types.UID(uid)}}
+		k.Spec.ForProvider.AutoDatacenterReplication = &v1alpha1.AutoDatacenterReplication{Factor: factor}
+		if len(dcs) > 0 {
+			k.Status.AtProvider.Replication = map[string]string{}
+		}
+		for _, dc := range dcs {
+			k.Status.AtProvider.Replication[dc] = strconv.Itoa(factor)
+		}
+		return k
+	}
+	list := func(u []v1alpha1.ProviderConfigUsage, k []v1alpha1.Keyspace, err error) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *v1alpha1.ProviderConfigUsageList:
+				l.Items = u
+			case *v1alpha1.KeyspaceList:
+				l.Items = k
+				return err
+			}
+			return nil
+		}
+	}
+	keyspace := &v1alpha1.Keyspace{
+		ObjectMeta: v1.ObjectMeta{UID: "me"},
+		Spec: v1alpha1.KeyspaceSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+		},
+	}
+
+	type want struct {
+		ok  bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		rf     int
+		limit  *int
+		want   want
+	}{
+		"Unlimited": {
+			reason: "A nil limit should always admit the keyspace",
+			rf:     3,
+			want:   want{ok: true},
+		},
+		"ErrListKeyspaces": {
+			reason: "An error should be returned if we can't list keyspaces",
+			kube:   &test.MockClient{MockList: list(nil, nil, errBoom)},
+			rf:     3,
+			limit:  ptr.To(6),
+			want:   want{err: errors.Wrap(errBoom, errListKeyspaces)},
+		},
+		"OwnFactorExceedsLimit": {
+			reason: "A keyspace whose own replication factor exceeds the limit should be rejected",
+			kube:   &test.MockClient{MockList: list(nil, nil, nil)},
+			rf:     5,
+			limit:  ptr.To(3),
+			want:   want{ok: false},
+		},
+		"OlderKeyspacesCount": {
+			reason: "The desired or observed replication factors of older keyspaces should count towards the limit",
+			kube: &test.MockClient{MockList: list(
+				[]v1alpha1.ProviderConfigUsage{usage("a", time.Hour), usage("b", time.Hour), usage("me", time.Minute)},
+				[]v1alpha1.Keyspace{ks("a", ptr.To(3), 3), ks("b", nil, 2)},
+				nil,
+			)},
+			rf:    3,
+			limit: ptr.To(7),
+			want:  want{ok: false},
+		},
+		"OlderAutoDatacenterKeyspacesCount": {
+			reason: "Older keyspaces replicated to every datacenter should count their factor once per observed datacenter",
+			kube: &test.MockClient{MockList: list(
+				[]v1alpha1.ProviderConfigUsage{usage("a", time.Hour), usage("me", time.Minute)},
+				[]v1alpha1.Keyspace{auto("a", 2, "dc1", "dc2")},
+				nil,
+			)},
+			// This keyspace replicates to the same two datacenters.
+			rf:    4,
+			limit: ptr.To(6),
+			want:  want{ok: false},
+		},
+		"UnobservedAutoDatacenterKeyspacesCount": {
+			reason: "Older keyspaces replicated to every datacenter that were not observed yet should count their factor at least once",
+			kube: &test.MockClient{MockList: list(
+				[]v1alpha1.ProviderConfigUsage{usage("a", time.Hour), usage("me", time.Minute)},
+				[]v1alpha1.Keyspace{auto("a", 2)},
+				nil,
+			)},
+			rf:    3,
+			limit: ptr.To(4),
+			want:  want{ok: false},
+		},
+		"NewerKeyspacesDoNotCount": {
+			reason: "Keyspaces ranked after this one should not count towards the limit",
+			kube: &test.MockClient{MockList: list(
+				[]v1alpha1.ProviderConfigUsage{usage("me", time.Hour), usage("a", time.Minute)},
+				[]v1alpha1.Keyspace{ks("a", ptr.To(3), 3)},
+				nil,
+			)},
+			rf:    3,
+			limit: ptr.To(3),
+			want:  want{ok: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ok, err := WithinReplication(context.Background(), tc.kube, keyspace, tc.rf, tc.limit)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWithinReplication(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Errorf("\n%s\nWithinReplication(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
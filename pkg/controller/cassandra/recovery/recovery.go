@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recovery recovers from panics of the external clients of Cassandra
+// managed resources, so that a malformed resource fails to reconcile rather
+// than crashing the provider.
+package recovery
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Operations of external clients that may panic.
+const (
+	OperationConnect = "connect"
+	OperationObserve = "observe"
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+)
+
+const errPanic = "recovered from panic during %s: %v\n%s"
+
+var panics = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cassandra_external_client_panics_total",
+	Help: "Panics recovered from while connecting to or calling the external clients of Cassandra managed resources.",
+}, []string{"kind", "operation"})
+
+func init() {
+	metrics.Registry.MustRegister(panics)
+}
+
+// A Connecter recovers from panics of the supplied connecter and of the
+// external clients it connects.
+type Connecter struct {
+	conn managed.ExternalConnecter
+	kind string
+}
+
+// NewConnecter returns a Connecter that recovers from panics of the
+// supplied connecter of resources of the supplied kind.
+func NewConnecter(c managed.ExternalConnecter, kind string) *Connecter {
+	return &Connecter{conn: c, kind: kind}
+}
+
+// Connect to the external client, recovering from panics.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (e managed.ExternalClient, err error) {
+	defer recoverTo(&err, c.kind, OperationConnect)
+	e, err = c.conn.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &External{client: e, kind: c.kind}, nil
+}
+
+// An External recovers from panics of the external client it wraps.
+type External struct {
+	client managed.ExternalClient
+	kind   string
+}
+
+// Observe the external resource, recovering from panics.
+func (e *External) Observe(ctx context.Context, mg resource.Managed) (o managed.ExternalObservation, err error) {
+	defer recoverTo(&err, e.kind, OperationObserve)
+	return e.client.Observe(ctx, mg)
+}
+
+// Create the external resource, recovering from panics.
+func (e *External) Create(ctx context.Context, mg resource.Managed) (c managed.ExternalCreation, err error) {
+	defer recoverTo(&err, e.kind, OperationCreate)
+	return e.client.Create(ctx, mg)
+}
+
+// Update the external resource, recovering from panics.
+func (e *External) Update(ctx context.Context, mg resource.Managed) (u managed.ExternalUpdate, err error) {
+	defer recoverTo(&err, e.kind, OperationUpdate)
+	return e.client.Update(ctx, mg)
+}
+
+// Delete the external resource, recovering from panics.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) (err error) {
+	defer recoverTo(&err, e.kind, OperationDelete)
+	return e.client.Delete(ctx, mg)
+}
+
+// recoverTo recovers from a panic of the supplied operation, if any, and
+// returns it as an error with the stack that panicked.
+func recoverTo(err *error, kind, operation string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	panics.WithLabelValues(kind, operation).Inc()
+	*err = errors.Errorf(errPanic, operation, r, debug.Stack())
+}
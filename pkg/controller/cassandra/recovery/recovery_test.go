@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recovery
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+	var role *string
+	client := &managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			// Dereferencing an unset pointer, as an unresolved reference would.
+			return managed.ExternalObservation{ResourceExists: *role != ""}, nil
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error {
+			return errBoom
+		},
+	}
+
+	cases := map[string]struct {
+		reason  string
+		conn    managed.ExternalConnecter
+		observe bool
+		want    string
+		panics  float64
+	}{
+		"ConnectPanics": {
+			reason: "Panics while connecting should be returned as errors.",
+			conn: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				panic("connect")
+			}),
+			want:   "recovered from panic during connect: connect",
+			panics: 1,
+		},
+		"ObservePanics": {
+			reason: "Panics of the external client should be returned as errors.",
+			conn: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return client, nil
+			}),
+			observe: true,
+			want:    "recovered from panic during observe: runtime error: invalid memory address or nil pointer dereference",
+			panics:  1,
+		},
+		"Errors": {
+			reason: "Errors of the external client should be returned as they are.",
+			conn: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				return client, nil
+			}),
+			want: errBoom.Error(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kind := "Test" + name
+			e, err := NewConnecter(tc.conn, kind).Connect(context.Background(), &v1alpha1.Grant{})
+			if err == nil {
+				if tc.observe {
+					_, err = e.Observe(context.Background(), &v1alpha1.Grant{})
+				} else {
+					err = e.Delete(context.Background(), &v1alpha1.Grant{})
+				}
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tc.want) {
+				t.Errorf("\n%s\nConnect(...): want error starting with %q, got %v\n", tc.reason, tc.want, err)
+			}
+			if tc.panics > 0 && !strings.Contains(err.Error(), "recovery_test.go") {
+				t.Errorf("\n%s\nConnect(...): want the stack that panicked, got %v\n", tc.reason, err)
+			}
+			var got float64
+			for _, op := range []string{OperationConnect, OperationObserve, OperationDelete} {
+				got += testutil.ToFloat64(panics.WithLabelValues(kind, op))
+			}
+			if got != tc.panics {
+				t.Errorf("\n%s\nConnect(...): want %v panics, got %v\n", tc.reason, tc.panics, got)
+			}
+		})
+	}
+}
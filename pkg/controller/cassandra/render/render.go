@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render renders the CQL statements that Cassandra managed resources
+// execute when they create their external resource. The reconcilers build
+// their statements with it, so manifests can be checked offline, e.g. in the
+// tests of compositions.
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+)
+
+// Defaults of keyspaces whose replication is not specified.
+const (
+	DefaultReplicationClass  = "SimpleStrategy"
+	DefaultReplicationFactor = 1
+)
+
+// NetworkTopologyStrategy is the replication class of keyspaces that are
+// replicated to every datacenter.
+const NetworkTopologyStrategy = "NetworkTopologyStrategy"
+
+// RedactedPassword stands in for the generated passwords of roles.
+const RedactedPassword = "*****"
+
+const (
+	errRead        = "cannot read manifest"
+	errDecode      = "cannot decode manifest"
+	errUnsupported = "unsupported kind"
+	errPerTable    = "grants applied to existing tables individually depend on the tables of the cluster"
+	errTarget      = "exactly one of keyspace, onRole or onAllRoles must be set"
+	errNoKeyspace  = "keyspace is not resolved"
+	errAutoDCs     = "automatic datacenter replication depends on the datacenters of the cluster"
+)
+
+// Keyspace returns the statement that creates the supplied keyspace. Keyspaces
+// with automatic datacenter replication replicate to the supplied datacenters.
+func Keyspace(cr *v1alpha1.Keyspace, datacenters []string) string {
+	p := cr.Spec.ForProvider
+	class, opts := Replication(cr, datacenters)
+	return cql.CreateKeyspace(meta.GetExternalName(cr)).
+		IfNotExists(ptr.Deref(p.IfNotExists, true)).
+		Replication(class, opts).
+		DurableWrites(ptr.Deref(p.DurableWrites, true)).
+		String()
+}
+
+// Replication returns the replication class and options of the supplied
+// keyspace. Keyspaces with automatic datacenter replication replicate to the
+// supplied datacenters with NetworkTopologyStrategy.
+func Replication(cr *v1alpha1.Keyspace, datacenters []string) (string, map[string]string) {
+	p := cr.Spec.ForProvider
+	transient := ptr.Deref(p.TransientReplicas, 0)
+	if auto := p.AutoDatacenterReplication; auto != nil {
+		opts := make(map[string]string, len(datacenters))
+		for _, dc := range datacenters {
+			opts[dc] = cassandra.FormatReplicationFactor(auto.Factor, transient)
+		}
+		return NetworkTopologyStrategy, opts
+	}
+	rf := cassandra.FormatReplicationFactor(ptr.Deref(p.ReplicationFactor, DefaultReplicationFactor), transient)
+	return ptr.Deref(p.ReplicationClass, DefaultReplicationClass), map[string]string{"replication_factor": rf}
+}
+
+// Role returns the statement that creates the supplied role with the supplied
+// password.
+func Role(cr *v1alpha1.Role, password string) string {
+	p := cr.Spec.ForProvider
+	return cql.CreateRole(meta.GetExternalName(cr)).
+		IfNotExists(ptr.Deref(p.IfNotExists, true)).
+		SuperUser(ptr.Deref(p.Privileges.SuperUser, false)).
+		Login(ptr.Deref(p.Privileges.Login, false)).
+		Password(password).
+		String()
+}
+
+// RoleMigration returns the statements that grant the supplied role what
+// another role was granted, and to the roles it was granted to, e.g. to
+// rename the other role.
+func RoleMigration(g cassandra.RoleGrants, role string) ([]string, error) {
+	stmts := make([]string, 0, len(g.MemberOf)+len(g.Members)+len(g.Permissions))
+	for _, r := range g.MemberOf {
+		stmts = append(stmts, cql.GrantRole(r, role))
+	}
+	for _, r := range g.Members {
+		stmts = append(stmts, cql.GrantRole(role, r))
+	}
+	for _, p := range g.Permissions {
+		r, err := cassandra.ParseResourceFor(cassandra.FlavorCassandra, p.Resource)
+		if err != nil {
+			return nil, err
+		}
+		for _, permission := range p.Permissions {
+			stmts = append(stmts, cql.Grant(permission, r.CQL(), role))
+		}
+	}
+	return stmts, nil
+}
+
+// GrantTargets returns the resources the supplied grant applies to: a role,
+// all roles or its keyspace. It returns no targets if the grant applies to
+// each of the existing tables of its keyspace instead, which are only known
+// to the cluster.
+func GrantTargets(cr *v1alpha1.Grant) ([]cassandra.Resource, error) {
+	p := cr.Spec.ForProvider
+	allRoles := ptr.Deref(p.OnAllRoles, false)
+	n := 0
+	for _, set := range []bool{p.Keyspace != nil, p.OnRole != nil, allRoles} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, errors.New(errTarget)
+	}
+
+	switch {
+	case allRoles:
+		return []cassandra.Resource{{Kind: cassandra.ResourceRoles}}, nil
+	case p.OnRole != nil:
+		return []cassandra.Resource{{Kind: cassandra.ResourceRoles, Role: *p.OnRole}}, nil
+	case ptr.Deref(p.ApplyToExistingTablesIndividually, false):
+		return nil, nil
+	}
+	return []cassandra.Resource{{Kind: cassandra.ResourceData, Keyspace: *p.Keyspace}}, nil
+}
+
+// Grant returns the statements that grant the privileges of the supplied
+// grant on the supplied targets. Every privilege is granted by a statement of
+// its own, since YugabyteDB does not allow granting several at once.
+func Grant(cr *v1alpha1.Grant, targets []cassandra.Resource) ([]string, error) {
+	privileges := cr.Spec.ForProvider.Privileges.ToCQL()
+	stmts := make([]string, 0, len(targets)*len(privileges))
+	for _, t := range targets {
+		if err := cassandra.ValidatePermissions(t, privileges); err != nil {
+			return nil, err
+		}
+		for _, privilege := range privileges {
+			stmts = append(stmts, cql.Grant(privilege, t.CQL(), *cr.Spec.ForProvider.Role))
+		}
+	}
+	return stmts, nil
+}
+
+// Revoke returns the statements that revoke the privileges of the supplied
+// grant from the supplied targets. Privileges that cover every permission of a
+// target are revoked by a single REVOKE ALL PERMISSIONS, which revokes nothing
+// else; otherwise every privilege is revoked by a statement of its own.
+func Revoke(cr *v1alpha1.Grant, targets []cassandra.Resource) []string {
+	privileges := cr.Spec.ForProvider.Privileges.ToCQL()
+	revoked := make(map[string]bool, len(privileges))
+	for _, p := range privileges {
+		revoked[p] = true
+	}
+
+	stmts := make([]string, 0, len(targets)*len(privileges))
+	for _, t := range targets {
+		all := revoked[cassandra.PermissionAll]
+		if !all {
+			all = true
+			for _, p := range t.Permissions() {
+				all = all && revoked[p]
+			}
+		}
+		if all {
+			stmts = append(stmts, cql.Revoke(cassandra.PermissionAll, t.CQL(), *cr.Spec.ForProvider.Role))
+			continue
+		}
+		for _, privilege := range privileges {
+			stmts = append(stmts, cql.Revoke(privilege, t.CQL(), *cr.Spec.ForProvider.Role))
+		}
+	}
+	return stmts
+}
+
+// Trigger returns the statement that creates the supplied trigger, whose
+// keyspace must be resolved.
+func Trigger(cr *v1alpha1.Trigger) string {
+	p := cr.Spec.ForProvider
+	return cql.CreateTrigger(meta.GetExternalName(cr), *p.Keyspace, p.Table, p.Class)
+}
+
+// Object returns the statements that create the external resource of the
+// supplied managed resource. Objects without an external name are named like
+// the external name initializer names them if their ProviderConfig does not
+// format external names. Passwords are redacted.
+func Object(o runtime.Object) ([]string, error) {
+	if mg, ok := o.(resource.Managed); ok && meta.GetExternalName(mg) == "" {
+		meta.SetExternalName(mg, mg.GetName())
+	}
+
+	switch cr := o.(type) {
+	case *v1alpha1.Keyspace:
+		if cr.Spec.ForProvider.AutoDatacenterReplication != nil {
+			return nil, errors.New(errAutoDCs)
+		}
+		return []string{Keyspace(cr, nil)}, nil
+	case *v1alpha1.Role:
+		return []string{Role(cr, RedactedPassword)}, nil
+	case *v1alpha1.Grant:
+		targets, err := GrantTargets(cr)
+		if err != nil {
+			return nil, err
+		}
+		if targets == nil {
+			return nil, errors.New(errPerTable)
+		}
+		return Grant(cr, targets)
+	case *v1alpha1.Trigger:
+		if cr.Spec.ForProvider.Keyspace == nil {
+			return nil, errors.New(errNoKeyspace)
+		}
+		return []string{Trigger(cr)}, nil
+	}
+	return nil, errors.Errorf("%s %s", errUnsupported, o.GetObjectKind().GroupVersionKind().Kind)
+}
+
+// Manifests returns the statements that create the external resources of the
+// Cassandra managed resources of the supplied YAML or JSON manifests, in the
+// order they are listed. The supplied scheme must know the Cassandra APIs.
+func Manifests(r io.Reader, s *runtime.Scheme) ([]string, error) {
+	dec := serializer.NewCodecFactory(s).UniversalDeserializer()
+	docs := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var stmts []string
+	for {
+		doc, err := docs.Read()
+		if errors.Is(err, io.EOF) {
+			return stmts, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, errRead)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		o, _, err := dec.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, errDecode)
+		}
+		st, err := Object(o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %s", o.GetObjectKind().GroupVersionKind().Kind, nameOf(o))
+		}
+		stmts = append(stmts, st...)
+	}
+}
+
+func nameOf(o runtime.Object) string {
+	if mg, ok := o.(resource.Object); ok {
+		return mg.GetName()
+	}
+	return ""
+}
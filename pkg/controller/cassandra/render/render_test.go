@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis"
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+)
+
+func TestReplication(t *testing.T) {
+	type want struct {
+		class string
+		opts  map[string]string
+	}
+
+	cases := map[string]struct {
+		reason      string
+		params      v1alpha1.KeyspaceParameters
+		datacenters []string
+		want        want
+	}{
+		"Default": {
+			reason: "Keyspaces should be replicated with SimpleStrategy and a factor of one by default",
+			want:   want{class: DefaultReplicationClass, opts: map[string]string{"replication_factor": "1"}},
+		},
+		"Transient": {
+			reason: "Transient replicas should be part of the replication factor",
+			params: v1alpha1.KeyspaceParameters{ReplicationClass: ptr.To(NetworkTopologyStrategy), ReplicationFactor: ptr.To(3), TransientReplicas: ptr.To(1)},
+			want:   want{class: NetworkTopologyStrategy, opts: map[string]string{"replication_factor": "3/1"}},
+		},
+		"AutoDatacenterReplication": {
+			reason:      "Keyspaces replicated to every datacenter should be replicated to each of the supplied datacenters with their factor",
+			params:      v1alpha1.KeyspaceParameters{ReplicationFactor: ptr.To(1), AutoDatacenterReplication: &v1alpha1.AutoDatacenterReplication{Factor: 3}},
+			datacenters: []string{"dc1", "dc2"},
+			want:        want{class: NetworkTopologyStrategy, opts: map[string]string{"dc1": "3", "dc2": "3"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			class, opts := Replication(&v1alpha1.Keyspace{Spec: v1alpha1.KeyspaceSpec{ForProvider: tc.params}}, tc.datacenters)
+			if diff := cmp.Diff(tc.want, want{class: class, opts: opts}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nReplication(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	keyspace := []cassandra.Resource{{Kind: cassandra.ResourceData, Keyspace: "shop"}}
+	tables := []cassandra.Resource{
+		{Kind: cassandra.ResourceData, Keyspace: "shop", Table: "orders"},
+		{Kind: cassandra.ResourceData, Keyspace: "shop", Table: "items"},
+	}
+
+	cases := map[string]struct {
+		reason     string
+		privileges v1alpha1.GrantPrivileges
+		targets    []cassandra.Resource
+		want       []string
+	}{
+		"Individual": {
+			reason:     "Privileges that do not cover every permission of a target should be revoked individually",
+			privileges: v1alpha1.GrantPrivileges{v1alpha1.GrantPrivilegeSelect, v1alpha1.GrantPrivilegeModify},
+			targets:    keyspace,
+			want: []string{
+				`REVOKE SELECT ON KEYSPACE "shop" FROM "app"`,
+				`REVOKE MODIFY ON KEYSPACE "shop" FROM "app"`,
+			},
+		},
+		"AllPermissions": {
+			reason:     "ALL PERMISSIONS should be revoked once per target",
+			privileges: v1alpha1.GrantPrivileges{v1alpha1.GrantPrivilegeAllPermissions, v1alpha1.GrantPrivilegeSelect},
+			targets:    keyspace,
+			want:       []string{`REVOKE ALL PERMISSIONS ON KEYSPACE "shop" FROM "app"`},
+		},
+		"Combined": {
+			reason: "Privileges that cover every permission of a table should be revoked by a single statement per table",
+			privileges: v1alpha1.GrantPrivileges{
+				v1alpha1.GrantPrivilegeAlter, v1alpha1.GrantPrivilegeDrop, v1alpha1.GrantPrivilegeSelect,
+				v1alpha1.GrantPrivilegeModify, v1alpha1.GrantPrivilegeAuthorize,
+			},
+			targets: tables,
+			want: []string{
+				`REVOKE ALL PERMISSIONS ON TABLE "shop"."orders" FROM "app"`,
+				`REVOKE ALL PERMISSIONS ON TABLE "shop"."items" FROM "app"`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Grant{Spec: v1alpha1.GrantSpec{ForProvider: v1alpha1.GrantParameters{Role: ptr.To("app"), Privileges: tc.privileges}}}
+			if diff := cmp.Diff(tc.want, Revoke(cr, tc.targets)); diff != "" {
+				t.Errorf("\n%s\nRevoke(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRoleMigration(t *testing.T) {
+	type want struct {
+		stmts []string
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		grants cassandra.RoleGrants
+		want   want
+	}{
+		"Nothing": {
+			reason: "A role that was granted nothing should need no statements.",
+			want:   want{stmts: []string{}},
+		},
+		"Migrated": {
+			reason: "Roles and permissions granted to the role and the roles it was granted to should be migrated.",
+			grants: cassandra.RoleGrants{
+				MemberOf: []string{"readers"},
+				Members:  []string{"admins"},
+				Permissions: []cassandra.Permission{
+					{Role: "old", Resource: "data/shop", Permissions: []string{"SELECT", "MODIFY"}},
+					{Role: "old", Resource: "roles/app", Permissions: []string{"AUTHORIZE"}},
+				},
+			},
+			want: want{stmts: []string{
+				`GRANT "readers" TO "new"`,
+				`GRANT "new" TO "admins"`,
+				`GRANT SELECT ON KEYSPACE "shop" TO "new"`,
+				`GRANT MODIFY ON KEYSPACE "shop" TO "new"`,
+				`GRANT AUTHORIZE ON ROLE "app" TO "new"`,
+			}},
+		},
+		"InvalidResource": {
+			reason: "Permissions on resources that cannot be parsed should not be migrated.",
+			grants: cassandra.RoleGrants{Permissions: []cassandra.Permission{{Role: "old", Resource: "tables/shop", Permissions: []string{"SELECT"}}}},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stmts, err := RoleMigration(tc.grants, "new")
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nRoleMigration(...): want error %t, got %v\n", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.stmts, stmts); diff != "" {
+				t.Errorf("\n%s\nRoleMigration(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManifests(t *testing.T) {
+	type want struct {
+		stmts []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		manifest string
+		want     want
+	}{
+		"Keyspace": {
+			reason: "A keyspace should be created with the default replication and named after its external name",
+			manifest: `
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: Keyspace
+metadata:
+  name: example
+  annotations:
+    crossplane.io/external-name: example_ks
+spec:
+  forProvider:
+    durableWrites: false
+`,
+			want: want{stmts: []string{
+				`CREATE KEYSPACE IF NOT EXISTS "example_ks" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '1'} AND durable_writes = false`,
+			}},
+		},
+		"Documents": {
+			reason: "The statements of every document should be rendered in order, with redacted passwords and a statement per privilege",
+			manifest: `
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: Role
+metadata:
+  name: app
+spec:
+  forProvider:
+    privileges:
+      login: true
+---
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: Grant
+metadata:
+  name: app
+spec:
+  forProvider:
+    role: app
+    keyspace: example
+    privileges:
+    - SELECT
+    - MODIFY
+`,
+			want: want{stmts: []string{
+				`CREATE ROLE IF NOT EXISTS "app" WITH SUPERUSER = false AND LOGIN = true AND PASSWORD = '*****'`,
+				`GRANT SELECT ON KEYSPACE "example" TO "app"`,
+				`GRANT MODIFY ON KEYSPACE "example" TO "app"`,
+			}},
+		},
+		"ExistingTables": {
+			reason: "Grants on each existing table cannot be rendered without the cluster",
+			manifest: `
+apiVersion: cassandra.cql.crossplane.io/v1alpha1
+kind: Grant
+metadata:
+  name: tables
+spec:
+  forProvider:
+    role: app
+    keyspace: example
+    applyToExistingTablesIndividually: true
+    privileges:
+    - SELECT
+`,
+			want: want{err: errors.Wrap(errors.New(errPerTable), "Grant tables")},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Manifests(strings.NewReader(tc.manifest), s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nManifests(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.stmts, got); diff != "" {
+				t.Errorf("\n%s\nManifests(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
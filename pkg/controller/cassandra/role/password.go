@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+)
+
+const (
+	errSelectHash  = "cannot select salted hash of role password"
+	errGetPassword = "cannot get published password"
+)
+
+// passwordDigest returns the SHA-256 digest of the salted hash of the password
+// of the named role, and whether the credentials may select it. Only the
+// digest is kept, so that the status of the Role does not disclose the hash.
+func (c *external) passwordDigest(ctx context.Context, name string) (string, bool, error) {
+	iter, err := c.db.Query(ctx, "SELECT salted_hash FROM system_auth.roles WHERE role = ?", name)
+	if err != nil {
+		return "", false, errors.Wrap(err, errSelectHash)
+	}
+	var hash string
+	iter.Scan(&hash)
+	err = iter.Close()
+	if cassandra.IsUnauthorized(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, errors.Wrap(err, errSelectHash)
+	}
+	sum := sha256.Sum256([]byte(hash))
+	return hex.EncodeToString(sum[:]), true, nil
+}
+
+// checkPassword compares the supplied digest of the salted hash of the
+// password of the supplied Role with the one observed after the provider last
+// set it, and sets the Password condition accordingly. The first digest that
+// is observed after the provider set the password is recorded. It returns
+// whether the password must be set again.
+func checkPassword(cr *v1alpha1.Role, digest string, observable bool) bool {
+	policy := cr.Spec.ForProvider.ExternalPasswordChangePolicy
+	if policy == nil {
+		cr.Status.AtProvider.PasswordHashDigest = ""
+		return false
+	}
+	if !observable {
+		cr.SetConditions(v1alpha1.PasswordUnobservable(fmt.Sprintf(
+			"the credentials of ProviderConfig %q may not select from system_auth.roles: grant them SELECT on system_auth.roles to detect password changes",
+			cr.GetProviderConfigReference().Name)))
+		return false
+	}
+
+	last := cr.Status.AtProvider.PasswordHashDigest
+	if last == "" || last == digest {
+		cr.Status.AtProvider.PasswordHashDigest = digest
+		cr.SetConditions(v1alpha1.PasswordManaged())
+		return false
+	}
+
+	reassert := *policy == v1alpha1.ExternalPasswordChangeReassert
+	msg := fmt.Sprintf("the password of role %q was changed by others than the provider", meta.GetExternalName(cr))
+	if reassert {
+		msg += "; it is set again"
+	}
+	cr.SetConditions(v1alpha1.PasswordChanged(msg))
+	return reassert
+}
+
+// reassertPassword returns whether the password of the supplied Role must be
+// set again because it was changed by others than the provider.
+func reassertPassword(cr *v1alpha1.Role) bool {
+	p := cr.Spec.ForProvider.ExternalPasswordChangePolicy
+	return p != nil && *p == v1alpha1.ExternalPasswordChangeReassert &&
+		cr.GetCondition(v1alpha1.TypePassword).Reason == v1alpha1.ReasonPasswordChanged
+}
+
+// publishedPassword returns the password published in the connection secret of
+// the supplied Role, or an empty string if none is published.
+func (c *external) publishedPassword(ctx context.Context, cr *v1alpha1.Role) (string, error) {
+	pw, err := secrets.Value(ctx, c.kube, cr, xpv1.ResourceCredentialsSecretPasswordKey)
+	return pw, errors.Wrap(err, errGetPassword)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestCheckPassword(t *testing.T) {
+	role := func(policy *string, last string) *v1alpha1.Role {
+		cr := &v1alpha1.Role{}
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "cassandra"})
+		cr.Spec.ForProvider.ExternalPasswordChangePolicy = policy
+		cr.Status.AtProvider.PasswordHashDigest = last
+		return cr
+	}
+
+	type want struct {
+		reassert bool
+		digest   string
+		reason   xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason     string
+		cr         *v1alpha1.Role
+		digest     string
+		observable bool
+		want       want
+	}{
+		"NoPolicy": {
+			reason:     "Digests should not be kept if changes are not detected.",
+			cr:         role(nil, "old"),
+			digest:     "new",
+			observable: true,
+		},
+		"Unobservable": {
+			reason: "Roles whose hash may not be selected should keep their digest and report that changes are not detected.",
+			cr:     role(ptr.To(v1alpha1.ExternalPasswordChangeAlert), "old"),
+			want:   want{digest: "old", reason: v1alpha1.ReasonPasswordUnobservable},
+		},
+		"FirstObservation": {
+			reason:     "The first digest observed after the provider set the password should be recorded.",
+			cr:         role(ptr.To(v1alpha1.ExternalPasswordChangeAlert), ""),
+			digest:     "new",
+			observable: true,
+			want:       want{digest: "new", reason: v1alpha1.ReasonPasswordManaged},
+		},
+		"Unchanged": {
+			reason:     "Passwords whose digest did not change should be reported as managed.",
+			cr:         role(ptr.To(v1alpha1.ExternalPasswordChangeReassert), "old"),
+			digest:     "old",
+			observable: true,
+			want:       want{digest: "old", reason: v1alpha1.ReasonPasswordManaged},
+		},
+		"ChangedAlert": {
+			reason:     "Changed passwords should be reported, keeping the recorded digest.",
+			cr:         role(ptr.To(v1alpha1.ExternalPasswordChangeAlert), "old"),
+			digest:     "new",
+			observable: true,
+			want:       want{digest: "old", reason: v1alpha1.ReasonPasswordChanged},
+		},
+		"ChangedReassert": {
+			reason:     "Changed passwords should be set again if the policy reasserts them.",
+			cr:         role(ptr.To(v1alpha1.ExternalPasswordChangeReassert), "old"),
+			digest:     "new",
+			observable: true,
+			want:       want{reassert: true, digest: "old", reason: v1alpha1.ReasonPasswordChanged},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reassert := checkPassword(tc.cr, tc.digest, tc.observable)
+			if reassert != tc.want.reassert {
+				t.Errorf("\n%s\ncheckPassword(...): want %t, got %t", tc.reason, tc.want.reassert, reassert)
+			}
+			if got := tc.cr.Status.AtProvider.PasswordHashDigest; got != tc.want.digest {
+				t.Errorf("\n%s\ncheckPassword(...): want digest %q, got %q", tc.reason, tc.want.digest, got)
+			}
+			if got := tc.cr.GetCondition(v1alpha1.TypePassword).Reason; got != tc.want.reason {
+				t.Errorf("\n%s\ncheckPassword(...): want reason %q, got %q", tc.reason, tc.want.reason, got)
+			}
+			if got := reassertPassword(tc.cr); got != tc.want.reassert {
+				t.Errorf("\n%s\nreassertPassword(...): want %t, got %t", tc.reason, tc.want.reassert, got)
+			}
+		})
+	}
+}
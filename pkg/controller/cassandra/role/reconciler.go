@@ -19,35 +19,93 @@ package role
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
 	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/audit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/credentials"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/dedup"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/description"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drift"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/events"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/externalname"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/lateinit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/notify"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/ownership"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/parallel"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/passwords"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/profile"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/quota"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/recovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+	"github.com/crossplane-contrib/provider-sql/pkg/features"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
-	"github.com/crossplane/crossplane-runtime/pkg/password"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 const (
-	errTrackPCUsage = "cannot track ProviderConfig usage"
-	errGetPC        = "cannot get ProviderConfig"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret"
-	errNotRole      = "managed resource is not a Role custom resource"
-	errSelectRole   = "cannot select role"
-	errCreateRole   = "cannot create role"
-	errUpdateRole   = "cannot update role"
-	errDropRole     = "cannot drop role"
-	maxConcurrency  = 5
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errConnect           = "cannot connect to cluster"
+	errNotifications     = "cannot configure notifications"
+	errNotRole           = "managed resource is not a Role custom resource"
+	errSelectIdentity    = "cannot select cluster identity"
+	errSelectRole        = "cannot select role"
+	errCreateRole        = "cannot create role"
+	errUpdateRole        = "cannot update role"
+	errDropRole          = "cannot drop role"
+	errRoleExists        = "role already exists and was not created by this resource"
+	errSelectDC          = "cannot select local datacenter"
+	errCheckQuota        = "cannot check ProviderConfig quota"
+	errQuotaExceeded     = "ProviderConfig role quota exceeded"
+	errCheckSecret       = "cannot check connection secret"
+	errSelectDescription = "cannot select role description"
+	errSetDescription    = "cannot set role description"
+	errDropDescription   = "cannot delete role description"
+	errClaimOwner        = "cannot record role owner"
+	errReleaseOwner      = "cannot release role owner"
+	errOwnedByOther      = "role is managed by another Role"
+	errSuperUser         = "ProviderConfig does not allow superuser roles"
+	errRenameForbidden   = "external name changed from %q to %q: annotate the Role with %s=true to rename its role"
+	errRenameRole        = "cannot rename role"
+	maxParallelReads     = 4
+	maxConcurrency       = 5
+)
+
+// Reasons of the events recorded by the Role controller.
+const (
+	// reasonAlreadyExists is recorded when a Role adopts a role that existed
+	// before it was created.
+	reasonAlreadyExists event.Reason = "AlreadyExists"
+
+	// reasonSuperUserForbidden is recorded when a Role is not created or
+	// updated because its ProviderConfig does not allow superuser roles.
+	reasonSuperUserForbidden event.Reason = "SuperUserForbidden"
+
+	// reasonRenamed is recorded when a Role renamed its role after its
+	// external name changed.
+	reasonRenamed event.Reason = "Renamed"
 )
 
 // Setup adds a controller that reconciles Role managed resources.
@@ -55,16 +113,28 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 	name := managed.ControllerName(v1alpha1.RoleGroupKind)
 
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	l := o.Logger.WithValues("controller", name)
+	rec := events.NewRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), mgr.GetClient())
+	a := audit.New(l, rec, audit.IdentityFromConfig(mgr.GetConfig()))
+	// Audit entries are never deduplicated, only the errors of every poll.
+	f := dedup.NewFilter(dedup.DefaultWindow)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RoleGroupVersionKind),
-		managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New}),
-		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithExternalConnecter(recovery.NewConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New, log: dedup.NewLogger(l, f), audit: a, drift: drift.NewRecorder(mgr.GetClient(), l), record: rec, noLateInit: o.Features.Enabled(features.DisableRoleLateInit)}, v1alpha1.RoleKind)),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient())),
+		managed.WithLogger(dedup.NewLogger(l, f)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithRecorder(dedup.NewRecorder(rec, f)),
+		managed.WithConnectionPublishers(secrets.NewPublisher(mgr.GetClient(), mgr.GetScheme())))
 
+	// Connection secrets that were edited or deleted are published again
+	// right away rather than at the next poll, and changed credentials are
+	// used right away too.
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.Role{}).
+		Owns(&corev1.Secret{}, builder.WithPredicates(secrets.Changed())).
+		Watches(&corev1.Secret{}, credentials.EnqueueUsers(mgr.GetClient(), func() resource.ManagedList { return &v1alpha1.RoleList{} }, l), builder.WithPredicates(secrets.Changed())).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrency,
 		}).
@@ -74,7 +144,14 @@ func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
 type connector struct {
 	kube      client.Client
 	usage     resource.Tracker
-	newClient func(creds map[string][]byte, keyspace string) *cassandra.CassandraDB
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+	log       logging.Logger
+	audit     *audit.Auditor
+	drift     *drift.Recorder
+	record    event.Recorder
+
+	// noLateInit stops the spec of Roles from being late initialized.
+	noLateInit bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -92,22 +169,45 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, c.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := c.newClient(d.Credentials, "", cassandra.WithSerialConsistency(pc.Spec.DefaultConsistencySerial), cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithMaxPreparedStatements(pc.Spec.MaxPreparedStatements), cassandra.WithLogger(c.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+	if c.audit != nil {
+		db.SetAuditFn(c.audit.For(cr))
 	}
-
-	db := c.newClient(s.Data, "")
-	return &external{db: db}, nil
+	db.SetExecutionProfiles(profile.Convert(pc.Spec.ExecutionProfiles))
+	db.SetLatencyObserver(latency.Default.Observer(pc.GetName(), v1alpha1.RoleKind, cr.GetName()))
+	db.SetDrainer(drain.Default)
+	ext := &external{db: db, drift: c.drift, kube: c.kube, quota: pc.Spec.Quota, descriptions: description.Table(pc.Spec.Descriptions), owners: ownership.Table(pc.Spec.Owners), passwords: passwords.NewGenerator(pc.Spec.Passwords, nil), record: c.record, allowSuperUser: ptr.Deref(pc.Spec.AllowSuperuserRoles, true), noLateInit: c.noLateInit}
+	if pc.Spec.Notifications == nil {
+		return ext, nil
+	}
+	sink, err := notify.NewSink(ctx, c.kube, pc.Spec.Notifications, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errNotifications)
+	}
+	return notify.Wrap(ext, db, sink, v1alpha1.RoleKind, c.log), nil
 }
 
 type external struct {
-	db *cassandra.CassandraDB
+	db           *cassandra.CassandraDB
+	kube         client.Client
+	quota        *v1alpha1.ProviderQuota
+	descriptions *cassandra.DescriptionTable
+	owners       *cassandra.OwnerTable
+	passwords    *passwords.Generator
+	record       event.Recorder
+	drift        *drift.Recorder
+
+	allowSuperUser bool
+	noLateInit     bool
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -116,21 +216,108 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRole)
 	}
 
-	query := "SELECT is_superuser, can_login FROM system_auth.roles WHERE role = ?"
-	var isSuperuser, canLogin bool
-	iter, err := c.db.Query(ctx, query, meta.GetExternalName(cr))
+	c.superUserForbidden(cr)
+
+	// Reads that do not depend on each other are issued concurrently, so
+	// that roles are observed in about one round trip to the cluster.
+	var (
+		r            cassandra.RoleInfo
+		exists       bool
+		roleErr      error
+		cluster, dc  string
+		description  string
+		secretAbsent bool
+		digest       string
+		observable   bool
+	)
+	err := parallel.Run(ctx, maxParallelReads,
+		func(ctx context.Context) error {
+			r, exists, roleErr = c.selectRole(ctx, meta.GetExternalName(cr))
+			return errors.Wrap(roleErr, errSelectRole)
+		},
+		func(ctx context.Context) error {
+			var err error
+			cluster, dc, err = c.db.Identity(ctx)
+			return errors.Wrap(err, errSelectIdentity)
+		},
+		func(ctx context.Context) error {
+			if c.descriptions == nil {
+				return nil
+			}
+			var err error
+			description, _, err = c.db.Description(ctx, *c.descriptions, cassandra.KindRole, meta.GetExternalName(cr))
+			return errors.Wrap(err, errSelectDescription)
+		},
+		func(ctx context.Context) error {
+			var err error
+			secretAbsent, err = c.secretMissing(ctx, cr)
+			return err
+		},
+		func(ctx context.Context) error {
+			if cr.Spec.ForProvider.ExternalPasswordChangePolicy == nil {
+				return nil
+			}
+			var err error
+			digest, observable, err = c.passwordDigest(ctx, meta.GetExternalName(cr))
+			return err
+		},
+	)
+	if cassandra.IsUnauthorized(roleErr) {
+		cr.SetConditions(v1alpha1.RolesUnreadable(fmt.Sprintf(
+			"the credentials of ProviderConfig %q may neither select from system_auth.roles nor list roles: grant them SELECT on system_auth.roles or DESCRIBE on ALL ROLES",
+			cr.GetProviderConfigReference().Name)))
+	}
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errSelectRole)
+		return managed.ExternalObservation{}, err
+	}
+	isSuperuser, canLogin := r.SuperUser, r.Login
+	if cr.GetCondition(v1alpha1.TypeAuthorized).Reason == v1alpha1.ReasonRolesUnreadable {
+		cr.SetConditions(v1alpha1.Authorized())
+	}
+
+	// A role whose external name changed is renamed by the next update as
+	// long as its old role exists, rather than created anew.
+	if from, ok := renamed(cr); ok && !meta.WasDeleted(cr) {
+		if !allowRename(cr) {
+			return managed.ExternalObservation{}, errors.Errorf(errRenameForbidden, from, meta.GetExternalName(cr), v1alpha1.AnnotationAllowRename)
+		}
+		old, err := c.db.RoleExists(ctx, from)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errSelectRole)
+		}
+		if old {
+			if r := cr.Status.AtProvider.Rename; r == nil || r.From != from || r.To != meta.GetExternalName(cr) || r.Phase == v1alpha1.RenameComplete {
+				setRenamePhase(cr, from, v1alpha1.RenamePending)
+			}
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+		}
 	}
-	defer iter.Close()
 
-	if !iter.Scan(&isSuperuser, &canLogin) {
+	if !exists {
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists:   false,
 			ResourceUpToDate: false,
 		}, nil
 	}
 
+	if !adopt(cr.Spec.ForProvider.IfNotExists, cr) {
+		return managed.ExternalObservation{}, errors.New(errRoleExists)
+	}
+
+	// Deleted Roles can be deleted even if they do not own their role, which
+	// they then leave to its owner.
+	if meta.WasDeleted(cr) {
+		owned, err := c.owned(ctx, cr, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if !owned {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+	} else if err := c.claim(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	observed := &v1alpha1.RoleParameters{
 		Privileges: v1alpha1.RolePrivilege{
 			SuperUser: &isSuperuser,
@@ -138,12 +325,40 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		},
 	}
 
+	cr.Status.AtProvider = v1alpha1.RoleObservation{
+		SuperUser:          &isSuperuser,
+		Login:              &canLogin,
+		PasswordRotatedAt:  cr.Status.AtProvider.PasswordRotatedAt,
+		Name:               meta.GetExternalName(cr),
+		Rename:             cr.Status.AtProvider.Rename,
+		PasswordHashDigest: cr.Status.AtProvider.PasswordHashDigest,
+	}
+	if r := cr.Status.AtProvider.Rename; r != nil && r.To == meta.GetExternalName(cr) && r.Phase != v1alpha1.RenameComplete {
+		// The old role was dropped, possibly by someone else.
+		setRenamePhase(cr, r.From, v1alpha1.RenameComplete)
+	}
+	cr.Status.AtProvider.ClusterIdentity = v1alpha1.ClusterIdentity{ClusterName: cluster, Datacenter: dc}
+
+	if c.descriptions != nil {
+		observed.Description = &description
+		cr.Status.AtProvider.Description = description
+	}
+
+	reassert := checkPassword(cr, digest, observable)
+
 	cr.SetConditions(xpv1.Available())
 
+	li := false
+	if !c.noLateInit {
+		li = lateInit(observed, &cr.Spec.ForProvider).Record(v1alpha1.RoleKind)
+	}
+	drifted := drift.Role(observed, &cr.Spec.ForProvider)
+	c.drift.Record(ctx, cr, v1alpha1.RoleKind, drifted)
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: lateInit(observed, &cr.Spec.ForProvider),
-		ResourceUpToDate:        upToDate(observed, &cr.Spec.ForProvider),
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        len(drifted) == 0 && !rotationDue(cr, time.Now()) && !secretAbsent && !reassert,
 	}, nil
 }
 
@@ -153,46 +368,380 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotRole)
 	}
 
-	pw, err := password.Generate()
+	if err := c.checkPolicy(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.checkQuota(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	pw, err := c.passwords.Generate(ctx)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
+	exists, err := c.db.RoleExists(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSelectRole)
+	}
+
 	params := cr.Spec.ForProvider
-	query := fmt.Sprintf("CREATE ROLE IF NOT EXISTS %s WITH SUPERUSER = %t AND LOGIN = %t AND PASSWORD = '%s'", 
-		cassandra.QuoteIdentifier(meta.GetExternalName(cr)), 
-		params.Privileges.SuperUser != nil && *params.Privileges.SuperUser, 
-		params.Privileges.Login != nil && *params.Privileges.Login, 
-		pw)
+	if exists && !adopt(params.IfNotExists, cr) {
+		return managed.ExternalCreation{}, errors.New(errRoleExists)
+	}
 
-	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalCreation{}, errors.New(errCreateRole + ": " + err.Error())
+	if err := c.claim(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
-	connectionDetails := c.db.GetConnectionDetails(meta.GetExternalName(cr), pw)
+	// CREATE ROLE IF NOT EXISTS is not a lightweight transaction and does not
+	// report whether it created the role, so a role created concurrently
+	// after the check above is indistinguishable from one we created. Only
+	// a plain CREATE ROLE, which fails if the role exists, is known to have
+	// set our password.
+	created := false
+	if !exists {
+		err := c.db.Exec(ctx, render.Role(cr, pw))
+		checkAuthorized(cr, err)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateRole)
+		}
+		created = params.IfNotExists != nil && !*params.IfNotExists
+	}
+
+	// The password of a role that existed before may be kept, in which case
+	// it is not published. Roles that were created concurrently, most likely
+	// by another replica of the provider, always converge on our password.
+	keep := exists && ptr.Deref(params.PasswordOnAdoption, v1alpha1.PasswordRotate) == v1alpha1.PasswordKeep
+	if keep {
+		pw = ""
+	}
+	if exists && c.record != nil {
+		msg := fmt.Sprintf("Adopted role %q, which already existed; its password was rotated", meta.GetExternalName(cr))
+		if keep {
+			msg = fmt.Sprintf("Adopted role %q, which already existed; its password was kept and is not published", meta.GetExternalName(cr))
+		}
+		c.record.Event(cr, event.Normal(reasonAlreadyExists, msg))
+	}
+
+	if !created && !keep {
+		// The role existed, or may have been created concurrently by another
+		// replica of the provider with a different password. Converge on
+		// ours so that the connection details we publish are valid.
+		query := cql.AlterRole(meta.GetExternalName(cr)).Password(pw).String()
+		err := c.db.Exec(ctx, query)
+		checkAuthorized(cr, err)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errUpdateRole)
+		}
+	}
+
+	if err := c.setDescription(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if pw != "" {
+		cr.Status.AtProvider.PasswordHashDigest = ""
+	}
+	connectionDetails, err := c.connectionDetails(ctx, cr, pw)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
 	return managed.ExternalCreation{
 		ConnectionDetails: connectionDetails,
 	}, nil
 }
 
+// claim records the supplied Role as the owner of its role, unless another
+// Role is recorded as its owner, in which case it returns an error and sets
+// the Ownership condition.
+func (c *external) claim(ctx context.Context, cr *v1alpha1.Role) error {
+	if c.owners == nil {
+		return nil
+	}
+
+	o, err := c.db.ClaimOwner(ctx, *c.owners, cassandra.KindRole, meta.GetExternalName(cr), cassandra.Owner{UID: string(cr.GetUID()), Resource: cr.GetName()})
+	if err != nil {
+		return errors.Wrap(err, errClaimOwner)
+	}
+	if o.UID != string(cr.GetUID()) {
+		cr.SetConditions(v1alpha1.ConflictingOwnership(fmt.Sprintf("role %q is recorded as managed by Role %q (UID %s) in %s.%s",
+			meta.GetExternalName(cr), o.Resource, o.UID, c.owners.Keyspace, c.owners.Table)))
+		return errors.New(errOwnedByOther)
+	}
+
+	if cr.GetCondition(v1alpha1.TypeOwnership).Status != corev1.ConditionUnknown {
+		cr.SetConditions(v1alpha1.ExclusiveOwnership())
+	}
+	return nil
+}
+
+// owned returns whether the supplied Role may drop the named role, i.e.
+// whether no other Role is recorded as its owner.
+func (c *external) owned(ctx context.Context, cr *v1alpha1.Role, name string) (bool, error) {
+	if c.owners == nil {
+		return true, nil
+	}
+	o, exists, err := c.db.Owner(ctx, *c.owners, cassandra.KindRole, name)
+	if err != nil {
+		return false, errors.Wrap(err, errClaimOwner)
+	}
+	return !exists || o.UID == string(cr.GetUID()), nil
+}
+
+// setDescription stores the description of the supplied role if it has one
+// that differs from the observed one and descriptions are stored.
+func (c *external) setDescription(ctx context.Context, cr *v1alpha1.Role) error {
+	d := cr.Spec.ForProvider.Description
+	if c.descriptions == nil || d == nil || *d == cr.Status.AtProvider.Description {
+		return nil
+	}
+	err := c.db.SetDescription(ctx, *c.descriptions, cassandra.KindRole, meta.GetExternalName(cr), *d, cr.GetName())
+	return errors.Wrap(err, errSetDescription)
+}
+
+// connectionDetails returns the connection details of the supplied role with
+// the supplied password, in the formats it requests.
+func (c *external) connectionDetails(ctx context.Context, cr *v1alpha1.Role, pw string) (managed.ConnectionDetails, error) {
+	params := cr.Spec.ForProvider
+	connectionDetails := c.db.GetConnectionDetails(meta.GetExternalName(cr), pw)
+
+	// Without a password only the formats that do not embed it are
+	// published.
+	f := make([]string, 0, len(params.ConnectionDetailsFormat))
+	for _, format := range params.ConnectionDetailsFormat {
+		if pw != "" || format == v1alpha1.ConnectionDetailsFormat(cassandra.FormatContactPoints) {
+			f = append(f, string(format))
+		}
+	}
+	if pw == "" {
+		delete(connectionDetails, xpv1.ResourceCredentialsSecretPasswordKey)
+	}
+
+	if len(f) > 0 {
+		dc, err := c.db.LocalDatacenter(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errSelectDC)
+		}
+		connectionDetails = cassandra.FormatConnectionDetails(connectionDetails, dc, f...)
+	}
+	if keys := params.ConnectionSecretKeys; keys != nil {
+		connectionDetails = cassandra.WithKeyNames(connectionDetails, keyNames(keys))
+	}
+	return connectionDetails, nil
+}
+
+// selectRole returns the named role and whether it exists.
+func (c *external) selectRole(ctx context.Context, name string) (cassandra.RoleInfo, bool, error) {
+	r := cassandra.RoleInfo{Name: name}
+	iter, err := c.db.Query(ctx, "SELECT is_superuser, can_login FROM system_auth.roles WHERE role = ?", name)
+	if err != nil {
+		return cassandra.RoleInfo{}, false, err
+	}
+	exists := iter.Scan(&r.SuperUser, &r.Login)
+	err = iter.Close()
+	if cassandra.IsUnauthorized(err) {
+		// Some clusters deny SELECT on system_auth even to admin roles.
+		return c.db.DescribeRole(ctx, name)
+	}
+	return r, exists, err
+}
+
+// secretMissing reports whether the connection secret of the supplied role
+// lacks any of its standard connection details. Only the username is
+// compared, since the password is not retained and the endpoint may change
+// when it is resolved from a Service. The password is not required of roles
+// whose password is kept when they are adopted, since it is not published.
+func (c *external) secretMissing(ctx context.Context, cr *v1alpha1.Role) (bool, error) {
+	want := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(meta.GetExternalName(cr)),
+		xpv1.ResourceCredentialsSecretPasswordKey: nil,
+		xpv1.ResourceCredentialsSecretEndpointKey: nil,
+		xpv1.ResourceCredentialsSecretPortKey:     nil,
+	}
+	if ptr.Deref(cr.Spec.ForProvider.PasswordOnAdoption, v1alpha1.PasswordRotate) == v1alpha1.PasswordKeep {
+		delete(want, xpv1.ResourceCredentialsSecretPasswordKey)
+	}
+	missing, err := secrets.Missing(ctx, c.kube, cr, want)
+	return missing, errors.Wrap(err, errCheckSecret)
+}
+
+// rotationDue returns whether the password of the supplied role should be
+// rotated at the supplied time. The password is due if it was neither rotated
+// nor set by the provider when it created the role.
+func rotationDue(cr *v1alpha1.Role, now time.Time) bool {
+	every := cr.Spec.ForProvider.RotatePasswordEvery
+	if every == nil || every.Duration <= 0 {
+		return false
+	}
+
+	last := meta.GetExternalCreateSucceeded(cr)
+	if r := cr.Status.AtProvider.PasswordRotatedAt; r != nil && r.After(last) {
+		last = r.Time
+	}
+	return last.IsZero() || now.Sub(last) >= every.Duration
+}
+
+// keyNames maps the standard connection detail keys to the additional key
+// names they are published under.
+func keyNames(keys *v1alpha1.ConnectionSecretKeys) map[string]string {
+	names := map[string]string{}
+	for std, name := range map[string]*string{
+		xpv1.ResourceCredentialsSecretUserKey:     keys.UsernameKeyName,
+		xpv1.ResourceCredentialsSecretPasswordKey: keys.PasswordKeyName,
+		xpv1.ResourceCredentialsSecretEndpointKey: keys.EndpointKeyName,
+		xpv1.ResourceCredentialsSecretPortKey:     keys.PortKeyName,
+	} {
+		if name != nil {
+			names[std] = *name
+		}
+	}
+	return names
+}
+
+// adopt returns whether the supplied existing role may be adopted by the
+// supplied resource, i.e. whether it either allows adoption or created the role
+// itself.
+func adopt(ifNotExists *bool, cr resource.Managed) bool {
+	return ifNotExists == nil || *ifNotExists || !meta.GetExternalCreateSucceeded(cr).IsZero()
+}
+
+// checkQuota returns an error and sets the Quota condition if creating the
+// role would exceed the quota of its ProviderConfig.
+func (c *external) checkQuota(ctx context.Context, cr *v1alpha1.Role) error {
+	if c.quota == nil || c.quota.MaxRoles == nil {
+		return nil
+	}
+
+	ok, err := quota.Within(ctx, c.kube, cr, v1alpha1.RoleKind, c.quota.MaxRoles)
+	if err != nil {
+		return errors.Wrap(err, errCheckQuota)
+	}
+	if !ok {
+		cr.SetConditions(v1alpha1.QuotaExceeded(fmt.Sprintf("ProviderConfig %q allows at most %d roles", cr.GetProviderConfigReference().Name, *c.quota.MaxRoles)))
+		return errors.New(errQuotaExceeded)
+	}
+
+	cr.SetConditions(v1alpha1.WithinQuota())
+	return nil
+}
+
+// superUserForbidden reports whether the supplied role is a superuser although
+// its ProviderConfig does not allow superuser roles, and sets the Policy
+// condition of ProviderConfigs that do not.
+func (c *external) superUserForbidden(cr *v1alpha1.Role) bool {
+	if c.allowSuperUser {
+		return false
+	}
+	if !ptr.Deref(cr.Spec.ForProvider.Privileges.SuperUser, false) {
+		cr.SetConditions(v1alpha1.WithinPolicy())
+		return false
+	}
+	cr.SetConditions(v1alpha1.SuperUserForbidden(fmt.Sprintf("ProviderConfig %q does not allow superuser roles: set superUser to false", cr.GetProviderConfigReference().Name)))
+	return true
+}
+
+// checkPolicy returns an error and records an event if the supplied role may
+// not be created or updated because of the policies of its ProviderConfig.
+func (c *external) checkPolicy(cr *v1alpha1.Role) error {
+	if !c.superUserForbidden(cr) {
+		return nil
+	}
+	err := errors.New(errSuperUser)
+	if c.record != nil {
+		c.record.Event(cr, event.Warning(reasonSuperUserForbidden, err))
+	}
+	return err
+}
+
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mg.(*v1alpha1.Role)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotRole)
 	}
 
+	if err := c.checkPolicy(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if from, ok := renamed(cr); ok {
+		return c.rename(ctx, cr, from)
+	}
+
+	// Privileges that are not specified are left as they are.
 	params := cr.Spec.ForProvider
-	query := fmt.Sprintf("ALTER ROLE %s WITH SUPERUSER = %t AND LOGIN = %t", 
-		cassandra.QuoteIdentifier(meta.GetExternalName(cr)), 
-		params.Privileges.SuperUser != nil && *params.Privileges.SuperUser, 
-		params.Privileges.Login != nil && *params.Privileges.Login)
+	alter := cql.AlterRole(meta.GetExternalName(cr))
+	if params.Privileges.SuperUser != nil {
+		alter.SuperUser(*params.Privileges.SuperUser)
+	}
+	if params.Privileges.Login != nil {
+		alter.Login(*params.Privileges.Login)
+	}
 
-	if err := c.db.Exec(ctx, query); err != nil {
-		return managed.ExternalUpdate{}, errors.New(errUpdateRole + ": " + err.Error())
+	// The password is not retained, so connection details that are missing
+	// from the connection secret can only be restored with a new one.
+	missing, err := c.secretMissing(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	now := time.Now()
+	var pw string
+	reasserted := false
+	switch {
+	case missing || rotationDue(cr, now):
+		if pw, err = c.passwords.Generate(ctx); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		alter.Password(pw)
+	case reassertPassword(cr):
+		// A password that was changed by others is set to the published one
+		// again, so that its users need not pick up a new one.
+		if pw, err = c.publishedPassword(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		reasserted = pw != ""
+		if !reasserted {
+			if pw, err = c.passwords.Generate(ctx); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+		}
+		alter.Password(pw)
+	}
+
+	if err := c.setDescription(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if alter.Empty() {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	err = c.db.Exec(ctx, alter.String())
+	checkAuthorized(cr, err)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRole)
+	}
+
+	if pw == "" {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// The salted hash of the new password is recorded by the next
+	// observation.
+	cr.Status.AtProvider.PasswordHashDigest = ""
+	if reasserted {
+		return managed.ExternalUpdate{}, nil
 	}
 
-	return managed.ExternalUpdate{}, nil
+	cr.Status.AtProvider.PasswordRotatedAt = &metav1.Time{Time: now}
+	connectionDetails, err := c.connectionDetails(ctx, cr, pw)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	return managed.ExternalUpdate{ConnectionDetails: connectionDetails}, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -201,35 +750,59 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRole)
 	}
 
-	query := fmt.Sprintf("DROP ROLE IF EXISTS %s", cassandra.QuoteIdentifier(meta.GetExternalName(cr)))
-	if err := c.db.Exec(ctx, query); err != nil {
-		return errors.New(errDropRole + ": " + err.Error())
+	owned, err := c.owned(ctx, cr, meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	if !owned {
+		// The role is left to the Role that owns it.
+		return nil
 	}
 
-	return nil
-}
+	query := cql.DropRole(meta.GetExternalName(cr))
+	if err := c.db.Exec(ctx, query); err != nil {
+		return errors.Wrap(err, errDropRole)
+	}
 
-func upToDate(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) bool {
-	if observed.Privileges.SuperUser == nil || desired.Privileges.SuperUser == nil || *observed.Privileges.SuperUser != *desired.Privileges.SuperUser {
-		return false
+	if c.owners != nil {
+		if err := c.db.ReleaseOwner(ctx, *c.owners, cassandra.KindRole, meta.GetExternalName(cr), string(cr.GetUID())); err != nil {
+			return errors.Wrap(err, errReleaseOwner)
+		}
 	}
-	if observed.Privileges.Login == nil || desired.Privileges.Login == nil || *observed.Privileges.Login != *desired.Privileges.Login {
-		return false
+
+	if c.descriptions != nil {
+		err := c.db.DeleteDescription(ctx, *c.descriptions, cassandra.KindRole, meta.GetExternalName(cr))
+		return errors.Wrap(err, errDropDescription)
 	}
-	return true
-}
 
-func lateInit(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) bool {
-	li := false
+	return nil
+}
 
-	if desired.Privileges.SuperUser == nil {
-		desired.Privileges.SuperUser = observed.Privileges.SuperUser
-		li = true
+// checkAuthorized sets the Authorized condition when err was returned because
+// the ProviderConfig credentials are not allowed to create or alter a
+// SUPERUSER role, and clears it once a statement succeeds.
+func checkAuthorized(cr *v1alpha1.Role, err error) {
+	if err == nil {
+		if cr.GetCondition(v1alpha1.TypeAuthorized).Status != corev1.ConditionUnknown {
+			cr.SetConditions(v1alpha1.Authorized())
+		}
+		return
 	}
-	if desired.Privileges.Login == nil {
-		desired.Privileges.Login = observed.Privileges.Login
-		li = true
+
+	superUser := cr.Spec.ForProvider.Privileges.SuperUser != nil && *cr.Spec.ForProvider.Privileges.SuperUser
+	wasSuperUser := cr.Status.AtProvider.SuperUser != nil && *cr.Status.AtProvider.SuperUser
+	if !cassandra.IsUnauthorized(err) || (!superUser && !wasSuperUser) {
+		return
 	}
 
-	return li
+	cr.SetConditions(v1alpha1.SuperUserRequired(fmt.Sprintf(
+		"the credentials of ProviderConfig %q are not allowed to manage SUPERUSER roles: use superuser credentials or set superUser to false",
+		cr.GetProviderConfigReference().Name)))
+}
+
+func lateInit(observed *v1alpha1.RoleParameters, desired *v1alpha1.RoleParameters) *lateinit.Fields {
+	f := lateinit.New(desired.LateInitializePolicy)
+	lateinit.Pointer(f, "superUser", &desired.Privileges.SuperUser, observed.Privileges.SuperUser)
+	lateinit.Pointer(f, "login", &desired.Privileges.Login, observed.Privileges.Login)
+	return f
 }
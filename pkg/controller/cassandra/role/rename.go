@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// renamed returns the name the role of the supplied Role had when it was last
+// observed, if its external name changed since.
+func renamed(cr *v1alpha1.Role) (string, bool) {
+	from := cr.Status.AtProvider.Name
+	return from, from != "" && from != meta.GetExternalName(cr)
+}
+
+// allowRename returns whether the supplied Role may rename its role.
+func allowRename(cr *v1alpha1.Role) bool {
+	return cr.GetAnnotations()[v1alpha1.AnnotationAllowRename] == "true"
+}
+
+// setRenamePhase records that the role of the supplied Role entered the
+// supplied phase of being renamed from the supplied name to its external
+// name.
+func setRenamePhase(cr *v1alpha1.Role, from string, phase v1alpha1.RenamePhase) {
+	cr.Status.AtProvider.Rename = &v1alpha1.RoleRename{
+		From:               from,
+		To:                 meta.GetExternalName(cr),
+		Phase:              phase,
+		LastTransitionTime: &metav1.Time{Time: time.Now()},
+	}
+}
+
+// rename the role of the supplied Role from the supplied name to its external
+// name: the role is created under its new name with a new password, granted
+// what the old role was granted and to the roles it was granted to, and the
+// old role is dropped. Every phase may be repeated, so a rename that failed
+// is resumed by the next update.
+func (c *external) rename(ctx context.Context, cr *v1alpha1.Role, from string) (managed.ExternalUpdate, error) {
+	to := meta.GetExternalName(cr)
+	grants, old, err := c.db.Grants(ctx, from)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectRole)
+	}
+	if !old {
+		// The old role was dropped since it was observed.
+		return managed.ExternalUpdate{}, nil
+	}
+
+	exists, err := c.db.RoleExists(ctx, to)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSelectRole)
+	}
+	// A role of the new name that existed before the rename started is only
+	// taken over if the Role adopts existing roles.
+	pending := cr.Status.AtProvider.Rename == nil || cr.Status.AtProvider.Rename.Phase == v1alpha1.RenamePending
+	if exists && pending && !adopt(cr.Spec.ForProvider.IfNotExists, cr) {
+		return managed.ExternalUpdate{}, errors.New(errRoleExists)
+	}
+	if err := c.claim(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	setRenamePhase(cr, from, v1alpha1.RenameCreating)
+	pw, err := c.passwords.Generate(ctx)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	stmts := []string{cql.AlterRole(to).Password(pw).String()}
+	if !exists {
+		stmts[0] = render.Role(cr, pw)
+	}
+	for _, stmt := range stmts {
+		err := c.db.Exec(ctx, stmt)
+		checkAuthorized(cr, err)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRenameRole)
+		}
+	}
+
+	setRenamePhase(cr, from, v1alpha1.RenameMigrating)
+	if stmts, err = render.RoleMigration(grants, to); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRenameRole)
+	}
+	for _, stmt := range stmts {
+		if err := c.db.Exec(ctx, stmt); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRenameRole)
+		}
+	}
+
+	setRenamePhase(cr, from, v1alpha1.RenameDropping)
+	if err := c.dropRenamed(ctx, cr, from); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	setRenamePhase(cr, from, v1alpha1.RenameComplete)
+	cr.Status.AtProvider.Name = to
+	cr.Status.AtProvider.PasswordRotatedAt = &metav1.Time{Time: time.Now()}
+	cr.Status.AtProvider.PasswordHashDigest = ""
+	if c.record != nil {
+		c.record.Event(cr, event.Normal(reasonRenamed, fmt.Sprintf("Renamed role %q to %q", from, to)))
+	}
+
+	// The description is set for the new name by the next update, since
+	// none is observed for it yet.
+	connectionDetails, err := c.connectionDetails(ctx, cr, pw)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	return managed.ExternalUpdate{ConnectionDetails: connectionDetails}, nil
+}
+
+// dropRenamed drops the named old role of the supplied Role, its owner record
+// and its description, unless another Role owns it.
+func (c *external) dropRenamed(ctx context.Context, cr *v1alpha1.Role, from string) error {
+	owned, err := c.owned(ctx, cr, from)
+	if err != nil || !owned {
+		return err
+	}
+	if err := c.db.Exec(ctx, cql.DropRole(from)); err != nil {
+		return errors.Wrap(err, errDropRole)
+	}
+	if c.owners != nil {
+		if err := c.db.ReleaseOwner(ctx, *c.owners, cassandra.KindRole, from, string(cr.GetUID())); err != nil {
+			return errors.Wrap(err, errReleaseOwner)
+		}
+	}
+	if c.descriptions != nil {
+		return errors.Wrap(c.db.DeleteDescription(ctx, *c.descriptions, cassandra.KindRole, from), errDropDescription)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const errRenameRejected = "cannot change the external name of a Role from %q to %q: its role would be orphaned and a new one created with a new password; annotate the Role with %s=true to rename its role instead"
+
+// +kubebuilder:webhook:path=/validate-cassandra-cql-crossplane-io-v1alpha1-role,mutating=false,failurePolicy=fail,sideEffects=None,groups=cassandra.cql.crossplane.io,resources=roles,verbs=update,versions=v1alpha1,name=roles.cassandra.cql.crossplane.io,admissionReviewVersions=v1
+
+// SetupWebhook adds a webhook that rejects changes of the external name of
+// Roles that do not allow renaming their role.
+func SetupWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Role{}).
+		WithValidator(&validator{}).
+		Complete()
+}
+
+// validator validates changes of Roles.
+type validator struct{}
+
+func (v *validator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate rejects changes of an external name that was set before,
+// unless the Role allows renaming its role.
+func (v *validator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, ok := oldObj.(*v1alpha1.Role)
+	if !ok {
+		return nil, errors.New(errNotRole)
+	}
+	n, ok := newObj.(*v1alpha1.Role)
+	if !ok {
+		return nil, errors.New(errNotRole)
+	}
+
+	from, to := meta.GetExternalName(o), meta.GetExternalName(n)
+	if from == "" || from == to || allowRename(n) {
+		return nil, nil
+	}
+	return nil, errors.Errorf(errRenameRejected, from, to, v1alpha1.AnnotationAllowRename)
+}
+
+func (v *validator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
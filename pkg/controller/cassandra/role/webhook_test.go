@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestValidateUpdate(t *testing.T) {
+	role := func(name string, annotations map[string]string) *v1alpha1.Role {
+		cr := &v1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "app", Annotations: annotations}}
+		if name != "" {
+			meta.SetExternalName(cr, name)
+		}
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		old    *v1alpha1.Role
+		new    *v1alpha1.Role
+		err    bool
+	}{
+		"Unchanged": {
+			reason: "Updates that keep the external name should be allowed.",
+			old:    role("app", nil),
+			new:    role("app", nil),
+		},
+		"Initialized": {
+			reason: "Setting an external name that was not set before should be allowed.",
+			old:    role("", nil),
+			new:    role("app", nil),
+		},
+		"Renamed": {
+			reason: "Changing the external name should be rejected.",
+			old:    role("app", nil),
+			new:    role("app-v2", nil),
+			err:    true,
+		},
+		"RenameAllowed": {
+			reason: "Changing the external name of a Role that allows renaming should be allowed.",
+			old:    role("app", nil),
+			new:    role("app-v2", map[string]string{v1alpha1.AnnotationAllowRename: "true"}),
+		},
+		"RenameNotAllowed": {
+			reason: "Only an allow-rename annotation of true should allow renaming.",
+			old:    role("app", nil),
+			new:    role("app-v2", map[string]string{v1alpha1.AnnotationAllowRename: "false"}),
+			err:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&validator{}).ValidateUpdate(context.Background(), tc.old, tc.new)
+			if (err != nil) != tc.err {
+				t.Errorf("\n%s\nValidateUpdate(...): want error %t, got %v\n", tc.reason, tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets publishes the connection details of Cassandra managed
+// resources with the metadata configured by their ProviderConfig.
+package secrets
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+const (
+	errGetPC       = "cannot get ProviderConfig"
+	errGetSecret   = "cannot get connection secret"
+	errApplySecret = "cannot create or update connection secret"
+)
+
+// A Publisher publishes connection details to a Secret like the default
+// publisher of the managed reconciler, and additionally applies the labels
+// and annotations of the ProviderConfig of the managed resource to it.
+type Publisher struct {
+	kube   client.Client
+	secret resource.Applicator
+	typer  runtime.ObjectTyper
+}
+
+// NewPublisher returns a Publisher that writes Secrets using the supplied
+// client.
+func NewPublisher(kube client.Client, ot runtime.ObjectTyper) *Publisher {
+	return &Publisher{
+		kube:   kube,
+		secret: resource.NewApplicatorWithRetry(resource.NewAPIPatchingApplicator(kube), resource.IsAPIErrorWrapped, nil),
+		typer:  ot,
+	}
+}
+
+// PublishConnection publishes the supplied connection details to the Secret
+// the supplied resource writes its connection details to. It is a no-op if
+// the Secret already has the supplied data and metadata.
+func (p *Publisher) PublishConnection(ctx context.Context, o resource.ConnectionSecretOwner, c managed.ConnectionDetails) (bool, error) {
+	if o.GetWriteConnectionSecretToReference() == nil {
+		return false, nil
+	}
+
+	s := resource.ConnectionSecretFor(o, resource.MustGetKind(o, p.typer))
+	s.Data = c
+
+	md, err := p.metadata(ctx, o)
+	if err != nil {
+		return false, err
+	}
+	if md != nil {
+		s.SetLabels(md.Labels)
+		s.SetAnnotations(md.Annotations)
+	}
+
+	err = p.secret.Apply(ctx, s,
+		resource.ConnectionSecretMustBeControllableBy(o.GetUID()),
+		resource.AllowUpdateIf(func(current, desired runtime.Object) bool {
+			cs, ds := current.(*corev1.Secret), desired.(*corev1.Secret) //nolint:forcetypeassert // Will always be a secret.
+			return !cmp.Equal(cs.Data, ds.Data, cmpopts.EquateEmpty()) ||
+				!contains(cs.GetLabels(), ds.GetLabels()) ||
+				!contains(cs.GetAnnotations(), ds.GetAnnotations())
+		}),
+	)
+	if resource.IsNotAllowed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errApplySecret)
+	}
+	return true, nil
+}
+
+// UnpublishConnection is a no-op, since connection Secrets are garbage
+// collected with their owner.
+func (p *Publisher) UnpublishConnection(_ context.Context, _ resource.ConnectionSecretOwner, _ managed.ConnectionDetails) error {
+	return nil
+}
+
+// Missing reports whether the connection Secret of the supplied resource lacks
+// any of the supplied connection details, e.g. because it was deleted or
+// edited. Details with an empty value only need to be present, which allows
+// checking for values, such as passwords, that are not retained.
+func Missing(ctx context.Context, kube client.Client, o resource.ConnectionSecretOwner, want managed.ConnectionDetails) (bool, error) {
+	ref := o.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return false, nil
+	}
+
+	s := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if resource.IgnoreNotFound(err) != nil {
+		return false, errors.Wrap(err, errGetSecret)
+	}
+	if err != nil {
+		return true, nil
+	}
+
+	for k, v := range want {
+		have, ok := s.Data[k]
+		if !ok || len(v) > 0 && string(have) != string(v) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Value returns the value of the supplied key of the connection Secret of the
+// supplied resource, or an empty string if the resource publishes no
+// connection details or the Secret or key does not exist.
+func Value(ctx context.Context, kube client.Client, o resource.ConnectionSecretOwner, key string) (string, error) {
+	ref := o.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return "", nil
+	}
+
+	s := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if err != nil {
+		return "", errors.Wrap(resource.IgnoreNotFound(err), errGetSecret)
+	}
+	return string(s.Data[key]), nil
+}
+
+// Changed filters the events of the connection Secrets of managed resources to
+// those that may require the Secrets to be published again, i.e. their data
+// changed or they were deleted. The Secrets the provider publishes itself
+// are created before their owner observes them, so creations are ignored.
+func Changed() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			o, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			n, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			return !cmp.Equal(o.Data, n.Data, cmpopts.EquateEmpty())
+		},
+	}
+}
+
+// metadata returns the connection Secret metadata of the ProviderConfig of
+// the supplied resource, if any.
+func (p *Publisher) metadata(ctx context.Context, o resource.ConnectionSecretOwner) (*v1alpha1.SecretMetadata, error) {
+	mg, ok := o.(resource.Managed)
+	if !ok || mg.GetProviderConfigReference() == nil {
+		return nil, nil
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := p.kube.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+	return pc.Spec.ConnectionSecretMetadata, nil
+}
+
+// contains returns whether have contains every key and value of want.
+func contains(have, want map[string]string) bool {
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
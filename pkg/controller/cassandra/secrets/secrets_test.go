@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func TestPublishConnection(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	s := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	role := &v1alpha1.Role{
+		ObjectMeta: v1.ObjectMeta{Name: "app", UID: "uid"},
+		Spec: v1alpha1.RoleSpec{ResourceSpec: xpv1.ResourceSpec{
+			ProviderConfigReference:          &xpv1.Reference{Name: "default"},
+			WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "ns", Name: "app"},
+		}},
+	}
+	cd := managed.ConnectionDetails{"password": []byte("s3cr3t")}
+	md := &v1alpha1.SecretMetadata{Labels: map[string]string{"team": "data"}, Annotations: map[string]string{"rotation": "90d"}}
+
+	getPC := func(md *v1alpha1.SecretMetadata, secret *corev1.Secret) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ProviderConfig:
+				o.Spec.ConnectionSecretMetadata = md
+			case *corev1.Secret:
+				if secret == nil {
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "app")
+				}
+				secret.DeepCopyInto(o)
+			}
+			return nil
+		}
+	}
+	existing := func(labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace:       "ns",
+				Name:            "app",
+				Labels:          labels,
+				Annotations:     map[string]string{"rotation": "90d"},
+				OwnerReferences: []v1.OwnerReference{meta.AsController(meta.TypedReferenceTo(role, v1alpha1.RoleGroupVersionKind))},
+			},
+			Data: cd,
+		}
+	}
+
+	type want struct {
+		published bool
+		err       error
+		created   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      *v1alpha1.Role
+		kube   *test.MockClient
+		want   want
+	}{
+		"NoSecretRef": {
+			reason: "Nothing should be published if the resource does not write a connection secret.",
+			o:      &v1alpha1.Role{},
+			kube:   &test.MockClient{},
+		},
+		"ErrGetPC": {
+			reason: "An error should be returned if the ProviderConfig cannot be read.",
+			o:      role,
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errGetPC)},
+		},
+		"Create": {
+			reason: "A new secret should carry the metadata of the ProviderConfig.",
+			o:      role,
+			kube: &test.MockClient{
+				MockGet:    getPC(md, nil),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			want: want{published: true, created: true},
+		},
+		"UpToDate": {
+			reason: "A secret with the data and metadata should not be updated.",
+			o:      role,
+			kube: &test.MockClient{
+				MockGet: getPC(md, existing(map[string]string{"team": "data", "other": "kept"})),
+			},
+		},
+		"MissingLabel": {
+			reason: "A secret lacking the metadata of the ProviderConfig should be updated.",
+			o:      role,
+			kube: &test.MockClient{
+				MockGet:   getPC(md, existing(nil)),
+				MockPatch: test.NewMockPatchFn(nil),
+			},
+			want: want{published: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created *corev1.Secret
+			if tc.kube.MockCreate != nil {
+				tc.kube.MockCreate = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					created = obj.(*corev1.Secret)
+					return nil
+				}
+			}
+
+			p := NewPublisher(tc.kube, s)
+			published, err := p.PublishConnection(context.Background(), tc.o, cd)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if published != tc.want.published {
+				t.Errorf("\n%s\nPublishConnection(...): want published %t, got %t\n", tc.reason, tc.want.published, published)
+			}
+			if tc.want.created {
+				if diff := cmp.Diff(md.Labels, created.GetLabels()); diff != "" {
+					t.Errorf("\n%s\nPublishConnection(...): -want labels, +got labels:\n%s\n", tc.reason, diff)
+				}
+				if diff := cmp.Diff(md.Annotations, created.GetAnnotations()); diff != "" {
+					t.Errorf("\n%s\nPublishConnection(...): -want annotations, +got annotations:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	role := &v1alpha1.Role{
+		Spec: v1alpha1.RoleSpec{ResourceSpec: xpv1.ResourceSpec{
+			WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "ns", Name: "app"},
+		}},
+	}
+	want := managed.ConnectionDetails{"username": []byte("app"), "password": nil}
+
+	get := func(data map[string][]byte) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if data == nil {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "app")
+			}
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+
+	type result struct {
+		missing bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		o      *v1alpha1.Role
+		want   result
+	}{
+		"NoSecretRef": {
+			reason: "Nothing should be missing if the resource does not publish connection details",
+			o:      &v1alpha1.Role{},
+		},
+		"ErrGet": {
+			reason: "Errors getting the Secret should be returned",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			o:      role,
+			want:   result{err: errors.Wrap(errBoom, errGetSecret)},
+		},
+		"Deleted": {
+			reason: "Everything should be missing if the Secret was deleted",
+			kube:   &test.MockClient{MockGet: get(nil)},
+			o:      role,
+			want:   result{missing: true},
+		},
+		"KeyRemoved": {
+			reason: "A removed key should be missing",
+			kube:   &test.MockClient{MockGet: get(map[string][]byte{"username": []byte("app")})},
+			o:      role,
+			want:   result{missing: true},
+		},
+		"ValueEdited": {
+			reason: "A value that differs from the wanted one should be missing",
+			kube:   &test.MockClient{MockGet: get(map[string][]byte{"username": []byte("admin"), "password": []byte("pw")})},
+			o:      role,
+			want:   result{missing: true},
+		},
+		"Intact": {
+			reason: "Nothing should be missing if every wanted key is present with the wanted value",
+			kube:   &test.MockClient{MockGet: get(map[string][]byte{"username": []byte("app"), "password": []byte("pw"), "extra": []byte("x")})},
+			o:      role,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			missing, err := Missing(context.Background(), tc.kube, tc.o, want)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nMissing(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.missing, missing); diff != "" {
+				t.Errorf("\n%s\nMissing(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
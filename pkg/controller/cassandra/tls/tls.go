@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tls loads the TLS configuration of Cassandra ProviderConfigs.
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+const (
+	errGetCACert    = "cannot get CA certificate Secret"
+	errNoCACertKey  = "CA certificate Secret does not contain key"
+	errParseCACert  = "cannot parse CA certificate"
+	errNoServerName = "serverName must not be empty"
+
+	errGetClientCert   = "cannot get client certificate Secret"
+	errParseClientCert = "cannot parse client certificate"
+)
+
+// LoadConfig returns the TLS configuration described by cfg, or nil if cfg is
+// nil and connections should not be encrypted.
+func LoadConfig(ctx context.Context, kube client.Client, cfg *v1alpha1.TLSConfig) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	tc := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// gocql verifies node certificates unless this is set.
+		InsecureSkipVerify: cfg.EnableHostVerification != nil && !*cfg.EnableHostVerification, //nolint:gosec // Opted into by the user.
+	}
+
+	if cfg.ServerName != nil {
+		if *cfg.ServerName == "" {
+			return nil, errors.New(errNoServerName)
+		}
+		tc.ServerName = *cfg.ServerName
+	}
+
+	var ca []byte
+	if ref := cfg.ClientCertSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, errors.Wrap(err, errGetClientCert)
+		}
+		cert, err := tls.X509KeyPair(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, errors.Wrap(err, errParseClientCert)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+		ca = s.Data[corev1.ServiceAccountRootCAKey]
+	}
+
+	if cfg.CACert != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: cfg.CACert.Namespace, Name: cfg.CACert.Name}, s); err != nil {
+			return nil, errors.Wrap(err, errGetCACert)
+		}
+		pem, ok := s.Data[cfg.CACert.Key]
+		if !ok {
+			return nil, errors.Errorf("%s %q", errNoCACertKey, cfg.CACert.Key)
+		}
+		ca = pem
+	}
+
+	if ca != nil {
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errParseCACert)
+		}
+	}
+
+	return tc, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+)
+
+func caCert(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cassandra-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestLoadConfig(t *testing.T) {
+	errBoom := errors.New("boom")
+	ca, key := caCert(t)
+	sel := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "crossplane-system"}, Key: "ca.crt"}
+	clientRef := &xpv1.SecretReference{Name: "client-tls", Namespace: "crossplane-system"}
+	secret := func(data map[string][]byte) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+
+	type want struct {
+		serverName string
+		skipVerify bool
+		rootCAs    bool
+		clientCert bool
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		cfg    *v1alpha1.TLSConfig
+		want   *want
+	}{
+		"Disabled": {
+			reason: "No TLS configuration should be returned if TLS is not configured",
+		},
+		"Defaults": {
+			reason: "Node certificates should be verified against the system roots by default",
+			cfg:    &v1alpha1.TLSConfig{},
+			want:   &want{},
+		},
+		"ServerName": {
+			reason: "Node certificates should be verified against the configured server name",
+			cfg:    &v1alpha1.TLSConfig{ServerName: ptr.To("cassandra.example.org"), EnableHostVerification: ptr.To(true)},
+			want:   &want{serverName: "cassandra.example.org"},
+		},
+		"EmptyServerName": {
+			reason: "An empty server name should be rejected rather than defaulted",
+			cfg:    &v1alpha1.TLSConfig{ServerName: ptr.To("")},
+			want:   &want{err: errors.New(errNoServerName)},
+		},
+		"HostVerificationDisabled": {
+			reason: "Node certificates should not be verified if host verification is disabled",
+			cfg:    &v1alpha1.TLSConfig{EnableHostVerification: ptr.To(false)},
+			want:   &want{skipVerify: true},
+		},
+		"ErrGetCACert": {
+			reason: "An error should be returned if the CA certificate Secret can't be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cfg:    &v1alpha1.TLSConfig{CACert: sel},
+			want:   &want{err: errors.Wrap(errBoom, errGetCACert)},
+		},
+		"NoCACertKey": {
+			reason: "An error should be returned if the CA certificate Secret lacks the key",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{})},
+			cfg:    &v1alpha1.TLSConfig{CACert: sel},
+			want:   &want{err: errors.Errorf("%s %q", errNoCACertKey, "ca.crt")},
+		},
+		"InvalidCACert": {
+			reason: "An error should be returned if the CA certificate is not PEM encoded",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{"ca.crt": []byte("nope")})},
+			cfg:    &v1alpha1.TLSConfig{CACert: sel},
+			want:   &want{err: errors.New(errParseCACert)},
+		},
+		"CACert": {
+			reason: "Node certificates should be verified against the configured CA",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{"ca.crt": ca})},
+			cfg:    &v1alpha1.TLSConfig{CACert: sel},
+			want:   &want{rootCAs: true},
+		},
+		"ErrGetClientCert": {
+			reason: "An error should be returned if the client certificate Secret can't be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cfg:    &v1alpha1.TLSConfig{ClientCertSecretRef: clientRef},
+			want:   &want{err: errors.Wrap(errBoom, errGetClientCert)},
+		},
+		"InvalidClientCert": {
+			reason: "An error should be returned if the client certificate Secret lacks a valid key pair",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{"tls.crt": ca})},
+			cfg:    &v1alpha1.TLSConfig{ClientCertSecretRef: clientRef},
+			want:   &want{err: errors.Wrap(errors.New("tls: failed to find any PEM data in key input"), errParseClientCert)},
+		},
+		"ClientCert": {
+			reason: "The client certificate should be presented to the nodes, and its CA should verify the node certificates",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{"tls.crt": ca, "tls.key": key, "ca.crt": ca})},
+			cfg:    &v1alpha1.TLSConfig{ClientCertSecretRef: clientRef},
+			want:   &want{rootCAs: true, clientCert: true},
+		},
+		"ClientCertWithoutCA": {
+			reason: "Node certificates should be verified against the system roots if the client certificate Secret has no CA",
+			kube:   &test.MockClient{MockGet: secret(map[string][]byte{"tls.crt": ca, "tls.key": key})},
+			cfg:    &v1alpha1.TLSConfig{ClientCertSecretRef: clientRef},
+			want:   &want{clientCert: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := LoadConfig(context.Background(), tc.kube, tc.cfg)
+			var wantErr error
+			if tc.want != nil {
+				wantErr = tc.want.err
+			}
+			if diff := cmp.Diff(wantErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nLoadConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want == nil || tc.want.err != nil {
+				if got != nil {
+					t.Errorf("\n%s\nLoadConfig(...): want nil config, got %v", tc.reason, got)
+				}
+				return
+			}
+			w := want{serverName: got.ServerName, skipVerify: got.InsecureSkipVerify, rootCAs: got.RootCAs != nil, clientCert: len(got.Certificates) > 0}
+			if diff := cmp.Diff(*tc.want, w, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nLoadConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
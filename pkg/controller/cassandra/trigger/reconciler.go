@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+
+	"github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	"github.com/crossplane-contrib/provider-sql/pkg/clients/cassandra"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/audit"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/breaker"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/connection"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/credentials"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/dedup"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drain"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/drift"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/events"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/latency"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/notify"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/pacing"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/profile"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/recovery"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/render"
+	"github.com/crossplane-contrib/provider-sql/pkg/controller/cassandra/secrets"
+	cql "github.com/crossplane-contrib/provider-sql/pkg/cql/builder"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xpcontroller "github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errConnect        = "cannot connect to cluster"
+	errNotifications  = "cannot configure notifications"
+	errNotTrigger     = "managed resource is not a Trigger custom resource"
+	errSelectIdentity = "cannot select cluster identity"
+	errNoKeyspace     = "keyspace is not resolved"
+	errSelectTrigger  = "cannot select trigger"
+	errCreateTrigger  = "cannot create trigger"
+	errUpdateTrigger  = "cannot update trigger"
+	errDropTrigger    = "cannot drop trigger"
+	errSchemaAgree    = "deferring schema change"
+	maxConcurrency    = 5
+)
+
+// Setup adds a controller that reconciles Trigger managed resources.
+func Setup(mgr ctrl.Manager, o xpcontroller.Options) error {
+	name := managed.ControllerName(v1alpha1.TriggerGroupKind)
+
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
+	l := o.Logger.WithValues("controller", name)
+	rec := events.NewRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)), mgr.GetClient())
+	a := audit.New(l, rec, audit.IdentityFromConfig(mgr.GetConfig()))
+	// Audit entries are never deduplicated, only the errors of every poll.
+	f := dedup.NewFilter(dedup.DefaultWindow)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TriggerGroupVersionKind),
+		managed.WithExternalConnecter(recovery.NewConnecter(&connector{kube: mgr.GetClient(), usage: t, newClient: cassandra.New, log: dedup.NewLogger(l, f), audit: a, drift: drift.NewRecorder(mgr.GetClient(), l)}, v1alpha1.TriggerKind)),
+		managed.WithLogger(dedup.NewLogger(l, f)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(dedup.NewRecorder(rec, f)),
+		managed.WithConnectionPublishers(secrets.NewPublisher(mgr.GetClient(), mgr.GetScheme())))
+
+	// Resources are reconciled as soon as the credentials of their
+	// ProviderConfig change rather than at the next poll.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Trigger{}).
+		Watches(&corev1.Secret{}, credentials.EnqueueUsers(mgr.GetClient(), func() resource.ManagedList { return &v1alpha1.TriggerList{} }, l), builder.WithPredicates(secrets.Changed())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrency,
+		}).
+		Complete(r)
+}
+
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(creds map[string][]byte, keyspace string, opts ...cassandra.Option) *cassandra.CassandraDB
+	log       logging.Logger
+	audit     *audit.Auditor
+	drift     *drift.Recorder
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Trigger)
+	if !ok {
+		return nil, errors.New(errNotTrigger)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	// Resources back off together while their cluster is unreachable,
+	// rather than each connecting at every poll.
+	b := breaker.Default.For(pc)
+	db, err := connection.Connect(ctx, c.kube, pc, b, func(d connection.Details) (*cassandra.CassandraDB, error) {
+		db := c.newClient(d.Credentials, "", cassandra.WithSerialConsistency(pc.Spec.DefaultConsistencySerial), cassandra.WithTLS(d.TLS), cassandra.WithAuthenticator(d.Authenticator), cassandra.WithMaxPreparedStatements(pc.Spec.MaxPreparedStatements), cassandra.WithLogger(c.log))
+		return db, errors.Wrap(b.Connect(ctx, db, pc), errConnect)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.audit != nil {
+		db.SetAuditFn(c.audit.For(cr))
+	}
+	db.SetExecutionProfiles(profile.Convert(pc.Spec.ExecutionProfiles))
+	db.SetLatencyObserver(latency.Default.Observer(pc.GetName(), v1alpha1.TriggerKind, cr.GetName()))
+	db.SetDrainer(drain.Default)
+	ext := &external{db: db, drift: c.drift, pacer: pacing.Default.For(pc)}
+	if pc.Spec.Notifications == nil {
+		return ext, nil
+	}
+	sink, err := notify.NewSink(ctx, c.kube, pc.Spec.Notifications, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errNotifications)
+	}
+	return notify.Wrap(ext, db, sink, v1alpha1.TriggerKind, c.log), nil
+}
+
+type external struct {
+	db    *cassandra.CassandraDB
+	drift *drift.Recorder
+	pacer *pacing.Pacer
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Trigger)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTrigger)
+	}
+
+	if cr.Spec.ForProvider.Keyspace == nil {
+		if meta.WasDeleted(cr) {
+			// Nothing was created before the keyspace was resolved.
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.SetConditions(v1alpha1.ReferencesUnresolved(errNoKeyspace))
+		return managed.ExternalObservation{}, errors.New(errNoKeyspace)
+	}
+
+	query := "SELECT options FROM system_schema.triggers WHERE keyspace_name = ? AND table_name = ? AND trigger_name = ?"
+	iter, err := c.db.Query(ctx, query, *cr.Spec.ForProvider.Keyspace, cr.Spec.ForProvider.Table, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectTrigger)
+	}
+
+	options := map[string]string{}
+	exists := iter.Scan(&options)
+	if err := iter.Close(); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectTrigger)
+	}
+
+	if !exists {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.Class = options["class"]
+	cluster, dc, err := c.db.Identity(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSelectIdentity)
+	}
+	cr.Status.AtProvider.ClusterIdentity = v1alpha1.ClusterIdentity{ClusterName: cluster, Datacenter: dc}
+
+	cr.SetConditions(xpv1.Available())
+
+	var drifted []drift.Field
+	if cr.Status.AtProvider.Class != cr.Spec.ForProvider.Class {
+		drifted = append(drifted, drift.Field{Name: "class", Desired: cr.Spec.ForProvider.Class, Observed: cr.Status.AtProvider.Class})
+	}
+	c.drift.Record(ctx, cr, v1alpha1.TriggerKind, drifted)
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(drifted) == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Trigger)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTrigger)
+	}
+
+	if cr.Spec.ForProvider.Keyspace == nil {
+		return managed.ExternalCreation{}, errors.New(errNoKeyspace)
+	}
+
+	if err := c.pacer.Check(ctx, c.db); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.db.CheckSchemaAgreement(ctx); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSchemaAgree)
+	}
+
+	if err := c.db.Exec(ctx, render.Trigger(cr)); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTrigger)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Trigger)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTrigger)
+	}
+
+	if cr.Spec.ForProvider.Keyspace == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoKeyspace)
+	}
+
+	if err := c.pacer.Check(ctx, c.db); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.db.CheckSchemaAgreement(ctx); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSchemaAgree)
+	}
+
+	// Triggers cannot be altered, so the trigger is recreated with the
+	// desired class.
+	if err := c.db.Exec(ctx, dropQuery(cr)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTrigger)
+	}
+	if err := c.db.Exec(ctx, render.Trigger(cr)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTrigger)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Trigger)
+	if !ok {
+		return errors.New(errNotTrigger)
+	}
+
+	if cr.Spec.ForProvider.Keyspace == nil {
+		// Nothing was created before the keyspace was resolved.
+		return nil
+	}
+
+	if err := c.db.Exec(ctx, dropQuery(cr)); err != nil {
+		return errors.Wrap(err, errDropTrigger)
+	}
+
+	return nil
+}
+
+func dropQuery(cr *v1alpha1.Trigger) string {
+	return cql.DropTrigger(meta.GetExternalName(cr), *cr.Spec.ForProvider.Keyspace, cr.Spec.ForProvider.Table)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builder builds CQL statements. Identifiers are always quoted and
+// literals always escaped, so callers never format user input into CQL
+// themselves.
+package builder
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QuoteIdentifier quotes an identifier, escaping embedded double quotes.
+// Quoted identifiers are case sensitive and may be reserved words, so the
+// quoted form always refers to exactly the name stored in the system tables.
+func QuoteIdentifier(id string) string {
+	return `"` + strings.ReplaceAll(id, `"`, `""`) + `"`
+}
+
+// QuoteLiteral quotes a string literal, escaping embedded single quotes.
+func QuoteLiteral(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
+
+// Map returns a map literal of the supplied options, sorted by key.
+func Map(opts map[string]string) string {
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		entries[i] = QuoteLiteral(k) + ": " + QuoteLiteral(opts[k])
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// qualified returns the quoted name of an object within a keyspace.
+func qualified(keyspace, name string) string {
+	return QuoteIdentifier(keyspace) + "." + QuoteIdentifier(name)
+}
+
+func ifNotExists(b bool) string {
+	if b {
+		return "IF NOT EXISTS "
+	}
+	return ""
+}
+
+// with returns the WITH clause of the supplied options, if any.
+func with(opts []string) string {
+	if len(opts) == 0 {
+		return ""
+	}
+	return " WITH " + strings.Join(opts, " AND ")
+}
+
+// A Keyspace builds a CREATE KEYSPACE or ALTER KEYSPACE statement.
+type Keyspace struct {
+	verb        string
+	name        string
+	ifNotExists bool
+	opts        []string
+}
+
+// CreateKeyspace returns a builder of a CREATE KEYSPACE statement.
+func CreateKeyspace(name string) *Keyspace {
+	return &Keyspace{verb: "CREATE", name: name}
+}
+
+// AlterKeyspace returns a builder of an ALTER KEYSPACE statement.
+func AlterKeyspace(name string) *Keyspace {
+	return &Keyspace{verb: "ALTER", name: name}
+}
+
+// IfNotExists sets whether an existing keyspace is left as is rather than
+// the statement failing. It only applies to CREATE KEYSPACE.
+func (k *Keyspace) IfNotExists(b bool) *Keyspace {
+	k.ifNotExists = b
+	return k
+}
+
+// Replication sets the replication strategy class and its options, e.g. the
+// replication_factor of the SimpleStrategy.
+func (k *Keyspace) Replication(class string, opts map[string]string) *Keyspace {
+	r := make(map[string]string, len(opts)+1)
+	for o, v := range opts {
+		r[o] = v
+	}
+	r["class"] = class
+	k.opts = append(k.opts, "replication = "+Map(r))
+	return k
+}
+
+// DurableWrites sets whether writes to the keyspace use the commit log.
+func (k *Keyspace) DurableWrites(b bool) *Keyspace {
+	k.opts = append(k.opts, "durable_writes = "+strconv.FormatBool(b))
+	return k
+}
+
+// String returns the statement.
+func (k *Keyspace) String() string {
+	s := k.verb + " KEYSPACE "
+	if k.verb == "CREATE" {
+		s += ifNotExists(k.ifNotExists)
+	}
+	return s + QuoteIdentifier(k.name) + with(k.opts)
+}
+
+// DropKeyspace returns a DROP KEYSPACE IF EXISTS statement.
+func DropKeyspace(name string) string {
+	return "DROP KEYSPACE IF EXISTS " + QuoteIdentifier(name)
+}
+
+// A Role builds a CREATE ROLE or ALTER ROLE statement.
+type Role struct {
+	verb        string
+	name        string
+	ifNotExists bool
+	opts        []string
+}
+
+// CreateRole returns a builder of a CREATE ROLE statement.
+func CreateRole(name string) *Role {
+	return &Role{verb: "CREATE", name: name}
+}
+
+// AlterRole returns a builder of an ALTER ROLE statement.
+func AlterRole(name string) *Role {
+	return &Role{verb: "ALTER", name: name}
+}
+
+// IfNotExists sets whether an existing role is left as is rather than the
+// statement failing. It only applies to CREATE ROLE.
+func (r *Role) IfNotExists(b bool) *Role {
+	r.ifNotExists = b
+	return r
+}
+
+// SuperUser sets whether the role is a superuser.
+func (r *Role) SuperUser(b bool) *Role {
+	r.opts = append(r.opts, "SUPERUSER = "+strconv.FormatBool(b))
+	return r
+}
+
+// Login sets whether the role may log in.
+func (r *Role) Login(b bool) *Role {
+	r.opts = append(r.opts, "LOGIN = "+strconv.FormatBool(b))
+	return r
+}
+
+// Password sets the password of the role.
+func (r *Role) Password(pw string) *Role {
+	r.opts = append(r.opts, "PASSWORD = "+QuoteLiteral(pw))
+	return r
+}
+
+// Empty returns whether no options were set. ALTER ROLE statements without
+// options are invalid.
+func (r *Role) Empty() bool {
+	return len(r.opts) == 0
+}
+
+// String returns the statement.
+func (r *Role) String() string {
+	s := r.verb + " ROLE "
+	if r.verb == "CREATE" {
+		s += ifNotExists(r.ifNotExists)
+	}
+	return s + QuoteIdentifier(r.name) + with(r.opts)
+}
+
+// DropRole returns a DROP ROLE IF EXISTS statement.
+func DropRole(name string) string {
+	return "DROP ROLE IF EXISTS " + QuoteIdentifier(name)
+}
+
+// Grant returns a GRANT statement of the supplied permission, e.g. SELECT or
+// ALL PERMISSIONS, on the supplied resource, e.g. KEYSPACE "app".
+func Grant(permission, resource, role string) string {
+	return "GRANT " + permission + " ON " + resource + " TO " + QuoteIdentifier(role)
+}
+
+// Revoke returns a REVOKE statement of the supplied permission on the
+// supplied resource.
+func Revoke(permission, resource, role string) string {
+	return "REVOKE " + permission + " ON " + resource + " FROM " + QuoteIdentifier(role)
+}
+
+// GrantRole returns a GRANT statement of the supplied role to the supplied
+// grantee, which thereby holds its permissions.
+func GrantRole(role, grantee string) string {
+	return "GRANT " + QuoteIdentifier(role) + " TO " + QuoteIdentifier(grantee)
+}
+
+// CreateTrigger returns a CREATE TRIGGER IF NOT EXISTS statement of a trigger
+// implemented by the supplied Java class.
+func CreateTrigger(name, keyspace, table, class string) string {
+	return "CREATE TRIGGER IF NOT EXISTS " + QuoteIdentifier(name) + " ON " + qualified(keyspace, table) + " USING " + QuoteLiteral(class)
+}
+
+// DropTrigger returns a DROP TRIGGER IF EXISTS statement.
+func DropTrigger(name, keyspace, table string) string {
+	return "DROP TRIGGER IF EXISTS " + QuoteIdentifier(name) + " ON " + qualified(keyspace, table)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStatements(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		stmt   string
+		want   string
+	}{
+		"QuoteIdentifier": {
+			reason: "Embedded double quotes should be escaped",
+			stmt:   QuoteIdentifier(`my"ks`),
+			want:   `"my""ks"`,
+		},
+		"QuoteLiteral": {
+			reason: "Embedded single quotes should be escaped",
+			stmt:   QuoteLiteral("it's"),
+			want:   `'it''s'`,
+		},
+		"Map": {
+			reason: "Map entries should be sorted by key",
+			stmt:   Map(map[string]string{"dc2": "2", "dc1": "3"}),
+			want:   `{'dc1': '3', 'dc2': '2'}`,
+		},
+		"CreateKeyspace": {
+			reason: "CREATE KEYSPACE should include IF NOT EXISTS and all options",
+			stmt: CreateKeyspace("app").IfNotExists(true).
+				Replication("SimpleStrategy", map[string]string{"replication_factor": "3"}).
+				DurableWrites(true).String(),
+			want: `CREATE KEYSPACE IF NOT EXISTS "app" WITH replication = {'class': 'SimpleStrategy', 'replication_factor': '3'} AND durable_writes = true`,
+		},
+		"AlterKeyspace": {
+			reason: "ALTER KEYSPACE should ignore IF NOT EXISTS",
+			stmt:   AlterKeyspace("app").IfNotExists(true).DurableWrites(false).String(),
+			want:   `ALTER KEYSPACE "app" WITH durable_writes = false`,
+		},
+		"DropKeyspace": {
+			reason: "DROP KEYSPACE should use IF EXISTS",
+			stmt:   DropKeyspace("app"),
+			want:   `DROP KEYSPACE IF EXISTS "app"`,
+		},
+		"CreateRole": {
+			reason: "CREATE ROLE should escape the password",
+			stmt:   CreateRole("app").SuperUser(false).Login(true).Password("p'w").String(),
+			want:   `CREATE ROLE "app" WITH SUPERUSER = false AND LOGIN = true AND PASSWORD = 'p''w'`,
+		},
+		"AlterRole": {
+			reason: "ALTER ROLE should only include the options that were set",
+			stmt:   AlterRole("app").Login(false).String(),
+			want:   `ALTER ROLE "app" WITH LOGIN = false`,
+		},
+		"DropRole": {
+			reason: "DROP ROLE should use IF EXISTS",
+			stmt:   DropRole("app"),
+			want:   `DROP ROLE IF EXISTS "app"`,
+		},
+		"Grant": {
+			reason: "GRANT should quote the role",
+			stmt:   Grant("SELECT", `KEYSPACE "app"`, "reader"),
+			want:   `GRANT SELECT ON KEYSPACE "app" TO "reader"`,
+		},
+		"Revoke": {
+			reason: "REVOKE should quote the role",
+			stmt:   Revoke("ALL PERMISSIONS", "ALL KEYSPACES", "reader"),
+			want:   `REVOKE ALL PERMISSIONS ON ALL KEYSPACES FROM "reader"`,
+		},
+		"GrantRole": {
+			reason: "GRANT of a role should quote both roles",
+			stmt:   GrantRole("readers", "app"),
+			want:   `GRANT "readers" TO "app"`,
+		},
+		"CreateTrigger": {
+			reason: "CREATE TRIGGER should quote the class",
+			stmt:   CreateTrigger("audit", "app", "events", "org.example.Audit"),
+			want:   `CREATE TRIGGER IF NOT EXISTS "audit" ON "app"."events" USING 'org.example.Audit'`,
+		},
+		"DropTrigger": {
+			reason: "DROP TRIGGER should use IF EXISTS",
+			stmt:   DropTrigger("audit", "app", "events"),
+			want:   `DROP TRIGGER IF EXISTS "audit" ON "app"."events"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.stmt); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRoleEmpty(t *testing.T) {
+	if !AlterRole("app").Empty() {
+		t.Errorf("AlterRole(...).Empty(): want true for a role without options")
+	}
+	if AlterRole("app").Login(true).Empty() {
+		t.Errorf("AlterRole(...).Login(...).Empty(): want false for a role with options")
+	}
+}
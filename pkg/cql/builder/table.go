@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"strings"
+)
+
+// Clustering orders.
+const (
+	Ascending  = "ASC"
+	Descending = "DESC"
+)
+
+// A Table builds a CREATE TABLE statement.
+type Table struct {
+	keyspace    string
+	name        string
+	ifNotExists bool
+	columns     []string
+	partition   []string
+	clustering  []string
+	order       []string
+	opts        []string
+}
+
+// CreateTable returns a builder of a CREATE TABLE statement.
+func CreateTable(keyspace, name string) *Table {
+	return &Table{keyspace: keyspace, name: name}
+}
+
+// IfNotExists sets whether an existing table is left as is rather than the
+// statement failing.
+func (t *Table) IfNotExists(b bool) *Table {
+	t.ifNotExists = b
+	return t
+}
+
+// Column adds a column of the supplied CQL type, e.g. frozen<list<text>>.
+// Columns are defined in the order they are added.
+func (t *Table) Column(name, typ string) *Table {
+	t.columns = append(t.columns, QuoteIdentifier(name)+" "+typ)
+	return t
+}
+
+// StaticColumn adds a static column of the supplied CQL type.
+func (t *Table) StaticColumn(name, typ string) *Table {
+	t.columns = append(t.columns, QuoteIdentifier(name)+" "+typ+" STATIC")
+	return t
+}
+
+// PartitionKey adds a column to the partition key.
+func (t *Table) PartitionKey(name, typ string) *Table {
+	t.partition = append(t.partition, QuoteIdentifier(name))
+	return t.Column(name, typ)
+}
+
+// ClusteringKey adds a clustering column with the supplied order, which is
+// ascending if empty.
+func (t *Table) ClusteringKey(name, typ, order string) *Table {
+	if order == "" {
+		order = Ascending
+	}
+	t.clustering = append(t.clustering, QuoteIdentifier(name))
+	t.order = append(t.order, strings.ToUpper(order))
+	return t.Column(name, typ)
+}
+
+// Option adds a table option whose value is CQL, e.g. a literal or a Map.
+func (t *Table) Option(name, value string) *Table {
+	t.opts = append(t.opts, name+" = "+value)
+	return t
+}
+
+// String returns the statement. The clustering order is only specified if
+// any clustering column is descending.
+func (t *Table) String() string {
+	key := append([]string{"(" + strings.Join(t.partition, ", ") + ")"}, t.clustering...)
+	cols := append(append([]string{}, t.columns...), "PRIMARY KEY ("+strings.Join(key, ", ")+")")
+
+	opts := t.opts
+	for _, o := range t.order {
+		if o != Descending {
+			continue
+		}
+		order := make([]string, len(t.clustering))
+		for i := range t.clustering {
+			order[i] = t.clustering[i] + " " + t.order[i]
+		}
+		opts = append([]string{"CLUSTERING ORDER BY (" + strings.Join(order, ", ") + ")"}, opts...)
+		break
+	}
+
+	return "CREATE TABLE " + ifNotExists(t.ifNotExists) + qualified(t.keyspace, t.name) + " (" + strings.Join(cols, ", ") + ")" + with(opts)
+}
+
+// A Type builds a CREATE TYPE statement.
+type Type struct {
+	keyspace    string
+	name        string
+	ifNotExists bool
+	fields      []string
+}
+
+// CreateType returns a builder of a CREATE TYPE statement.
+func CreateType(keyspace, name string) *Type {
+	return &Type{keyspace: keyspace, name: name}
+}
+
+// IfNotExists sets whether an existing type is left as is rather than the
+// statement failing.
+func (t *Type) IfNotExists(b bool) *Type {
+	t.ifNotExists = b
+	return t
+}
+
+// Field adds a field of the supplied CQL type.
+func (t *Type) Field(name, typ string) *Type {
+	t.fields = append(t.fields, QuoteIdentifier(name)+" "+typ)
+	return t
+}
+
+// String returns the statement.
+func (t *Type) String() string {
+	return "CREATE TYPE " + ifNotExists(t.ifNotExists) + qualified(t.keyspace, t.name) + " (" + strings.Join(t.fields, ", ") + ")"
+}
+
+// An Index builds a CREATE INDEX or CREATE CUSTOM INDEX statement.
+type Index struct {
+	keyspace    string
+	table       string
+	name        string
+	target      string
+	ifNotExists bool
+	class       string
+	opts        map[string]string
+}
+
+// CreateIndex returns a builder of a CREATE INDEX statement of the supplied
+// target, which is CQL such as a quoted column name or keys("column").
+func CreateIndex(keyspace, table, name, target string) *Index {
+	return &Index{keyspace: keyspace, table: table, name: name, target: target}
+}
+
+// IfNotExists sets whether an existing index is left as is rather than the
+// statement failing.
+func (i *Index) IfNotExists(b bool) *Index {
+	i.ifNotExists = b
+	return i
+}
+
+// Custom makes the index a custom index implemented by the supplied class,
+// e.g. a storage attached index, with the supplied options.
+func (i *Index) Custom(class string, opts map[string]string) *Index {
+	i.class = class
+	i.opts = opts
+	return i
+}
+
+// String returns the statement.
+func (i *Index) String() string {
+	s := "CREATE INDEX "
+	if i.class != "" {
+		s = "CREATE CUSTOM INDEX "
+	}
+	s += ifNotExists(i.ifNotExists) + QuoteIdentifier(i.name) + " ON " + qualified(i.keyspace, i.table) + " (" + i.target + ")"
+	if i.class == "" {
+		return s
+	}
+	s += " USING " + QuoteLiteral(i.class)
+	if len(i.opts) > 0 {
+		s += " WITH OPTIONS = " + Map(i.opts)
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchemaStatements(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		stmt   string
+		want   string
+	}{
+		"CreateTable": {
+			reason: "Columns should be defined in order, followed by the primary key",
+			stmt: CreateTable("app", "events").IfNotExists(true).
+				PartitionKey("tenant", "uuid").
+				ClusteringKey("at", "timestamp", "").
+				StaticColumn("owner", "text").
+				Column("body", "text").String(),
+			want: `CREATE TABLE IF NOT EXISTS "app"."events" ("tenant" uuid, "at" timestamp, "owner" text STATIC, "body" text, PRIMARY KEY (("tenant"), "at"))`,
+		},
+		"CreateTableDescending": {
+			reason: "The clustering order should be specified before other options if any clustering column is descending",
+			stmt: CreateTable("app", "events").
+				PartitionKey("tenant", "uuid").
+				PartitionKey("day", "date").
+				ClusteringKey("at", "timestamp", "desc").
+				ClusteringKey("id", "timeuuid", Ascending).
+				Option("comment", QuoteLiteral("events")).String(),
+			want: `CREATE TABLE "app"."events" ("tenant" uuid, "day" date, "at" timestamp, "id" timeuuid, PRIMARY KEY (("tenant", "day"), "at", "id")) WITH CLUSTERING ORDER BY ("at" DESC, "id" ASC) AND comment = 'events'`,
+		},
+		"CreateType": {
+			reason: "Fields should be defined in order",
+			stmt:   CreateType("app", "address").IfNotExists(true).Field("street", "text").Field("zip", "int").String(),
+			want:   `CREATE TYPE IF NOT EXISTS "app"."address" ("street" text, "zip" int)`,
+		},
+		"CreateIndex": {
+			reason: "A regular index should not have a class",
+			stmt:   CreateIndex("app", "events", "by_owner", `"owner"`).IfNotExists(true).String(),
+			want:   `CREATE INDEX IF NOT EXISTS "by_owner" ON "app"."events" ("owner")`,
+		},
+		"CreateCustomIndex": {
+			reason: "A custom index should include its class and sorted options",
+			stmt: CreateIndex("app", "events", "by_body", `"body"`).
+				Custom("StorageAttachedIndex", map[string]string{"normalize": "true", "case_sensitive": "false"}).String(),
+			want: `CREATE CUSTOM INDEX "by_body" ON "app"."events" ("body") USING 'StorageAttachedIndex' WITH OPTIONS = {'case_sensitive': 'false', 'normalize': 'true'}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.stmt); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package examples generates example manifests for the managed resource kinds
+// of this provider from their API types.
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	cassandra "github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	mssql "github.com/crossplane-contrib/provider-sql/apis/mssql/v1alpha1"
+	mysql "github.com/crossplane-contrib/provider-sql/apis/mysql/v1alpha1"
+	postgresql "github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+)
+
+const (
+	errMkdir   = "cannot create examples directory"
+	errMarshal = "cannot marshal example"
+	errWrite   = "cannot write example"
+
+	// DefaultProviderConfig is the ProviderConfig examples refer to.
+	DefaultProviderConfig = "default"
+)
+
+var (
+	referenceType = reflect.TypeOf(&xpv1.Reference{})
+	selectorType  = reflect.TypeOf(&xpv1.Selector{})
+)
+
+// values overrides the placeholder used for types whose values are
+// constrained by their CRD schema. Fields of these types are always populated,
+// even when they are optional.
+var values = map[reflect.Type]interface{}{
+	reflect.TypeOf(cassandra.GrantPrivileges{}):  []interface{}{"SELECT"},
+	reflect.TypeOf(mssql.GrantPermissions{}):     []interface{}{"SELECT"},
+	reflect.TypeOf(mysql.GrantPrivileges{}):      []interface{}{"SELECT"},
+	reflect.TypeOf(postgresql.GrantPrivileges{}): []interface{}{"SELECT"},
+}
+
+// referenceKinds maps references whose field name differs from the kind they
+// refer to.
+var referenceKinds = map[string]string{
+	"LoginDatabaseRef": "Database",
+}
+
+// skipReferences are references that are mutually exclusive with others of
+// the same kind, e.g. a PostgreSQL Grant either grants privileges on a
+// Database or membership of a Role.
+var skipReferences = map[string]bool{
+	"MemberOfRef": true,
+}
+
+// Generate writes an example manifest for every managed resource kind
+// registered with the supplied scheme to dir, in a subdirectory per API group.
+// It returns the paths of the written files.
+func Generate(s *runtime.Scheme, dir string) ([]string, error) {
+	gvks := make([]schema.GroupVersionKind, 0)
+	for gvk, t := range s.AllKnownTypes() {
+		if _, ok := reflect.New(t).Interface().(resource.Managed); ok {
+			gvks = append(gvks, gvk)
+		}
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+
+	paths := make([]string, 0, len(gvks))
+	for _, gvk := range gvks {
+		b, err := yaml.Marshal(Example(gvk, s.AllKnownTypes()[gvk]))
+		if err != nil {
+			return nil, errors.Wrap(err, errMarshal)
+		}
+
+		d := filepath.Join(dir, strings.SplitN(gvk.Group, ".", 2)[0])
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, errors.Wrap(err, errMkdir)
+		}
+		p := filepath.Join(d, strings.ToLower(gvk.Kind)+".yaml")
+		if err := os.WriteFile(p, b, 0o644); err != nil { //nolint:gosec // Examples are not sensitive.
+			return nil, errors.Wrap(err, errWrite)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+// Example returns an example of the supplied managed resource kind. Required
+// fields of its parameters are populated with placeholder values, privileges
+// with SELECT, and each reference refers to the example of the referenced
+// kind.
+func Example(gvk schema.GroupVersionKind, t reflect.Type) map[string]interface{} {
+	spec := map[string]interface{}{
+		"providerConfigRef": map[string]interface{}{"name": DefaultProviderConfig},
+	}
+	if st, ok := t.FieldByName("Spec"); ok {
+		if fp, ok := st.Type.FieldByName("ForProvider"); ok {
+			spec["forProvider"] = fields(fp.Type)
+		}
+	}
+
+	return map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata": map[string]interface{}{
+			"name": Name(gvk.Kind),
+			"annotations": map[string]interface{}{
+				meta.AnnotationKeyExternalName: ExternalName(gvk.Kind),
+			},
+		},
+		"spec": spec,
+	}
+}
+
+// Name returns the name of the example of the supplied kind.
+func Name(kind string) string {
+	return "example-" + strings.ToLower(kind)
+}
+
+// ExternalName returns the external name of the example of the supplied kind.
+// It is a valid identifier in every supported database.
+func ExternalName(kind string) string {
+	return "example_" + strings.ToLower(kind)
+}
+
+func fields(t reflect.Type) map[string]interface{} {
+	out := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+
+		switch {
+		case f.Type == referenceType:
+			if skipReferences[f.Name] {
+				continue
+			}
+			kind, ok := referenceKinds[f.Name]
+			if !ok {
+				kind = strings.TrimSuffix(f.Name, "Ref")
+			}
+			out[name] = map[string]interface{}{"name": Name(kind)}
+		case values[f.Type] != nil:
+			out[name] = values[f.Type]
+		case f.Type == selectorType, strings.Contains(opts, "omitempty"):
+			continue
+		case f.Anonymous && strings.Contains(opts, "inline"):
+			for k, v := range fields(f.Type) {
+				out[k] = v
+			}
+		default:
+			out[name] = value(f.Type)
+		}
+	}
+	return out
+}
+
+func value(t reflect.Type) interface{} {
+	if v, ok := values[t]; ok {
+		return v
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds are not used by API types.
+	case reflect.Ptr:
+		return value(t.Elem())
+	case reflect.String:
+		return "example"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return 1
+	case reflect.Bool:
+		return true
+	case reflect.Slice:
+		return []interface{}{value(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"example": value(t.Elem())}
+	case reflect.Struct:
+		return fields(t)
+	}
+	return nil
+}
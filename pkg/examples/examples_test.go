@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cassandra "github.com/crossplane-contrib/provider-sql/apis/cassandra/v1alpha1"
+	postgresql "github.com/crossplane-contrib/provider-sql/apis/postgresql/v1alpha1"
+)
+
+func TestExample(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		gvk    runtime.Object
+		want   map[string]interface{}
+	}{
+		"CassandraGrant": {
+			reason: "Required fields should be populated and references should refer to the examples of their kind",
+			gvk:    &cassandra.Grant{},
+			want: map[string]interface{}{
+				"apiVersion": "cassandra.cql.crossplane.io/v1alpha1",
+				"kind":       "Grant",
+				"metadata": map[string]interface{}{
+					"name":        "example-grant",
+					"annotations": map[string]interface{}{"crossplane.io/external-name": "example_grant"},
+				},
+				"spec": map[string]interface{}{
+					"providerConfigRef": map[string]interface{}{"name": "default"},
+					"forProvider": map[string]interface{}{
+						"privileges":  []interface{}{"SELECT"},
+						"roleRef":     map[string]interface{}{"name": "example-role"},
+						"keyspaceRef": map[string]interface{}{"name": "example-keyspace"},
+					},
+				},
+			},
+		},
+		"PostgreSQLGrant": {
+			reason: "Mutually exclusive references should be skipped",
+			gvk:    &postgresql.Grant{},
+			want: map[string]interface{}{
+				"apiVersion": "postgresql.sql.crossplane.io/v1alpha1",
+				"kind":       "Grant",
+				"metadata": map[string]interface{}{
+					"name":        "example-grant",
+					"annotations": map[string]interface{}{"crossplane.io/external-name": "example_grant"},
+				},
+				"spec": map[string]interface{}{
+					"providerConfigRef": map[string]interface{}{"name": "default"},
+					"forProvider": map[string]interface{}{
+						"privileges":  []interface{}{"SELECT"},
+						"roleRef":     map[string]interface{}{"name": "example-role"},
+						"databaseRef": map[string]interface{}{"name": "example-database"},
+					},
+				},
+			},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := cassandra.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := postgresql.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gvks, _, err := s.ObjectKinds(tc.gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Example(gvks[0], reflect.TypeOf(tc.gvk).Elem())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExample(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := cassandra.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	got, err := Generate(s, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "cassandra", "effectiveaccess.yaml"),
+		filepath.Join(dir, "cassandra", "grant.yaml"),
+		filepath.Join(dir, "cassandra", "keyspace.yaml"),
+		filepath.Join(dir, "cassandra", "role.yaml"),
+		filepath.Join(dir, "cassandra", "trigger.yaml"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nGenerate(...): -want, +got:\n%s\n", diff)
+	}
+	for _, p := range got {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("Generate(...): %s", err)
+		}
+	}
+}
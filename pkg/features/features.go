@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the feature flags of the provider.
+package features
+
+import "github.com/crossplane/crossplane-runtime/pkg/feature"
+
+// Feature flags.
+const (
+	// DisableKeyspaceLateInit stops the spec of Cassandra Keyspaces from
+	// being late initialized. Observed values are still reported in their
+	// status.
+	DisableKeyspaceLateInit feature.Flag = "DisableKeyspaceLateInit"
+
+	// DisableRoleLateInit stops the spec of Cassandra Roles from being late
+	// initialized. Observed values are still reported in their status.
+	DisableRoleLateInit feature.Flag = "DisableRoleLateInit"
+)